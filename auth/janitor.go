@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// SessionJanitor periodically purges expired sessions from a store, so
+// sessions that are never looked up again do not pile up
+type SessionJanitor struct {
+	store    SessionStore
+	interval time.Duration
+	now      func() time.Time
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewSessionJanitor starts purging the store every interval. A zero
+// interval disables purging.
+func NewSessionJanitor(store SessionStore, interval time.Duration) *SessionJanitor {
+	return newSessionJanitor(store, interval, time.Now)
+}
+
+// newSessionJanitor is NewSessionJanitor reading the time from now
+func newSessionJanitor(store SessionStore, interval time.Duration, now func() time.Time) *SessionJanitor {
+	j := &SessionJanitor{
+		store:    store,
+		interval: interval,
+		now:      now,
+		stop:     make(chan struct{}),
+	}
+	if interval > 0 {
+		j.wg.Add(1)
+		go j.run()
+	}
+	return j
+}
+
+// Close stops the janitor and waits for a purge in progress to finish
+func (j *SessionJanitor) Close() {
+	close(j.stop)
+	j.wg.Wait()
+}
+
+// run purges on every tick until Close is called
+func (j *SessionJanitor) run() {
+	defer j.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.purge(j.now())
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+// purge removes the sessions that expired before now
+func (j *SessionJanitor) purge(now time.Time) {
+	removed, err := j.store.PurgeExpired(now)
+	if err != nil {
+		log.Printf("Failed to purge expired sessions: %v", err)
+		return
+	}
+	if removed > 0 {
+		log.Printf("Purged %d expired session(s)", removed)
+	}
+}
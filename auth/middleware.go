@@ -1,7 +1,11 @@
 package auth
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -9,11 +13,38 @@ import (
 const (
 	SessionCookieName = "session_id"
 	UsernameContextKey = "username"
+	TokenContextKey = "api_token"
+	SessionContextKey = "session"
 )
 
-// AuthMiddleware checks for valid session and adds username to context
-func AuthMiddleware(sessionStore *SessionStore) gin.HandlerFunc {
+// AuthMiddleware checks for a valid session or API token and adds the
+// username to context. A request with an Authorization: Bearer header is
+// authenticated by the token alone and acts as the token's principal.
+func AuthMiddleware(sessionStore SessionStore, tokens *Tokens) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if secret, ok := bearerToken(c); ok {
+			token, err := tokens.Authenticate(secret)
+			if errors.Is(err, ErrInvalidToken) {
+				c.JSON(http.StatusUnauthorized, map[string]string{
+					"error": "Invalid or expired API token",
+				})
+				c.Abort()
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, map[string]string{
+					"error": "Failed to check API token",
+				})
+				c.Abort()
+				return
+			}
+
+			c.Set(UsernameContextKey, token.Principal)
+			c.Set(TokenContextKey, token)
+			c.Next()
+			return
+		}
+
 		cookie, err := c.Cookie(SessionCookieName)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, map[string]string{
@@ -32,8 +63,15 @@ func AuthMiddleware(sessionStore *SessionStore) gin.HandlerFunc {
 			return
 		}
 
+		// Renewing a signed session replaces the token, so hand the browser
+		// the new one
+		if session.ID != cookie {
+			c.Writer.Header().Add("Set-Cookie", SessionCookieHeader(session))
+		}
+
 		// Add username to context for handlers to use
 		c.Set(UsernameContextKey, session.Username)
+		c.Set(SessionContextKey, session)
 		c.Next()
 	}
 }
@@ -49,6 +87,47 @@ func GetUsernameFromContext(c *gin.Context) (string, bool) {
 	return usernameStr, ok
 }
 
+// GetTokenFromContext returns the API token a request was authenticated
+// with, or false if it used a session cookie
+func GetTokenFromContext(c *gin.Context) (APIToken, bool) {
+	token, exists := c.Get(TokenContextKey)
+	if !exists {
+		return APIToken{}, false
+	}
+
+	apiToken, ok := token.(APIToken)
+	return apiToken, ok
+}
+
+// GetSessionFromContext returns the session a request was authenticated
+// with, or false if it used an API token
+func GetSessionFromContext(c *gin.Context) (*Session, bool) {
+	session, exists := c.Get(SessionContextKey)
+	if !exists {
+		return nil, false
+	}
+
+	s, ok := session.(*Session)
+	return s, ok
+}
+
+// bearerToken returns the token from an Authorization: Bearer header
+func bearerToken(c *gin.Context) (string, bool) {
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// SessionCookieHeader returns the Set-Cookie header value that hands the
+// session to the browser. The cookie is kept until the session's deadline;
+// the idle timeout is enforced by the store.
+func SessionCookieHeader(session *Session) string {
+	maxAge := int(time.Until(session.Deadline).Seconds())
+	return fmt.Sprintf("%s=%s; Path=/; HttpOnly; Max-Age=%d", SessionCookieName, session.ID, maxAge)
+}
+
 // SetSessionCookie sets the session cookie on the response
 func SetSessionCookie(c *gin.Context, sessionID string, maxAge int) {
 	c.SetCookie(
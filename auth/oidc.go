@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// OIDCConfig configures single sign-on through an OpenID Connect provider
+type OIDCConfig struct {
+	Issuer        string // Issuer URL; its discovery document lists the endpoints
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string // Where the provider sends the browser back, i.e. /oidc/callback
+	UsernameClaim string // ID token claim used as the username, falling back to the subject
+}
+
+// Identity is a user as vouched for by a verified ID token
+type Identity struct {
+	Username string
+	Issuer   string
+	Subject  string
+}
+
+var (
+	// ErrInvalidOIDCState is returned for a callback that does not belong to
+	// a login started by this server, or one that has expired
+	ErrInvalidOIDCState = errors.New("unknown or expired OIDC login")
+
+	// ErrOIDCVerification is returned when the code exchange fails or the ID
+	// token does not verify
+	ErrOIDCVerification = errors.New("OIDC login could not be verified")
+)
+
+// oidcLoginTimeout is how long a user has to complete a login at the provider
+const oidcLoginTimeout = 10 * time.Minute
+
+// pendingLogin is what a login started by AuthCodeURL needs to finish
+type pendingLogin struct {
+	verifier  string // PKCE code verifier
+	nonce     string // Must come back in the ID token
+	expiresAt time.Time
+}
+
+// OIDCProvider runs the authorization code flow with PKCE against an OpenID
+// Connect provider and verifies the resulting ID tokens against its JWKS
+type OIDCProvider struct {
+	oauth         *oauth2.Config
+	verifier      *oidc.IDTokenVerifier
+	usernameClaim string
+
+	mu      sync.Mutex
+	pending map[string]pendingLogin // Map of state -> login in progress
+}
+
+// NewOIDCProvider discovers the provider's endpoints and signing keys
+func NewOIDCProvider(ctx context.Context, cfg OIDCConfig) (*OIDCProvider, error) {
+	provider, err := oidc.NewProvider(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider %s: %w", cfg.Issuer, err)
+	}
+
+	return &OIDCProvider{
+		oauth: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       []string{oidc.ScopeOpenID, "profile", "email"},
+		},
+		verifier:      provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+		usernameClaim: cfg.UsernameClaim,
+		pending:       make(map[string]pendingLogin),
+	}, nil
+}
+
+// AuthCodeURL starts a login. It returns the state, which the caller should
+// also bind to the browser, and the provider URL to redirect the browser to.
+// A non-empty loginHint is passed on to preselect the account.
+func (p *OIDCProvider) AuthCodeURL(loginHint string) (state string, url string, err error) {
+	if state, err = randomHex(16); err != nil {
+		return "", "", err
+	}
+	nonce, err := randomHex(16)
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	now := time.Now()
+	p.mu.Lock()
+	for s, login := range p.pending {
+		if now.After(login.expiresAt) {
+			delete(p.pending, s)
+		}
+	}
+	p.pending[state] = pendingLogin{
+		verifier:  verifier,
+		nonce:     nonce,
+		expiresAt: now.Add(oidcLoginTimeout),
+	}
+	p.mu.Unlock()
+
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier), oidc.Nonce(nonce)}
+	if loginHint != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("login_hint", loginHint))
+	}
+	return state, p.oauth.AuthCodeURL(state, opts...), nil
+}
+
+// Exchange finishes the login identified by state: it redeems the code,
+// verifies the ID token's signature, issuer, audience, expiry and nonce, and
+// returns who it identifies. Each state can be used once.
+func (p *OIDCProvider) Exchange(ctx context.Context, state, code string) (Identity, error) {
+	p.mu.Lock()
+	login, ok := p.pending[state]
+	delete(p.pending, state)
+	p.mu.Unlock()
+	if !ok || time.Now().After(login.expiresAt) {
+		return Identity{}, ErrInvalidOIDCState
+	}
+
+	token, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(login.verifier))
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: code exchange: %v", ErrOIDCVerification, err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return Identity{}, fmt.Errorf("%w: no id_token in token response", ErrOIDCVerification)
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrOIDCVerification, err)
+	}
+	if idToken.Nonce != login.nonce {
+		return Identity{}, fmt.Errorf("%w: nonce mismatch", ErrOIDCVerification)
+	}
+
+	identity := Identity{
+		Username: idToken.Subject,
+		Issuer:   idToken.Issuer,
+		Subject:  idToken.Subject,
+	}
+	if p.usernameClaim != "" {
+		var claims map[string]any
+		if err := idToken.Claims(&claims); err != nil {
+			return Identity{}, fmt.Errorf("%w: %v", ErrOIDCVerification, err)
+		}
+		if username, ok := claims[p.usernameClaim].(string); ok && username != "" {
+			identity.Username = username
+		}
+	}
+	return identity, nil
+}
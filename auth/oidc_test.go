@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"sse-demo/auth/mockidp"
+
+	"golang.org/x/oauth2"
+)
+
+// newTestOIDC starts a mock identity provider and discovers it
+func newTestOIDC(t *testing.T) (*mockidp.Server, *OIDCProvider) {
+	t.Helper()
+
+	idp, err := mockidp.New("test-client", "test-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(idp.Close)
+
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		Issuer:        idp.URL,
+		ClientID:      idp.ClientID,
+		ClientSecret:  idp.ClientSecret,
+		RedirectURL:   "http://app.test/oidc/callback",
+		UsernameClaim: "preferred_username",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return idp, provider
+}
+
+// authorize starts a login and follows it to the provider, returning the
+// state and the code the provider redirects back with
+func authorize(t *testing.T, provider *OIDCProvider, loginHint string) (state string, code string) {
+	t.Helper()
+
+	state, authURL, err := provider.AuthCodeURL(loginHint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorization endpoint answered %d, want a redirect", resp.StatusCode)
+	}
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := callback.Query().Get("state"); got != state {
+		t.Fatalf("provider returned state %q, want %q", got, state)
+	}
+	return state, callback.Query().Get("code")
+}
+
+func TestOIDCDiscovery(t *testing.T) {
+	idp, provider := newTestOIDC(t)
+
+	if got := provider.oauth.Endpoint.AuthURL; got != idp.URL+"/authorize" {
+		t.Errorf("authorization endpoint = %q, want the discovered one", got)
+	}
+	if got := provider.oauth.Endpoint.TokenURL; got != idp.URL+"/token" {
+		t.Errorf("token endpoint = %q, want the discovered one", got)
+	}
+
+	// The discovery document names a different issuer than the one asked for
+	_, err := NewOIDCProvider(context.Background(), OIDCConfig{Issuer: idp.URL + "/other"})
+	if err == nil {
+		t.Error("discovering a provider under the wrong issuer URL succeeded")
+	}
+}
+
+func TestOIDCExchange(t *testing.T) {
+	idp, provider := newTestOIDC(t)
+
+	tests := []struct {
+		name string
+		// setup prepares the provider and may tamper with the login
+		setup   func(state, code *string)
+		wantErr error
+	}{
+		{
+			name:  "valid login",
+			setup: func(state, code *string) {},
+		},
+		{
+			name:    "unknown state",
+			setup:   func(state, code *string) { *state = "not-a-login-we-started" },
+			wantErr: ErrInvalidOIDCState,
+		},
+		{
+			name: "state of another login",
+			setup: func(state, code *string) {
+				// The other login's verifier does not match this code's challenge
+				*state, _ = authorize(t, provider, "alice")
+			},
+			wantErr: ErrOIDCVerification,
+		},
+		{
+			name: "wrong PKCE verifier",
+			setup: func(state, code *string) {
+				provider.mu.Lock()
+				login := provider.pending[*state]
+				login.verifier = oauth2.GenerateVerifier()
+				provider.pending[*state] = login
+				provider.mu.Unlock()
+			},
+			wantErr: ErrOIDCVerification,
+		},
+		{
+			name: "bad nonce",
+			setup: func(state, code *string) {
+				idp.EditClaims = func(claims map[string]any) { claims["nonce"] = "replayed-nonce" }
+			},
+			wantErr: ErrOIDCVerification,
+		},
+		{
+			name:    "bad signature",
+			setup:   func(state, code *string) { idp.ForgeSignature = true },
+			wantErr: ErrOIDCVerification,
+		},
+		{
+			name: "expired ID token",
+			setup: func(state, code *string) {
+				idp.EditClaims = func(claims map[string]any) {
+					claims["iat"] = time.Now().Add(-2 * time.Hour).Unix()
+					claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				}
+			},
+			wantErr: ErrOIDCVerification,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp.EditClaims = nil
+			idp.ForgeSignature = false
+
+			state, code := authorize(t, provider, "alice")
+			tt.setup(&state, &code)
+
+			identity, err := provider.Exchange(context.Background(), state, code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Exchange error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			want := Identity{Username: "alice", Issuer: idp.URL, Subject: "mock|alice"}
+			if identity != want {
+				t.Errorf("identity = %+v, want %+v", identity, want)
+			}
+
+			// Each login completes once
+			if _, err := provider.Exchange(context.Background(), state, code); !errors.Is(err, ErrInvalidOIDCState) {
+				t.Errorf("second Exchange error = %v, want ErrInvalidOIDCState", err)
+			}
+		})
+	}
+}
+
+func TestLinkKeepsIdentitiesApartFromPasswordAccounts(t *testing.T) {
+	accounts := newTestAccounts(t, 0)
+	accounts.Reserve("admin")
+
+	bob := Identity{Username: "bob", Issuer: "https://idp.test", Subject: "1"}
+	steps := []struct {
+		name     string
+		identity Identity
+		want     string
+		wantErr  error
+	}{
+		{"first login creates the account", bob, "bob", nil},
+		{"next login finds it by subject", Identity{Username: "robert", Issuer: bob.Issuer, Subject: bob.Subject}, "bob", nil},
+		{"password account's username", Identity{Username: "alice", Issuer: bob.Issuer, Subject: "2"}, "", ErrUserExists},
+		{"another identity's username", Identity{Username: "bob", Issuer: bob.Issuer, Subject: "3"}, "", ErrUserExists},
+		{"same subject at another issuer", Identity{Username: "bob", Issuer: "https://other.test", Subject: "1"}, "", ErrUserExists},
+		{"reserved username", Identity{Username: "admin", Issuer: bob.Issuer, Subject: "4"}, "", ErrUsernameReserved},
+		{"invalid username", Identity{Username: "mock|carol", Issuer: bob.Issuer, Subject: "5"}, "", ErrInvalidUsername},
+	}
+	for _, step := range steps {
+		got, err := accounts.Link(step.identity)
+		if !errors.Is(err, step.wantErr) || got != step.want {
+			t.Errorf("%s: Link = %q, %v; want %q, %v", step.name, got, err, step.want, step.wantErr)
+		}
+	}
+
+	if err := accounts.Register("bob", "password1"); !errors.Is(err, ErrUserExists) {
+		t.Errorf("registering a linked username: error = %v, want ErrUserExists", err)
+	}
+	if _, err := accounts.Authenticate("bob", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("password login to a linked account: error = %v, want ErrInvalidCredentials", err)
+	}
+}
@@ -0,0 +1,30 @@
+package auth
+
+// Permission is a capability granted to specific users beyond what every
+// authenticated user may do
+type Permission string
+
+const (
+	// PermissionImpersonate allows sending notifications as another user
+	PermissionImpersonate Permission = "impersonate"
+)
+
+// Permissions maps usernames to the permissions granted to them
+type Permissions map[string][]Permission
+
+// Grant gives username the permission
+func (p Permissions) Grant(username string, permission Permission) {
+	if !p.Has(username, permission) {
+		p[username] = append(p[username], permission)
+	}
+}
+
+// Has reports whether username has been granted the permission
+func (p Permissions) Has(username string, permission Permission) bool {
+	for _, granted := range p[username] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
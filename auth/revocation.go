@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// RevocationList records which signed session tokens are no longer valid.
+// Signed tokens are checked without a server-side session, so logging out
+// has to be remembered here until the token would have expired anyway.
+type RevocationList interface {
+	// Revoke invalidates the token with the ID until expiresAt, after which
+	// the token is invalid anyway
+	Revoke(id string, expiresAt time.Time) error
+
+	// RevokeUser invalidates every token of username issued before the
+	// given time, except the one with the ID keep. The cutoff is needed
+	// until expiresAt, when every token it covers has expired.
+	RevokeUser(username string, before time.Time, keep string, expiresAt time.Time) error
+
+	// Revoked reports whether the token with the ID, issued to username at
+	// issuedAt, has been revoked
+	Revoked(id string, username string, issuedAt time.Time) (bool, error)
+
+	// Purge forgets the IDs and cutoffs whose tokens expired before now,
+	// returning how many were removed
+	Purge(now time.Time) (int, error)
+}
+
+// userRevocation is a RevokeUser cutoff
+type userRevocation struct {
+	Before    time.Time `json:"before"`
+	Keep      string    `json:"keep,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// revokes reports whether the cutoff covers a token. A token issued at the
+// very instant of the cutoff, such as one created by the request that made
+// it, is not covered.
+func (u userRevocation) revokes(id string, issuedAt time.Time) bool {
+	return id != u.Keep && issuedAt.Before(u.Before)
+}
+
+// MemoryRevocationList is a RevocationList kept in process memory. A
+// restart forgets it, making logged out tokens valid again until they expire.
+type MemoryRevocationList struct {
+	mu    sync.Mutex
+	ids   map[string]time.Time      // Map of token ID -> when it expires
+	users map[string]userRevocation // Map of username -> cutoff
+}
+
+// NewMemoryRevocationList creates a new in-memory revocation list
+func NewMemoryRevocationList() *MemoryRevocationList {
+	return &MemoryRevocationList{
+		ids:   make(map[string]time.Time),
+		users: make(map[string]userRevocation),
+	}
+}
+
+// Revoke records the ID
+func (m *MemoryRevocationList) Revoke(id string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ids[id] = expiresAt
+	return nil
+}
+
+// RevokeUser replaces the user's cutoff
+func (m *MemoryRevocationList) RevokeUser(username string, before time.Time, keep string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.users[username] = userRevocation{Before: before, Keep: keep, ExpiresAt: expiresAt}
+	return nil
+}
+
+// Revoked checks the ID and the user's cutoff
+func (m *MemoryRevocationList) Revoked(id string, username string, issuedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.ids[id]; ok {
+		return true, nil
+	}
+	cutoff, ok := m.users[username]
+	return ok && cutoff.revokes(id, issuedAt), nil
+}
+
+// Purge drops the entries for tokens that have expired
+func (m *MemoryRevocationList) Purge(now time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := 0
+	for id, expiry := range m.ids {
+		if now.After(expiry) {
+			delete(m.ids, id)
+			removed++
+		}
+	}
+	for username, cutoff := range m.users {
+		if now.After(cutoff.ExpiresAt) {
+			delete(m.users, username)
+			removed++
+		}
+	}
+	return removed, nil
+}
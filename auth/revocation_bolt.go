@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// revokedSessionsBucket maps token ID -> RFC 3339 expiry
+	revokedSessionsBucket = []byte("revoked_sessions")
+
+	// revokedUsersBucket maps username -> JSON encoded userRevocation
+	revokedUsersBucket = []byte("revoked_users")
+)
+
+// BoltRevocationList is a RevocationList persisted in a bbolt database
+// file, so logouts survive a restart
+type BoltRevocationList struct {
+	db *bolt.DB
+}
+
+// NewBoltRevocationList creates the revocation buckets in db if needed
+func NewBoltRevocationList(db *bolt.DB) (*BoltRevocationList, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{revokedSessionsBucket, revokedUsersBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create revocation buckets: %w", err)
+	}
+	return &BoltRevocationList{db: db}, nil
+}
+
+// Revoke records the ID
+func (b *BoltRevocationList) Revoke(id string, expiresAt time.Time) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(revokedSessionsBucket).Put([]byte(id), []byte(expiresAt.UTC().Format(time.RFC3339)))
+	})
+}
+
+// RevokeUser replaces the user's cutoff
+func (b *BoltRevocationList) RevokeUser(username string, before time.Time, keep string, expiresAt time.Time) error {
+	data, err := json.Marshal(userRevocation{Before: before, Keep: keep, ExpiresAt: expiresAt})
+	if err != nil {
+		return fmt.Errorf("failed to marshal user revocation: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(revokedUsersBucket).Put([]byte(username), data)
+	})
+}
+
+// Revoked checks the ID and the user's cutoff
+func (b *BoltRevocationList) Revoked(id string, username string, issuedAt time.Time) (bool, error) {
+	revoked := false
+	err := b.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(revokedSessionsBucket).Get([]byte(id)) != nil {
+			revoked = true
+			return nil
+		}
+
+		data := tx.Bucket(revokedUsersBucket).Get([]byte(username))
+		if data == nil {
+			return nil
+		}
+		var cutoff userRevocation
+		if err := json.Unmarshal(data, &cutoff); err != nil {
+			return fmt.Errorf("failed to decode user revocation: %w", err)
+		}
+		revoked = cutoff.revokes(id, issuedAt)
+		return nil
+	})
+	return revoked, err
+}
+
+// Purge drops the entries for tokens that have expired
+func (b *BoltRevocationList) Purge(now time.Time) (int, error) {
+	removed := 0
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		sessions, err := deleteExpired(tx.Bucket(revokedSessionsBucket), func(v []byte) (time.Time, error) {
+			return time.Parse(time.RFC3339, string(v))
+		}, now)
+		if err != nil {
+			return err
+		}
+		users, err := deleteExpired(tx.Bucket(revokedUsersBucket), func(v []byte) (time.Time, error) {
+			var cutoff userRevocation
+			err := json.Unmarshal(v, &cutoff)
+			return cutoff.ExpiresAt, err
+		}, now)
+		if err != nil {
+			return err
+		}
+		removed = sessions + users
+		return nil
+	})
+	return removed, err
+}
+
+// deleteExpired deletes the entries of bucket whose expiry, as decoded by
+// expiry, is before now. Entries that do not decode are kept.
+func deleteExpired(bucket *bolt.Bucket, expiry func(v []byte) (time.Time, error), now time.Time) (int, error) {
+	var expired [][]byte
+	err := bucket.ForEach(func(k, v []byte) error {
+		if at, err := expiry(v); err == nil && now.After(at) {
+			expired = append(expired, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, k := range expired {
+		if err := bucket.Delete(k); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
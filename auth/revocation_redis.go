@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisRevocationList is a RevocationList shared through Redis, so a logout
+// on one server instance is honoured by all of them. Entries are stored
+// with a TTL and Redis drops them once their tokens have expired.
+type RedisRevocationList struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisRevocationList connects to the Redis server at url
+// (redis://host:port/db). Keys are namespaced with prefix.
+func NewRedisRevocationList(url string, prefix string) (*RedisRevocationList, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	return &RedisRevocationList{client: client, prefix: prefix}, nil
+}
+
+// Revoke records the ID until it expires
+func (r *RedisRevocationList) Revoke(id string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.client.Set(context.Background(), r.sessionKey(id), 1, ttl).Err()
+}
+
+// RevokeUser replaces the user's cutoff
+func (r *RedisRevocationList) RevokeUser(username string, before time.Time, keep string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	data, err := json.Marshal(userRevocation{Before: before, Keep: keep, ExpiresAt: expiresAt})
+	if err != nil {
+		return fmt.Errorf("failed to marshal user revocation: %w", err)
+	}
+	return r.client.Set(context.Background(), r.userKey(username), data, ttl).Err()
+}
+
+// Revoked checks the ID and the user's cutoff in one round trip
+func (r *RedisRevocationList) Revoked(id string, username string, issuedAt time.Time) (bool, error) {
+	ctx := context.Background()
+	var exists *redis.IntCmd
+	var cutoff *redis.StringCmd
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		exists = pipe.Exists(ctx, r.sessionKey(id))
+		cutoff = pipe.Get(ctx, r.userKey(username))
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+	if exists.Val() > 0 {
+		return true, nil
+	}
+
+	data, err := cutoff.Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var u userRevocation
+	if err := json.Unmarshal(data, &u); err != nil {
+		return false, fmt.Errorf("failed to decode user revocation: %w", err)
+	}
+	return u.revokes(id, issuedAt), nil
+}
+
+// Purge has nothing to do, as Redis expires the entries itself
+func (r *RedisRevocationList) Purge(now time.Time) (int, error) {
+	return 0, nil
+}
+
+// Close closes the Redis connection
+func (r *RedisRevocationList) Close() error {
+	return r.client.Close()
+}
+
+func (r *RedisRevocationList) sessionKey(id string) string {
+	return r.prefix + ":revoked:session:" + id
+}
+
+func (r *RedisRevocationList) userKey(username string) string {
+	return r.prefix + ":revoked:user:" + username
+}
@@ -3,44 +3,132 @@ package auth
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"sync"
 	"time"
 )
 
-// SessionStore manages user sessions
-type SessionStore struct {
+// sessionRenewStep is how far a session's expiry must move before activity
+// renews it, so busy sessions are not rewritten on every request. Short idle
+// timeouts use a tenth of the timeout instead.
+const sessionRenewStep = time.Minute
+
+// SessionStore manages user sessions. The session ID is what the browser
+// holds in its session cookie.
+type SessionStore interface {
+	// CreateSession creates a session for the user
+	CreateSession(identity Identity) (*Session, error)
+
+	// GetSession returns the session with the ID, or false if it is unknown,
+	// expired or revoked. Looking a session up counts as activity and
+	// renews it; if the store had to issue a new ID for that, the returned
+	// session carries it and the cookie should be updated.
+	GetSession(sessionID string) (*Session, bool)
+
+	// LookupSession returns the session like GetSession does, but without
+	// counting as activity, so checking on an idle connection does not keep
+	// its session alive
+	LookupSession(sessionID string) (*Session, bool)
+
+	// DeleteSession ends a session
+	DeleteSession(sessionID string) error
+
+	// DeleteUserSessions ends every session of username except the one with
+	// ID except, so a password change signs out other browsers
+	DeleteUserSessions(username string, except string) error
+
+	// PurgeExpired removes what the store keeps for sessions that expired
+	// before now, returning how many entries were removed
+	PurgeExpired(now time.Time) (int, error)
+}
+
+// SessionLifetime bounds how long sessions last. Activity slides a
+// session's expiry forward by Idle, but never past Max after login.
+type SessionLifetime struct {
+	Idle time.Duration // A session expires after this long without a request
+	Max  time.Duration // A session ends this long after login regardless of activity
+}
+
+// Validate checks both durations are positive
+func (l SessionLifetime) Validate() error {
+	if l.Idle <= 0 || l.Max <= 0 {
+		return errors.New("session idle timeout and maximum lifetime must be positive")
+	}
+	return nil
+}
+
+// start returns the expiry and deadline of a session created at now
+func (l SessionLifetime) start(now time.Time) (expiresAt time.Time, deadline time.Time) {
+	deadline = now.Add(l.Max)
+	expiresAt = now.Add(l.Idle)
+	if expiresAt.After(deadline) {
+		expiresAt = deadline
+	}
+	return expiresAt, deadline
+}
+
+// renewStep is the step server-side stores renew sessions by
+func (l SessionLifetime) renewStep() time.Duration {
+	return min(sessionRenewStep, l.Idle/10)
+}
+
+// renew returns the session's expiry after activity at now, and whether it
+// moved by at least step
+func (l SessionLifetime) renew(session *Session, now time.Time, step time.Duration) (time.Time, bool) {
+	expiresAt := now.Add(l.Idle)
+	if expiresAt.After(session.Deadline) {
+		expiresAt = session.Deadline
+	}
+	return expiresAt, expiresAt.Sub(session.ExpiresAt) >= step
+}
+
+// MemorySessionStore is a SessionStore kept in process memory, so a restart
+// logs everyone out and replicas cannot share sessions
+type MemorySessionStore struct {
 	sessions map[string]*Session
+	lifetime SessionLifetime
+	now      func() time.Time // Clock, replaced in tests
 	mu       sync.RWMutex
 }
 
 // Session represents an authenticated user session
 type Session struct {
-	ID        string
-	Username  string
-	CreatedAt time.Time
-	ExpiresAt time.Time
+	ID        string    `json:"-"`
+	Username  string    `json:"username"`
+	Issuer    string    `json:"issuer,omitempty"`  // OIDC provider that verified the user; empty for password logins
+	Subject   string    `json:"subject,omitempty"` // The user's subject at Issuer
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"` // Moves forward with activity
+	Deadline  time.Time `json:"deadline"`   // ExpiresAt never moves past this
 }
 
-// NewSessionStore creates a new session store
-func NewSessionStore() *SessionStore {
-	return &SessionStore{
+// NewMemorySessionStore creates a new in-memory session store
+func NewMemorySessionStore(lifetime SessionLifetime) *MemorySessionStore {
+	return &MemorySessionStore{
 		sessions: make(map[string]*Session),
+		lifetime: lifetime,
+		now:      time.Now,
 	}
 }
 
-// CreateSession creates a new session for a user
-func (s *SessionStore) CreateSession(username string, duration time.Duration) (*Session, error) {
+// CreateSession creates a new session for a user, keeping the OIDC issuer
+// and subject they were verified as, if any
+func (s *MemorySessionStore) CreateSession(identity Identity) (*Session, error) {
 	sessionID, err := generateSessionID()
 	if err != nil {
 		return nil, err
 	}
 
-	now := time.Now()
+	now := s.now()
+	expiresAt, deadline := s.lifetime.start(now)
 	session := &Session{
 		ID:        sessionID,
-		Username:  username,
+		Username:  identity.Username,
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
 		CreatedAt: now,
-		ExpiresAt: now.Add(duration),
+		ExpiresAt: expiresAt,
+		Deadline:  deadline,
 	}
 
 	s.mu.Lock()
@@ -50,8 +138,33 @@ func (s *SessionStore) CreateSession(username string, duration time.Duration) (*
 	return session, nil
 }
 
-// GetSession retrieves a session by ID
-func (s *SessionStore) GetSession(sessionID string) (*Session, bool) {
+// GetSession retrieves a session by ID, sliding its expiry forward
+func (s *MemorySessionStore) GetSession(sessionID string) (*Session, bool) {
+	session, ok := s.LookupSession(sessionID)
+	if !ok {
+		return nil, false
+	}
+
+	expiresAt, renew := s.lifetime.renew(session, s.now(), s.lifetime.renewStep())
+	if !renew {
+		return session, true
+	}
+
+	// Replace rather than modify the session, since callers may still hold
+	// the old one
+	renewed := *session
+	renewed.ExpiresAt = expiresAt
+	s.mu.Lock()
+	if _, exists := s.sessions[sessionID]; exists {
+		s.sessions[sessionID] = &renewed
+	}
+	s.mu.Unlock()
+
+	return &renewed, true
+}
+
+// LookupSession retrieves a session by ID, deleting it if it has expired
+func (s *MemorySessionStore) LookupSession(sessionID string) (*Session, bool) {
 	s.mu.RLock()
 	session, exists := s.sessions[sessionID]
 	s.mu.RUnlock()
@@ -61,21 +174,48 @@ func (s *SessionStore) GetSession(sessionID string) (*Session, bool) {
 	}
 
 	// Check if session has expired
-	if time.Now().After(session.ExpiresAt) {
+	if s.now().After(session.ExpiresAt) {
 		s.mu.Lock()
 		delete(s.sessions, sessionID)
 		s.mu.Unlock()
 		return nil, false
 	}
-
 	return session, true
 }
 
 // DeleteSession removes a session
-func (s *SessionStore) DeleteSession(sessionID string) {
+func (s *MemorySessionStore) DeleteSession(sessionID string) error {
 	s.mu.Lock()
 	delete(s.sessions, sessionID)
 	s.mu.Unlock()
+	return nil
+}
+
+// DeleteUserSessions removes the user's other sessions
+func (s *MemorySessionStore) DeleteUserSessions(username string, except string) error {
+	s.mu.Lock()
+	for id, session := range s.sessions {
+		if session.Username == username && id != except {
+			delete(s.sessions, id)
+		}
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// PurgeExpired removes the sessions that expired before now
+func (s *MemorySessionStore) PurgeExpired(now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, session := range s.sessions {
+		if now.After(session.ExpiresAt) {
+			delete(s.sessions, id)
+			removed++
+		}
+	}
+	return removed, nil
 }
 
 // generateSessionID generates a random session ID
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// sessionsBucket maps SHA-256 of the session ID -> JSON encoded Session.
+// Only hashes are stored, so a copy of the file does not hand out sessions.
+var sessionsBucket = []byte("sessions")
+
+// BoltSessionStore is a SessionStore persisted in a bbolt database file, so
+// sessions survive a restart
+type BoltSessionStore struct {
+	db       *bolt.DB
+	lifetime SessionLifetime
+	now      func() time.Time // Clock, replaced in tests
+}
+
+// NewBoltSessionStore creates the session bucket in db if needed
+func NewBoltSessionStore(db *bolt.DB, lifetime SessionLifetime) (*BoltSessionStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(sessionsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session bucket: %w", err)
+	}
+	return &BoltSessionStore{db: db, lifetime: lifetime, now: time.Now}, nil
+}
+
+// CreateSession creates and stores a new session for a user
+func (b *BoltSessionStore) CreateSession(identity Identity) (*Session, error) {
+	sessionID, err := generateSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := b.now()
+	expiresAt, deadline := b.lifetime.start(now)
+	session := &Session{
+		ID:        sessionID,
+		Username:  identity.Username,
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+		Deadline:  deadline,
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal session: %w", err)
+	}
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(sessionsBucket).Put([]byte(hashToken(sessionID)), data)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store session: %w", err)
+	}
+	return session, nil
+}
+
+// GetSession decodes the stored session and slides its expiry forward.
+// Expired sessions are left for PurgeExpired.
+func (b *BoltSessionStore) GetSession(sessionID string) (*Session, bool) {
+	session, ok := b.LookupSession(sessionID)
+	if !ok {
+		return nil, false
+	}
+
+	expiresAt, renew := b.lifetime.renew(session, b.now(), b.lifetime.renewStep())
+	if !renew {
+		return session, true
+	}
+
+	key := []byte(hashToken(sessionID))
+	renewed := *session
+	renewed.ExpiresAt = expiresAt
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(sessionsBucket)
+		// A logout may have raced with this request
+		if bucket.Get(key) == nil {
+			return nil
+		}
+		data, err := json.Marshal(&renewed)
+		if err != nil {
+			return fmt.Errorf("failed to marshal session: %w", err)
+		}
+		return bucket.Put(key, data)
+	})
+	if err != nil {
+		// The session stays valid until its old expiry
+		log.Printf("Failed to renew session: %v", err)
+		return session, true
+	}
+	return &renewed, true
+}
+
+// LookupSession decodes the stored session if it has not expired
+func (b *BoltSessionStore) LookupSession(sessionID string) (*Session, bool) {
+	key := []byte(hashToken(sessionID))
+	session := &Session{}
+	found := false
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(sessionsBucket).Get(key)
+		if data == nil {
+			return nil
+		}
+		found = true
+		return decodeSession(data, session)
+	})
+	if err != nil || !found {
+		return nil, false
+	}
+	session.ID = sessionID
+
+	if b.now().After(session.ExpiresAt) {
+		return nil, false
+	}
+	return session, true
+}
+
+// DeleteSession removes a session
+func (b *BoltSessionStore) DeleteSession(sessionID string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(sessionsBucket).Delete([]byte(hashToken(sessionID)))
+	})
+}
+
+// DeleteUserSessions removes the user's other sessions
+func (b *BoltSessionStore) DeleteUserSessions(username string, except string) error {
+	keep := hashToken(except)
+	return b.deleteWhere(func(key string, session Session) bool {
+		return session.Username == username && key != keep
+	})
+}
+
+// PurgeExpired removes the sessions that expired before now
+func (b *BoltSessionStore) PurgeExpired(now time.Time) (int, error) {
+	removed := 0
+	err := b.deleteWhere(func(_ string, session Session) bool {
+		if now.After(session.ExpiresAt) {
+			removed++
+			return true
+		}
+		return false
+	})
+	return removed, err
+}
+
+// deleteWhere removes the sessions matching fn in a single write transaction.
+// A record that cannot be decoded can never be used as a session, so it is
+// logged and removed too rather than failing every later sweep.
+func (b *BoltSessionStore) deleteWhere(fn func(key string, session Session) bool) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(sessionsBucket)
+
+		// Keys are collected first, since a bucket must not be modified
+		// while iterating it
+		var matched [][]byte
+		err := bucket.ForEach(func(k, v []byte) error {
+			var session Session
+			if err := decodeSession(v, &session); err != nil {
+				log.Printf("Deleting unreadable session record %s: %v", k, err)
+				matched = append(matched, k)
+				return nil
+			}
+			if fn(string(k), session) {
+				matched = append(matched, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range matched {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// decodeSession unmarshals a stored session
+func decodeSession(data []byte, session *Session) error {
+	if err := json.Unmarshal(data, session); err != nil {
+		return fmt.Errorf("failed to decode session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// sessionTokenIssuer is the iss claim of session tokens, so tokens signed
+// with the same key for another purpose are not accepted as sessions
+const sessionTokenIssuer = "sse-demo"
+
+// minHMACKeyLength is the shortest HMAC secret accepted, in bytes
+const minHMACKeyLength = 32
+
+// SigningKey is a key session tokens are signed or verified with. Its ID
+// goes in the token's kid header, so tokens signed with a retired key stay
+// valid while it is still configured.
+type SigningKey struct {
+	ID     string
+	Method jwt.SigningMethod // HS256, HS384, HS512 or EdDSA
+	sign   any               // []byte or ed25519.PrivateKey
+	verify any               // []byte or ed25519.PublicKey
+}
+
+// ParseSigningKeys parses keys written as "kid:alg:base64", where alg is
+// HS256, HS384 or HS512 with a secret of at least 32 bytes, or EdDSA with a
+// 32 byte Ed25519 seed. The first key signs new tokens; the rest only
+// verify, which lets keys be rotated without logging everyone out.
+func ParseSigningKeys(specs []string) ([]SigningKey, error) {
+	keys := make([]SigningKey, 0, len(specs))
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, ":", 3)
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("session key %q is not kid:alg:base64", spec)
+		}
+		id, alg := parts[0], parts[1]
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate session key ID %q", id)
+		}
+		seen[id] = true
+
+		material, err := base64.StdEncoding.DecodeString(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("session key %q is not valid base64: %w", id, err)
+		}
+
+		key := SigningKey{ID: id, Method: jwt.GetSigningMethod(alg)}
+		switch key.Method {
+		case jwt.SigningMethodHS256, jwt.SigningMethodHS384, jwt.SigningMethodHS512:
+			if len(material) < minHMACKeyLength {
+				return nil, fmt.Errorf("session key %q must be at least %d bytes", id, minHMACKeyLength)
+			}
+			key.sign, key.verify = material, material
+		case jwt.SigningMethodEdDSA:
+			if len(material) != ed25519.SeedSize {
+				return nil, fmt.Errorf("session key %q must be a %d byte Ed25519 seed", id, ed25519.SeedSize)
+			}
+			private := ed25519.NewKeyFromSeed(material)
+			key.sign, key.verify = private, private.Public()
+		default:
+			return nil, fmt.Errorf("session key %q has unsupported algorithm %q", id, alg)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sessionClaims are the claims of a session token
+type sessionClaims struct {
+	jwt.RegisteredClaims
+	Deadline    *jwt.NumericDate `json:"max_exp"`          // Renewed tokens never expire after this
+	IssuedNano  int64            `json:"iat_ns,omitempty"` // Issue time in nanoseconds, as iat has only seconds
+	OIDCIssuer  string           `json:"oidc_iss,omitempty"`
+	OIDCSubject string           `json:"oidc_sub,omitempty"`
+}
+
+// issuedAt is when the token was issued, as precisely as it records it
+func (c *sessionClaims) issuedAt() time.Time {
+	if c.IssuedNano != 0 {
+		return time.Unix(0, c.IssuedNano)
+	}
+	return c.IssuedAt.Time
+}
+
+// JWTSessionStore is a SessionStore whose session IDs are signed tokens
+// carrying the session itself. Any replica with the keys can check them and
+// they survive restarts; only revocations need to be stored. Since a token
+// cannot be changed, activity renews a session by issuing a new token with
+// the same ID once half of the idle timeout has passed.
+type JWTSessionStore struct {
+	keys     []SigningKey // keys[0] signs
+	byID     map[string]SigningKey
+	methods  []string // Algorithms of the configured keys
+	revoked  RevocationList
+	lifetime SessionLifetime
+	now      func() time.Time // Clock, replaced in tests
+}
+
+// NewJWTSessionStore creates a signed-token session store. At least one key
+// is required.
+func NewJWTSessionStore(keys []SigningKey, revoked RevocationList, lifetime SessionLifetime) (*JWTSessionStore, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("signed session tokens need at least one key")
+	}
+
+	s := &JWTSessionStore{
+		keys:     keys,
+		byID:     make(map[string]SigningKey, len(keys)),
+		revoked:  revoked,
+		lifetime: lifetime,
+		now:      time.Now,
+	}
+	for _, key := range keys {
+		s.byID[key.ID] = key
+		s.methods = append(s.methods, key.Method.Alg())
+	}
+	return s, nil
+}
+
+// CreateSession signs a token for the user with the current key
+func (s *JWTSessionStore) CreateSession(identity Identity) (*Session, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+
+	// JWT times have second precision; iat_ns keeps the exact time so a
+	// session started just after a revocation cutoff is not caught by it
+	issued := s.now()
+	now := issued.Truncate(time.Second)
+	expiresAt, deadline := s.lifetime.start(now)
+	claims := &sessionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    sessionTokenIssuer,
+			Subject:   identity.Username,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Deadline:    jwt.NewNumericDate(deadline),
+		IssuedNano:  issued.UnixNano(),
+		OIDCIssuer:  identity.Issuer,
+		OIDCSubject: identity.Subject,
+	}
+
+	signed, err := s.sign(claims)
+	if err != nil {
+		return nil, err
+	}
+	return claims.session(signed), nil
+}
+
+// GetSession verifies the token like LookupSession and renews it by
+// issuing a new one
+func (s *JWTSessionStore) GetSession(sessionID string) (*Session, bool) {
+	claims, ok := s.verify(sessionID)
+	if !ok {
+		return nil, false
+	}
+
+	// Renew once the expiry would move by half the idle timeout, or up to
+	// the deadline once that is nearer, so the last stretch is not lost
+	session := claims.session(sessionID)
+	step := min(s.lifetime.Idle/2, session.Deadline.Sub(session.ExpiresAt))
+	expiresAt, renew := s.lifetime.renew(session, s.now(), step)
+	if !renew || !expiresAt.After(session.ExpiresAt) {
+		return session, true
+	}
+
+	// The renewed token is signed with the current key, which also moves
+	// active sessions off retired keys
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt.Truncate(time.Second))
+	signed, err := s.sign(claims)
+	if err != nil {
+		// The session stays valid until its old expiry
+		log.Printf("Failed to renew session: %v", err)
+		return session, true
+	}
+	return claims.session(signed), true
+}
+
+// LookupSession verifies the token's signature, issuer and expiry and checks
+// it has not been revoked
+func (s *JWTSessionStore) LookupSession(sessionID string) (*Session, bool) {
+	claims, ok := s.verify(sessionID)
+	if !ok {
+		return nil, false
+	}
+	return claims.session(sessionID), true
+}
+
+// verify parses a token and checks it has not been revoked
+func (s *JWTSessionStore) verify(sessionID string) (*sessionClaims, bool) {
+	claims, ok := s.parse(sessionID)
+	if !ok {
+		return nil, false
+	}
+
+	revoked, err := s.revoked.Revoked(claims.ID, claims.Subject, claims.issuedAt())
+	if err != nil {
+		log.Printf("Failed to check session revocation: %v", err)
+		return nil, false
+	}
+	if revoked {
+		return nil, false
+	}
+	return claims, true
+}
+
+// DeleteSession revokes the token, and any renewal of it, until the
+// session's deadline. Tokens that no longer verify are already unusable and
+// are ignored.
+func (s *JWTSessionStore) DeleteSession(sessionID string) error {
+	claims, ok := s.parse(sessionID)
+	if !ok {
+		return nil
+	}
+	return s.revoked.Revoke(claims.ID, claims.Deadline.Time)
+}
+
+// DeleteUserSessions revokes every token issued to username so far, except
+// the one with ID except. The cutoff is kept until the last of those tokens
+// reaches its deadline.
+func (s *JWTSessionStore) DeleteUserSessions(username string, except string) error {
+	keep := ""
+	if claims, ok := s.parse(except); ok {
+		keep = claims.ID
+	}
+	now := s.now()
+	return s.revoked.RevokeUser(username, now, keep, now.Add(s.lifetime.Max))
+}
+
+// PurgeExpired forgets revocations of tokens that have expired
+func (s *JWTSessionStore) PurgeExpired(now time.Time) (int, error) {
+	return s.revoked.Purge(now)
+}
+
+// sign signs the claims with the current key
+func (s *JWTSessionStore) sign(claims *sessionClaims) (string, error) {
+	key := s.keys[0]
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.sign)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign session token: %w", err)
+	}
+	return signed, nil
+}
+
+// parse verifies a token and returns its claims
+func (s *JWTSessionStore) parse(sessionID string) (*sessionClaims, bool) {
+	claims := &sessionClaims{}
+	_, err := jwt.ParseWithClaims(sessionID, claims, s.keyFunc,
+		jwt.WithValidMethods(s.methods),
+		jwt.WithIssuer(sessionTokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithTimeFunc(s.now),
+	)
+	if err != nil || claims.ID == "" || claims.Subject == "" || claims.IssuedAt == nil || claims.Deadline == nil {
+		return nil, false
+	}
+	return claims, true
+}
+
+// keyFunc picks the verification key named by the token's kid, and refuses
+// tokens whose algorithm is not that key's
+func (s *JWTSessionStore) keyFunc(token *jwt.Token) (any, error) {
+	id, _ := token.Header["kid"].(string)
+	key, ok := s.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown session key %q", id)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("session key %q does not use %s", id, token.Method.Alg())
+	}
+	return key.verify, nil
+}
+
+// session returns the session the claims describe, with the token as its ID
+func (c *sessionClaims) session(token string) *Session {
+	return &Session{
+		ID:        token,
+		Username:  c.Subject,
+		Issuer:    c.OIDCIssuer,
+		Subject:   c.OIDCSubject,
+		CreatedAt: c.IssuedAt.Time,
+		ExpiresAt: c.ExpiresAt.Time,
+		Deadline:  c.Deadline.Time,
+	}
+}
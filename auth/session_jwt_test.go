@@ -0,0 +1,268 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testLifetime is the session lifetime of the JWT tests
+var testLifetime = SessionLifetime{Idle: time.Hour, Max: 24 * time.Hour}
+
+// testKey writes a kid:alg:base64 key whose 32 bytes of material are fill
+func testKey(kid, alg string, fill byte) string {
+	return kid + ":" + alg + ":" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, 32))
+}
+
+// testKeys parses keys written by testKey
+func testKeys(t *testing.T, specs ...string) []SigningKey {
+	t.Helper()
+
+	keys, err := ParseSigningKeys(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// newTestJWTStore creates a JWT session store with a memory revocation list
+func newTestJWTStore(t *testing.T, keys []SigningKey) *JWTSessionStore {
+	t.Helper()
+
+	store, err := NewJWTSessionStore(keys, NewMemoryRevocationList(), testLifetime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// kidOf returns the kid header of a session token
+func kidOf(t *testing.T, token string) string {
+	t.Helper()
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &sessionClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func TestJWTSessionKeyRotation(t *testing.T) {
+	before := newTestJWTStore(t, testKeys(t, testKey("k1", "HS256", 'a')))
+	rotated := newTestJWTStore(t, testKeys(t, testKey("k2", "HS256", 'b'), testKey("k1", "HS256", 'a')))
+	retired := newTestJWTStore(t, testKeys(t, testKey("k2", "HS256", 'b')))
+
+	old, err := before.CreateSession(Identity{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := kidOf(t, old.ID); kid != "k1" {
+		t.Fatalf("token signed with kid %q, want k1", kid)
+	}
+
+	if session, ok := rotated.GetSession(old.ID); !ok || session.Username != "alice" {
+		t.Error("token signed with the previous key was refused after rotation")
+	}
+	if _, ok := retired.GetSession(old.ID); ok {
+		t.Error("token signed with a key no longer configured was accepted")
+	}
+
+	fresh, err := rotated.CreateSession(Identity{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := kidOf(t, fresh.ID); kid != "k2" {
+		t.Errorf("new token signed with kid %q, want the first key k2", kid)
+	}
+	if _, ok := retired.GetSession(fresh.ID); !ok {
+		t.Error("token signed with the new key was refused")
+	}
+}
+
+func TestJWTSessionRejectsForgedTokens(t *testing.T) {
+	keys := testKeys(t, testKey("k1", "HS256", 'a'), testKey("k2", "EdDSA", 'b'))
+	store := newTestJWTStore(t, keys)
+
+	now := time.Now()
+	claims := &sessionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "forged",
+			Issuer:    sessionTokenIssuer,
+			Subject:   "alice",
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Deadline: jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+	sign := func(method jwt.SigningMethod, kid string, key any) string {
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	edPublic := []byte(keys[1].verify.(ed25519.PublicKey))
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"signed with k1", sign(jwt.SigningMethodHS256, "k1", keys[0].sign), true},
+		{"unknown kid", sign(jwt.SigningMethodHS256, "k9", keys[0].sign), false},
+		{"no kid", sign(jwt.SigningMethodHS256, "", keys[0].sign), false},
+		{"other HMAC algorithm than the key's", sign(jwt.SigningMethodHS512, "k1", keys[0].sign), false},
+		{"HMAC with the Ed25519 public key", sign(jwt.SigningMethodHS256, "k2", edPublic), false},
+		{"alg none", sign(jwt.SigningMethodNone, "k1", jwt.UnsafeAllowNoneSignatureType), false},
+		{"signed with another secret", sign(jwt.SigningMethodHS256, "k1", bytes.Repeat([]byte("z"), 32)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := store.GetSession(tt.token); ok != tt.valid {
+				t.Errorf("GetSession accepted = %v, want %v", ok, tt.valid)
+			}
+		})
+	}
+}
+
+func TestJWTSessionRevocation(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+	redisList, err := NewRedisRevocationList("redis://"+redisServer.Addr(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { redisList.Close() })
+	boltList, err := NewBoltRevocationList(newTestBolt(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lists := map[string]RevocationList{
+		"memory": NewMemoryRevocationList(),
+		"bolt":   boltList,
+		"redis":  redisList,
+	}
+	for name, list := range lists {
+		t.Run(name, func(t *testing.T) {
+			store, err := NewJWTSessionStore(testKeys(t, testKey("k1", "HS256", 'a')), list, testLifetime)
+			if err != nil {
+				t.Fatal(err)
+			}
+			create := func(username string) *Session {
+				session, err := store.CreateSession(Identity{Username: username})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return session
+			}
+
+			loggedOut, other, current, bob := create("alice"), create("alice"), create("alice"), create("bob")
+			if err := store.DeleteSession(loggedOut.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := store.GetSession(loggedOut.ID); ok {
+				t.Error("logged out token is still accepted")
+			}
+			if _, ok := store.GetSession(other.ID); !ok {
+				t.Error("logging out one token revoked another")
+			}
+
+			if err := store.DeleteUserSessions("alice", current.ID); err != nil {
+				t.Fatal(err)
+			}
+			// Within the same second as the cutoff, so only the precise
+			// issue time tells it apart from the revoked tokens
+			later := create("alice")
+
+			for _, check := range []struct {
+				name    string
+				session *Session
+				valid   bool
+			}{
+				{"other token of the user", other, false},
+				{"token that made the change", current, true},
+				{"token issued after the cutoff", later, true},
+				{"another user's token", bob, true},
+			} {
+				if _, ok := store.GetSession(check.session.ID); ok != check.valid {
+					t.Errorf("%s: accepted = %v, want %v", check.name, ok, check.valid)
+				}
+			}
+		})
+	}
+}
+
+func TestRedisRevocationListIsShared(t *testing.T) {
+	server := miniredis.RunT(t)
+	stores := make([]*JWTSessionStore, 2)
+	for i := range stores {
+		list, err := NewRedisRevocationList("redis://"+server.Addr(), "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { list.Close() })
+		if stores[i], err = NewJWTSessionStore(testKeys(t, testKey("k1", "HS256", 'a')), list, testLifetime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	session, err := stores[0].CreateSession(Identity{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stores[0].DeleteSession(session.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := stores[1].GetSession(session.ID); ok {
+		t.Error("token logged out on one instance is still accepted by another")
+	}
+
+	// Redis forgets the revocation once the token could no longer be used
+	server.FastForward(testLifetime.Max + time.Second)
+	if keys := server.Keys(); len(keys) != 0 {
+		t.Errorf("revocations left in Redis after the deadline: %v", keys)
+	}
+}
+
+func TestRevocationListPurge(t *testing.T) {
+	boltList, err := NewBoltRevocationList(newTestBolt(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lists := map[string]RevocationList{
+		"memory": NewMemoryRevocationList(),
+		"bolt":   boltList,
+	}
+
+	for name, list := range lists {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			if err := list.Revoke("token", now.Add(time.Minute)); err != nil {
+				t.Fatal(err)
+			}
+			if err := list.RevokeUser("alice", now, "", now.Add(time.Minute)); err != nil {
+				t.Fatal(err)
+			}
+
+			if removed, err := list.Purge(now); err != nil || removed != 0 {
+				t.Fatalf("Purge before expiry = %d, %v; want nothing removed", removed, err)
+			}
+			if removed, err := list.Purge(now.Add(2 * time.Minute)); err != nil || removed != 2 {
+				t.Fatalf("Purge after expiry = %d, %v; want the ID and the cutoff removed", removed, err)
+			}
+			if revoked, _ := list.Revoked("other", "alice", now.Add(-time.Hour)); revoked {
+				t.Error("purged user cutoff still revokes tokens")
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package auth
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// testClock is a clock the tests move forward by hand
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// newTestClock starts a clock on a whole second, as JWT times have no more
+func newTestClock() *testClock {
+	return &testClock{now: time.Unix(1_800_000_000, 0)}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newClockedStores creates each kind of session store reading the time
+// from clock
+func newClockedStores(t *testing.T, lifetime SessionLifetime, clock *testClock) map[string]SessionStore {
+	t.Helper()
+
+	memory := NewMemorySessionStore(lifetime)
+	memory.now = clock.Now
+
+	bolted, err := NewBoltSessionStore(newTestBolt(t), lifetime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bolted.now = clock.Now
+
+	signed, err := NewJWTSessionStore(testKeys(t, testKey("k1", "HS256", 'a')), NewMemoryRevocationList(), lifetime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed.now = clock.Now
+
+	return map[string]SessionStore{"memory": memory, "bolt": bolted, "jwt": signed}
+}
+
+func TestSessionLifetime(t *testing.T) {
+	lifetime := SessionLifetime{Idle: time.Hour, Max: 3 * time.Hour}
+
+	// Each step moves the clock by advance and then uses the session
+	tests := []struct {
+		name  string
+		steps []struct {
+			advance time.Duration
+			valid   bool
+		}
+	}{
+		{"idle expiry", []struct {
+			advance time.Duration
+			valid   bool
+		}{
+			{59 * time.Minute, true},
+			{61 * time.Minute, false},
+		}},
+		{"refresh on use", []struct {
+			advance time.Duration
+			valid   bool
+		}{
+			{50 * time.Minute, true},
+			{50 * time.Minute, true},
+			{50 * time.Minute, true},
+		}},
+		{"max lifetime cap", []struct {
+			advance time.Duration
+			valid   bool
+		}{
+			{50 * time.Minute, true},
+			{50 * time.Minute, true},
+			{50 * time.Minute, true},
+			{25 * time.Minute, true},  // 175 minutes in, just before the deadline
+			{10 * time.Minute, false}, // Past the deadline though used within Idle
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			for name, store := range newClockedStores(t, lifetime, clock) {
+				t.Run(name, func(t *testing.T) {
+					start := clock.Now()
+					session, err := store.CreateSession(Identity{Username: "alice"})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if want := start.Add(lifetime.Max); !session.Deadline.Equal(want) {
+						t.Fatalf("deadline = %v, want %v", session.Deadline, want)
+					}
+
+					elapsed := time.Duration(0)
+					for _, step := range tt.steps {
+						clock.Advance(step.advance)
+						elapsed += step.advance
+
+						renewed, ok := store.GetSession(session.ID)
+						if ok != step.valid {
+							t.Fatalf("after %s: valid = %v, want %v", elapsed, ok, step.valid)
+						}
+						if !ok {
+							break
+						}
+						if renewed.ExpiresAt.After(renewed.Deadline) {
+							t.Errorf("after %s: expiry %v is past the deadline %v", elapsed, renewed.ExpiresAt, renewed.Deadline)
+						}
+						if !renewed.ExpiresAt.After(clock.Now()) {
+							t.Errorf("after %s: expiry %v was not moved forward", elapsed, renewed.ExpiresAt)
+						}
+						// A signed token is replaced when renewed
+						session = renewed
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestLookupSessionDoesNotRenew(t *testing.T) {
+	lifetime := SessionLifetime{Idle: time.Hour, Max: 24 * time.Hour}
+	clock := newTestClock()
+
+	for name, store := range newClockedStores(t, lifetime, clock) {
+		t.Run(name, func(t *testing.T) {
+			session, err := store.CreateSession(Identity{Username: "alice"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Checked often, but never used, the session still idles out
+			for _, step := range []struct {
+				advance time.Duration
+				valid   bool
+			}{
+				{30 * time.Minute, true},
+				{29 * time.Minute, true},
+				{2 * time.Minute, false},
+			} {
+				clock.Advance(step.advance)
+				if _, ok := store.LookupSession(session.ID); ok != step.valid {
+					t.Fatalf("LookupSession valid = %v, want %v", ok, step.valid)
+				}
+			}
+			if _, ok := store.GetSession(session.ID); ok {
+				t.Error("session that was only looked up outlived its idle timeout")
+			}
+		})
+	}
+}
+
+func TestSessionJanitorPurgesExpiredBoltSessions(t *testing.T) {
+	lifetime := SessionLifetime{Idle: time.Hour, Max: 24 * time.Hour}
+	clock := newTestClock()
+
+	db := newTestBolt(t)
+	store, err := NewBoltSessionStore(db, lifetime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.now = clock.Now
+
+	stale, err := store.CreateSession(Identity{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(30 * time.Minute)
+	fresh, err := store.CreateSession(Identity{Username: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// alice's session has now been idle too long; bob's has not
+	clock.Advance(45 * time.Minute)
+
+	janitor := newSessionJanitor(store, time.Millisecond, clock.Now)
+	defer janitor.Close()
+
+	stored := func(sessionID string) bool {
+		found := false
+		db.View(func(tx *bolt.Tx) error {
+			found = tx.Bucket(sessionsBucket).Get([]byte(hashToken(sessionID))) != nil
+			return nil
+		})
+		return found
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for stored(stale.ID) {
+		if time.Now().After(deadline) {
+			t.Fatal("janitor did not delete the expired session")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if !stored(fresh.ID) {
+		t.Error("janitor deleted a session that had not expired")
+	}
+	if _, ok := store.GetSession(fresh.ID); !ok {
+		t.Error("session that had not expired is no longer valid")
+	}
+}
+
+func TestBoltSessionStoreDeletesUnreadableRecords(t *testing.T) {
+	lifetime := SessionLifetime{Idle: time.Hour, Max: 24 * time.Hour}
+	db := newTestBolt(t)
+	store, err := NewBoltSessionStore(db, lifetime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice, err := store.CreateSession(Identity{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sweeps := []struct {
+		name string
+		run  func() error
+	}{
+		{"PurgeExpired", func() error {
+			_, err := store.PurgeExpired(time.Now())
+			return err
+		}},
+		{"DeleteUserSessions", func() error { return store.DeleteUserSessions("alice", "") }},
+	}
+	for _, sweep := range sweeps {
+		err := db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(sessionsBucket).Put([]byte("corrupt"), []byte("{not json"))
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := sweep.run(); err != nil {
+			t.Fatalf("%s with an unreadable record: %v", sweep.name, err)
+		}
+		db.View(func(tx *bolt.Tx) error {
+			if tx.Bucket(sessionsBucket).Get([]byte("corrupt")) != nil {
+				t.Errorf("%s kept the unreadable record", sweep.name)
+			}
+			return nil
+		})
+	}
+
+	if _, ok := store.GetSession(alice.ID); ok {
+		t.Error("DeleteUserSessions left the user's session")
+	}
+}
@@ -0,0 +1,255 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Scope limits what an API token may be used for
+type Scope string
+
+const (
+	// ScopeNotify allows sending notifications through /notify
+	ScopeNotify Scope = "notify"
+
+	// ScopeAcknowledge allows sending acknowledgment requests through
+	// /acknowledge/request
+	ScopeAcknowledge Scope = "acknowledge"
+)
+
+// Valid reports whether the scope is one tokens can be granted
+func (s Scope) Valid() bool {
+	return s == ScopeNotify || s == ScopeAcknowledge
+}
+
+// tokenPrefix starts every API token so leaked ones are easy to recognize
+const tokenPrefix = "sse_"
+
+// APIToken is a bearer token for non-browser clients such as CI. Requests
+// made with it act as Principal. Only the SHA-256 hash of the secret is
+// kept; the secret itself is shown once, when the token is created.
+type APIToken struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Principal string     `json:"principal"` // User the token acts as
+	Scopes    []Scope    `json:"scopes"`
+	Hash      string     `json:"hash"` // Hex SHA-256 of the secret
+	Hint      string     `json:"hint"` // Last characters of the secret, to tell tokens apart
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Nil for a token that does not expire
+}
+
+// HasScope reports whether the token grants scope
+func (t APIToken) HasScope(scope Scope) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+// Expired reports whether the token has expired at now
+func (t APIToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// TokenStore persists API tokens
+type TokenStore interface {
+	// Create stores a new token
+	Create(token APIToken) error
+
+	// Get returns a token by ID, or ErrTokenNotFound
+	Get(id string) (APIToken, error)
+
+	// GetByHash returns the token whose secret hashes to hash, or ErrTokenNotFound
+	GetByHash(hash string) (APIToken, error)
+
+	// List returns the tokens created by username, oldest first
+	List(createdBy string) ([]APIToken, error)
+
+	// Delete removes a token, or returns ErrTokenNotFound
+	Delete(id string) error
+}
+
+var (
+	// ErrInvalidToken is returned for a bearer token that is unknown or expired
+	ErrInvalidToken = errors.New("invalid or expired API token")
+
+	// ErrTokenNotFound is returned for an unknown token ID
+	ErrTokenNotFound = errors.New("API token not found")
+
+	// ErrNotTokenOwner is returned when someone other than the creator revokes a token
+	ErrNotTokenOwner = errors.New("only the token's creator may revoke it")
+)
+
+// MemoryTokenStore is a TokenStore kept in process memory
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]APIToken // Map of ID -> token
+	hashes map[string]string   // Map of secret hash -> ID
+}
+
+// NewMemoryTokenStore creates a new in-memory token store
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: make(map[string]APIToken),
+		hashes: make(map[string]string),
+	}
+}
+
+// Create stores the token and indexes its hash
+func (m *MemoryTokenStore) Create(token APIToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tokens[token.ID] = token
+	m.hashes[token.Hash] = token.ID
+	return nil
+}
+
+// Get returns the token with the ID
+func (m *MemoryTokenStore) Get(id string) (APIToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.tokens[id]
+	if !ok {
+		return APIToken{}, ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// GetByHash looks the token up through the hash index
+func (m *MemoryTokenStore) GetByHash(hash string) (APIToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.tokens[m.hashes[hash]]
+	if !ok {
+		return APIToken{}, ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// List returns the creator's tokens sorted by creation time
+func (m *MemoryTokenStore) List(createdBy string) ([]APIToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tokens := []APIToken{}
+	for _, token := range m.tokens {
+		if token.CreatedBy == createdBy {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+	})
+	return tokens, nil
+}
+
+// Delete removes the token and its hash index entry
+func (m *MemoryTokenStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.tokens[id]
+	if !ok {
+		return ErrTokenNotFound
+	}
+	delete(m.tokens, id)
+	delete(m.hashes, token.Hash)
+	return nil
+}
+
+// Tokens issues, checks and revokes API tokens
+type Tokens struct {
+	store TokenStore
+}
+
+// NewTokens creates the token manager on top of store
+func NewTokens(store TokenStore) *Tokens {
+	return &Tokens{store: store}
+}
+
+// Create issues a token acting as principal and returns it together with
+// its secret, which cannot be recovered later
+func (t *Tokens) Create(createdBy, principal, name string, scopes []Scope, expiresAt *time.Time) (APIToken, string, error) {
+	id, err := randomHex(8)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+	secret = tokenPrefix + secret
+
+	token := APIToken{
+		ID:        id,
+		Name:      name,
+		Principal: principal,
+		Scopes:    scopes,
+		Hash:      hashToken(secret),
+		Hint:      secret[len(secret)-4:],
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+	if err := t.store.Create(token); err != nil {
+		return APIToken{}, "", err
+	}
+	return token, secret, nil
+}
+
+// List returns the tokens created by username
+func (t *Tokens) List(username string) ([]APIToken, error) {
+	return t.store.List(username)
+}
+
+// Revoke deletes a token; only its creator may
+func (t *Tokens) Revoke(id, username string) error {
+	token, err := t.store.Get(id)
+	if err != nil {
+		return err
+	}
+	if token.CreatedBy != username {
+		return ErrNotTokenOwner
+	}
+	return t.store.Delete(id)
+}
+
+// Authenticate returns the token a bearer secret belongs to, or
+// ErrInvalidToken if it is unknown or expired
+func (t *Tokens) Authenticate(secret string) (APIToken, error) {
+	token, err := t.store.GetByHash(hashToken(secret))
+	if errors.Is(err, ErrTokenNotFound) {
+		return APIToken{}, ErrInvalidToken
+	}
+	if err != nil {
+		return APIToken{}, err
+	}
+	if token.Expired(time.Now()) {
+		return APIToken{}, ErrInvalidToken
+	}
+	return token, nil
+}
+
+// hashToken returns the hex SHA-256 of a secret. Secrets are random, so a
+// fast hash is enough; unlike passwords they cannot be guessed.
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// tokensBucket maps token ID -> JSON encoded APIToken
+	tokensBucket = []byte("tokens")
+
+	// tokenHashesBucket maps secret hash -> token ID
+	tokenHashesBucket = []byte("token_hashes")
+)
+
+// BoltTokenStore is a TokenStore persisted in a bbolt database file
+type BoltTokenStore struct {
+	db *bolt.DB
+}
+
+// NewBoltTokenStore creates the token buckets in db if needed
+func NewBoltTokenStore(db *bolt.DB) (*BoltTokenStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{tokensBucket, tokenHashesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token buckets: %w", err)
+	}
+	return &BoltTokenStore{db: db}, nil
+}
+
+// Create stores the token and indexes its hash in one transaction
+func (b *BoltTokenStore) Create(token APIToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal API token: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(tokensBucket).Put([]byte(token.ID), data); err != nil {
+			return err
+		}
+		return tx.Bucket(tokenHashesBucket).Put([]byte(token.Hash), []byte(token.ID))
+	})
+}
+
+// Get decodes the token with the ID
+func (b *BoltTokenStore) Get(id string) (APIToken, error) {
+	var token APIToken
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(tokensBucket).Get([]byte(id))
+		if data == nil {
+			return ErrTokenNotFound
+		}
+		return decodeToken(data, &token)
+	})
+	return token, err
+}
+
+// GetByHash looks the token up through the hash index
+func (b *BoltTokenStore) GetByHash(hash string) (APIToken, error) {
+	var token APIToken
+	err := b.db.View(func(tx *bolt.Tx) error {
+		id := tx.Bucket(tokenHashesBucket).Get([]byte(hash))
+		if id == nil {
+			return ErrTokenNotFound
+		}
+		data := tx.Bucket(tokensBucket).Get(id)
+		if data == nil {
+			return ErrTokenNotFound
+		}
+		return decodeToken(data, &token)
+	})
+	return token, err
+}
+
+// List scans every token for the creator's, sorted by creation time
+func (b *BoltTokenStore) List(createdBy string) ([]APIToken, error) {
+	tokens := []APIToken{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(tokensBucket).ForEach(func(k, v []byte) error {
+			var token APIToken
+			if err := decodeToken(v, &token); err != nil {
+				return err
+			}
+			if token.CreatedBy == createdBy {
+				tokens = append(tokens, token)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+	})
+	return tokens, nil
+}
+
+// Delete removes the token and its hash index entry
+func (b *BoltTokenStore) Delete(id string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(tokensBucket)
+		data := bucket.Get([]byte(id))
+		if data == nil {
+			return ErrTokenNotFound
+		}
+
+		var token APIToken
+		if err := decodeToken(data, &token); err != nil {
+			return err
+		}
+		if err := tx.Bucket(tokenHashesBucket).Delete([]byte(token.Hash)); err != nil {
+			return err
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+// decodeToken unmarshals a stored API token
+func decodeToken(data []byte, token *APIToken) error {
+	if err := json.Unmarshal(data, token); err != nil {
+		return fmt.Errorf("failed to decode API token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package auth
+
+import (
+	"errors"
+	"regexp"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// User is a registered account. Only the bcrypt hash of the password is
+// kept. An account linked to a single sign-on identity has no password and
+// is only logged into through its provider.
+type User struct {
+	Username     string    `json:"username"`
+	PasswordHash []byte    `json:"password_hash"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	Issuer  string `json:"issuer,omitempty"`  // OIDC issuer of a linked account
+	Subject string `json:"subject,omitempty"` // Subject of the linked identity at the issuer
+
+	FailedLogins int       `json:"failed_logins,omitempty"` // Consecutive failures since the last success
+	LockedUntil  time.Time `json:"locked_until,omitempty"`  // Logins are refused until then
+}
+
+// UserStore persists registered accounts
+type UserStore interface {
+	// Create stores a new user, or returns ErrUserExists if the username is
+	// taken or the user's single sign-on identity is already linked
+	Create(user User) error
+
+	// Get returns a user, or ErrUserNotFound
+	Get(username string) (User, error)
+
+	// GetLinked returns the account linked to the single sign-on identity,
+	// or ErrUserNotFound
+	GetLinked(issuer, subject string) (User, error)
+
+	// Update applies fn to a user and stores the result atomically. If fn
+	// returns an error the user is left unchanged.
+	Update(username string, fn func(user *User) error) (User, error)
+}
+
+var (
+	// ErrInvalidUsername is returned when registering a username that is not allowed
+	ErrInvalidUsername = errors.New("invalid username")
+
+	// ErrWeakPassword is returned for a password shorter than
+	// MinPasswordLength or too long for bcrypt
+	ErrWeakPassword = errors.New("password must be between 8 and 72 bytes")
+
+	// ErrUserExists is returned when registering a username that is taken
+	ErrUserExists = errors.New("user already exists")
+
+	// ErrUsernameReserved is returned when registering a username that holds
+	// permissions, whose account has to be provisioned by an operator
+	ErrUsernameReserved = errors.New("username is reserved")
+
+	// ErrUserNotFound is returned for an unknown user
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrInvalidCredentials is returned for an unknown user or a wrong password
+	ErrInvalidCredentials = errors.New("invalid username or password")
+
+	// ErrAccountLocked is returned while an account is locked out after
+	// repeated failed logins
+	ErrAccountLocked = errors.New("account is temporarily locked")
+)
+
+const (
+	// MinPasswordLength is the shortest password accepted
+	MinPasswordLength = 8
+
+	// maxPasswordLength is bcrypt's input limit; longer passwords would be
+	// silently truncated
+	maxPasswordLength = 72
+)
+
+// usernamePattern matches the usernames that may be registered
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.@-]{0,63}$`)
+
+// linkKey is how stores index linked accounts by single sign-on identity.
+// Subjects are only unique per issuer, so both are part of the key.
+func linkKey(issuer, subject string) string {
+	return issuer + "\x00" + subject
+}
+
+// MemoryUserStore is a UserStore kept in process memory
+type MemoryUserStore struct {
+	mu    sync.Mutex
+	users map[string]User   // Map of username -> user
+	links map[string]string // Map of linkKey -> username of the linked account
+}
+
+// NewMemoryUserStore creates a new in-memory user store
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{
+		users: make(map[string]User),
+		links: make(map[string]string),
+	}
+}
+
+// Create stores the user unless the username or identity is taken
+func (m *MemoryUserStore) Create(user User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[user.Username]; ok {
+		return ErrUserExists
+	}
+	if user.Subject != "" {
+		key := linkKey(user.Issuer, user.Subject)
+		if _, ok := m.links[key]; ok {
+			return ErrUserExists
+		}
+		m.links[key] = user.Username
+	}
+	m.users[user.Username] = user
+	return nil
+}
+
+// GetLinked looks the account up by identity
+func (m *MemoryUserStore) GetLinked(issuer, subject string) (User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	username, ok := m.links[linkKey(issuer, subject)]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return m.users[username], nil
+}
+
+// Get returns the stored user
+func (m *MemoryUserStore) Get(username string) (User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[username]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// Update applies fn under the store lock
+func (m *MemoryUserStore) Update(username string, fn func(user *User) error) (User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[username]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	if err := fn(&user); err != nil {
+		return User{}, err
+	}
+	m.users[username] = user
+	return user, nil
+}
+
+// Accounts registers users and checks their passwords, locking an account
+// out for a while after too many consecutive failed logins
+type Accounts struct {
+	store       UserStore
+	maxFailures int           // Failed logins allowed before a lockout, 0 to never lock
+	lockout     time.Duration // How long a locked account refuses logins
+
+	// dummyHash is compared against when the user does not exist or is
+	// locked out, so neither is told apart from a wrong password by timing
+	dummyHash []byte
+
+	mu       sync.RWMutex
+	reserved map[string]bool // Usernames only Provision may create
+}
+
+// NewAccounts creates the account manager on top of store
+func NewAccounts(store UserStore, maxFailures int, lockout time.Duration) (*Accounts, error) {
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	return &Accounts{
+		store:       store,
+		maxFailures: maxFailures,
+		lockout:     lockout,
+		dummyHash:   dummyHash,
+		reserved:    make(map[string]bool),
+	}, nil
+}
+
+// Reserve keeps username from being registered by anyone, e.g. because
+// permissions are granted to it. Its account can still be provisioned.
+func (a *Accounts) Reserve(username string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.reserved[username] = true
+}
+
+// Register creates an account with the given password, unless the username
+// is reserved
+func (a *Accounts) Register(username, password string) error {
+	a.mu.RLock()
+	reserved := a.reserved[username]
+	a.mu.RUnlock()
+	if reserved {
+		return ErrUsernameReserved
+	}
+	return a.Provision(username, password)
+}
+
+// Link returns the username of the account linked to a single sign-on
+// identity, creating the account under the identity's username on its first
+// login. That username never logs into an existing account: if it is taken
+// by a password account or another identity, or is reserved or invalid, the
+// login is refused with ErrUserExists, ErrUsernameReserved or
+// ErrInvalidUsername.
+func (a *Accounts) Link(identity Identity) (string, error) {
+	user, err := a.store.GetLinked(identity.Issuer, identity.Subject)
+	if err == nil {
+		return user.Username, nil
+	}
+	if !errors.Is(err, ErrUserNotFound) {
+		return "", err
+	}
+
+	if !usernamePattern.MatchString(identity.Username) {
+		return "", ErrInvalidUsername
+	}
+	a.mu.RLock()
+	reserved := a.reserved[identity.Username]
+	a.mu.RUnlock()
+	if reserved {
+		return "", ErrUsernameReserved
+	}
+
+	now := time.Now()
+	err = a.store.Create(User{
+		Username:  identity.Username,
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if errors.Is(err, ErrUserExists) {
+		// A concurrent first login of the same identity may have linked it
+		if user, err := a.store.GetLinked(identity.Issuer, identity.Subject); err == nil {
+			return user.Username, nil
+		}
+		return "", ErrUserExists
+	}
+	if err != nil {
+		return "", err
+	}
+	return identity.Username, nil
+}
+
+// Provision creates an account with the given password on an operator's
+// behalf, reserved username or not
+func (a *Accounts) Provision(username, password string) error {
+	if !usernamePattern.MatchString(username) {
+		return ErrInvalidUsername
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return a.store.Create(User{
+		Username:     username,
+		PasswordHash: hash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+}
+
+// Authenticate checks a login attempt. It returns ErrInvalidCredentials for
+// an unknown user or wrong password, and ErrAccountLocked while the account
+// is locked out, even if the password is right. The second result is when a
+// lockout ends.
+//
+// The attempt is counted as a failure before the password is compared, so
+// concurrent guesses cannot get past the lockout while the comparisons run;
+// a right password then resets the count.
+func (a *Accounts) Authenticate(username, password string) (time.Time, error) {
+	now := time.Now()
+	var lockedUntil time.Time
+	user, err := a.store.Update(username, func(user *User) error {
+		if len(user.PasswordHash) == 0 {
+			// A linked account has no password to guess
+			return ErrInvalidCredentials
+		}
+		if now.Before(user.LockedUntil) {
+			lockedUntil = user.LockedUntil
+			return ErrAccountLocked
+		}
+		user.FailedLogins++
+		if a.maxFailures > 0 && user.FailedLogins >= a.maxFailures {
+			user.FailedLogins = 0
+			user.LockedUntil = now.Add(a.lockout)
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, ErrUserNotFound), errors.Is(err, ErrInvalidCredentials):
+		bcrypt.CompareHashAndPassword(a.dummyHash, []byte(password))
+		return time.Time{}, ErrInvalidCredentials
+	case errors.Is(err, ErrAccountLocked):
+		bcrypt.CompareHashAndPassword(a.dummyHash, []byte(password))
+		return lockedUntil, ErrAccountLocked
+	case err != nil:
+		return time.Time{}, err
+	}
+
+	if bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(password)) != nil {
+		if now.Before(user.LockedUntil) {
+			return user.LockedUntil, ErrAccountLocked
+		}
+		return time.Time{}, ErrInvalidCredentials
+	}
+
+	_, err = a.store.Update(username, func(user *User) error {
+		user.FailedLogins = 0
+		user.LockedUntil = time.Time{}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Time{}, nil
+}
+
+// ChangePassword replaces the user's password after checking the current
+// one. The check counts toward the lockout like a login, so a stolen session
+// cannot be used to guess the password; the first result is when a lockout
+// ends.
+func (a *Accounts) ChangePassword(username, current, password string) (time.Time, error) {
+	if lockedUntil, err := a.Authenticate(username, current); err != nil {
+		return lockedUntil, err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return time.Time{}, err
+	}
+	_, err = a.store.Update(username, func(user *User) error {
+		user.PasswordHash = hash
+		user.UpdatedAt = time.Now()
+		return nil
+	})
+	return time.Time{}, err
+}
+
+// hashPassword checks the password's length and hashes it with bcrypt
+func hashPassword(password string) ([]byte, error) {
+	if len(password) < MinPasswordLength || len(password) > maxPasswordLength {
+		return nil, ErrWeakPassword
+	}
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// usersBucket maps username -> JSON encoded User
+	usersBucket = []byte("users")
+
+	// userLinksBucket maps linkKey -> username of the linked account
+	userLinksBucket = []byte("user_links")
+)
+
+// BoltUserStore is a UserStore persisted in a bbolt database file
+type BoltUserStore struct {
+	db *bolt.DB
+}
+
+// NewBoltUserStore creates the user bucket in db if needed
+func NewBoltUserStore(db *bolt.DB) (*BoltUserStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{usersBucket, userLinksBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user bucket: %w", err)
+	}
+	return &BoltUserStore{db: db}, nil
+}
+
+// Create stores the user unless the username or identity is taken
+func (b *BoltUserStore) Create(user User) error {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return fmt.Errorf("failed to marshal user: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(usersBucket)
+		if bucket.Get([]byte(user.Username)) != nil {
+			return ErrUserExists
+		}
+		if user.Subject != "" {
+			links := tx.Bucket(userLinksBucket)
+			key := []byte(linkKey(user.Issuer, user.Subject))
+			if links.Get(key) != nil {
+				return ErrUserExists
+			}
+			if err := links.Put(key, []byte(user.Username)); err != nil {
+				return err
+			}
+		}
+		return bucket.Put([]byte(user.Username), data)
+	})
+}
+
+// Get decodes the stored user
+func (b *BoltUserStore) Get(username string) (User, error) {
+	var user User
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(usersBucket).Get([]byte(username))
+		if data == nil {
+			return ErrUserNotFound
+		}
+		return decodeUser(data, &user)
+	})
+	return user, err
+}
+
+// GetLinked follows the identity's link to the account
+func (b *BoltUserStore) GetLinked(issuer, subject string) (User, error) {
+	var user User
+	err := b.db.View(func(tx *bolt.Tx) error {
+		username := tx.Bucket(userLinksBucket).Get([]byte(linkKey(issuer, subject)))
+		if username == nil {
+			return ErrUserNotFound
+		}
+		data := tx.Bucket(usersBucket).Get(username)
+		if data == nil {
+			return ErrUserNotFound
+		}
+		return decodeUser(data, &user)
+	})
+	return user, err
+}
+
+// Update applies fn within a single write transaction
+func (b *BoltUserStore) Update(username string, fn func(user *User) error) (User, error) {
+	var user User
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(usersBucket)
+		data := bucket.Get([]byte(username))
+		if data == nil {
+			return ErrUserNotFound
+		}
+		if err := decodeUser(data, &user); err != nil {
+			return err
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(user)
+		if err != nil {
+			return fmt.Errorf("failed to marshal user: %w", err)
+		}
+		return bucket.Put([]byte(username), data)
+	})
+	if err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+// decodeUser unmarshals a stored user
+func decodeUser(data []byte, user *User) error {
+	if err := json.Unmarshal(data, user); err != nil {
+		return fmt.Errorf("failed to decode user: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package auth
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newTestAccounts creates accounts on a memory store, locking out after
+// maxFailures, with alice registered as "password1"
+func newTestAccounts(t *testing.T, maxFailures int) *Accounts {
+	t.Helper()
+
+	accounts, err := NewAccounts(NewMemoryUserStore(), maxFailures, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := accounts.Register("alice", "password1"); err != nil {
+		t.Fatal(err)
+	}
+	return accounts
+}
+
+// newTestBolt opens a bolt database that is removed after the test
+func newTestBolt(t *testing.T) *bolt.DB {
+	t.Helper()
+
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestHashPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{"too short", strings.Repeat("a", MinPasswordLength-1), ErrWeakPassword},
+		{"shortest", strings.Repeat("a", MinPasswordLength), nil},
+		{"longest", strings.Repeat("a", maxPasswordLength), nil},
+		{"too long for bcrypt", strings.Repeat("a", maxPasswordLength+1), ErrWeakPassword},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := hashPassword(tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("hashPassword error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if string(hash) == tt.password {
+				t.Error("hash is the plain password")
+			}
+			if bcrypt.CompareHashAndPassword(hash, []byte(tt.password)) != nil {
+				t.Error("hash does not match the password")
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	accounts := newTestAccounts(t, 0)
+	accounts.Reserve("admin")
+
+	tests := []struct {
+		name     string
+		username string
+		wantErr  error
+	}{
+		{"new user", "bob", nil},
+		{"taken", "alice", ErrUserExists},
+		{"reserved", "admin", ErrUsernameReserved},
+		{"invalid", "no spaces", ErrInvalidUsername},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := accounts.Register(tt.username, "password1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Register(%q) error = %v, want %v", tt.username, err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := accounts.Provision("admin", "password1"); err != nil {
+		t.Fatalf("Provision of a reserved name: %v", err)
+	}
+	if _, err := accounts.Authenticate("admin", "password1"); err != nil {
+		t.Errorf("provisioned account cannot log in: %v", err)
+	}
+}
+
+func TestAuthenticateLockout(t *testing.T) {
+	accounts := newTestAccounts(t, 3)
+
+	steps := []struct {
+		name     string
+		username string
+		password string
+		wantErr  error
+	}{
+		{"unknown user", "mallory", "password1", ErrInvalidCredentials},
+		{"first failure", "alice", "wrong-password", ErrInvalidCredentials},
+		{"success resets the count", "alice", "password1", nil},
+		{"failure after reset", "alice", "wrong-password", ErrInvalidCredentials},
+		{"second failure", "alice", "wrong-password", ErrInvalidCredentials},
+		{"third failure locks", "alice", "wrong-password", ErrAccountLocked},
+		{"right password while locked", "alice", "password1", ErrAccountLocked},
+	}
+	for _, step := range steps {
+		lockedUntil, err := accounts.Authenticate(step.username, step.password)
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: error = %v, want %v", step.name, err, step.wantErr)
+		}
+		if errors.Is(err, ErrAccountLocked) && !lockedUntil.After(time.Now()) {
+			t.Errorf("%s: lockout ends at %v, want a time in the future", step.name, lockedUntil)
+		}
+	}
+
+	// Once the lockout has passed the right password works again
+	if _, err := accounts.store.Update("alice", func(user *User) error {
+		user.LockedUntil = time.Now().Add(-time.Second)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := accounts.Authenticate("alice", "password1"); err != nil {
+		t.Errorf("login after the lockout: %v", err)
+	}
+}
+
+func TestAuthenticateConcurrentGuessesStopAtLockout(t *testing.T) {
+	const maxFailures = 3
+	accounts := newTestAccounts(t, maxFailures)
+
+	var mu sync.Mutex
+	results := make(map[error]int)
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := accounts.Authenticate("alice", "wrong-password")
+			mu.Lock()
+			results[err]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// However many guesses run at once, only those within the limit are
+	// told the password was wrong; the rest find the account locked
+	if got := results[ErrInvalidCredentials]; got != maxFailures-1 {
+		t.Errorf("%d guesses were rejected as wrong before the lockout, want %d (results %v)", got, maxFailures-1, results)
+	}
+	if _, err := accounts.Authenticate("alice", "password1"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("right password after the guesses: error = %v, want ErrAccountLocked", err)
+	}
+}
+
+func TestChangePasswordLockout(t *testing.T) {
+	accounts := newTestAccounts(t, 3)
+
+	steps := []struct {
+		name    string
+		current string
+		wantErr error
+	}{
+		{"first wrong password", "wrong-password", ErrInvalidCredentials},
+		{"right password resets the count", "password1", nil},
+		{"wrong password after reset", "wrong-password", ErrInvalidCredentials},
+		{"second wrong password", "wrong-password", ErrInvalidCredentials},
+		{"third wrong password locks", "wrong-password", ErrAccountLocked},
+		{"right password while locked", "password1", ErrAccountLocked},
+	}
+	for _, step := range steps {
+		lockedUntil, err := accounts.ChangePassword("alice", step.current, "password1")
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: error = %v, want %v", step.name, err, step.wantErr)
+		}
+		if errors.Is(err, ErrAccountLocked) && !lockedUntil.After(time.Now()) {
+			t.Errorf("%s: lockout ends at %v, want a time in the future", step.name, lockedUntil)
+		}
+	}
+
+	// Guesses through a password change lock logins out too
+	if _, err := accounts.Authenticate("alice", "password1"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("login after the guesses: error = %v, want ErrAccountLocked", err)
+	}
+}
+
+func TestChangePasswordSignsOutOtherSessions(t *testing.T) {
+	lifetime := SessionLifetime{Idle: time.Hour, Max: 24 * time.Hour}
+	bolted, err := NewBoltSessionStore(newTestBolt(t), lifetime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stores := map[string]SessionStore{
+		"memory": NewMemorySessionStore(lifetime),
+		"bolt":   bolted,
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			accounts := newTestAccounts(t, 0)
+
+			current, err := store.CreateSession(Identity{Username: "alice"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			other, err := store.CreateSession(Identity{Username: "alice"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			unrelated, err := store.CreateSession(Identity{Username: "bob"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := accounts.ChangePassword("alice", "wrong-password", "password2"); !errors.Is(err, ErrInvalidCredentials) {
+				t.Fatalf("change with the wrong current password: error = %v", err)
+			}
+			if _, err := accounts.ChangePassword("alice", "password1", "short"); !errors.Is(err, ErrWeakPassword) {
+				t.Fatalf("change to a weak password: error = %v", err)
+			}
+			if _, err := accounts.ChangePassword("alice", "password1", "password2"); err != nil {
+				t.Fatal(err)
+			}
+			// As PutPassword does after a successful change
+			if err := store.DeleteUserSessions("alice", current.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := accounts.Authenticate("alice", "password1"); !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("old password: error = %v, want ErrInvalidCredentials", err)
+			}
+			if _, err := accounts.Authenticate("alice", "password2"); err != nil {
+				t.Errorf("new password: %v", err)
+			}
+			if _, ok := store.GetSession(current.ID); !ok {
+				t.Error("the session that changed the password was ended")
+			}
+			if _, ok := store.GetSession(other.ID); ok {
+				t.Error("another session of the user survived the change")
+			}
+			if _, ok := store.GetSession(unrelated.ID); !ok {
+				t.Error("another user's session was ended")
+			}
+		})
+	}
+}
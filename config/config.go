@@ -0,0 +1,134 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the server settings, read from SSE_* environment variables
+type Config struct {
+	Addr        string // SSE_ADDR: address the HTTP server listens on
+	Broker      string // SSE_BROKER: "memory" (single process) or "redis"
+	RedisURL    string // SSE_REDIS_URL: Redis server used by the redis broker
+	RedisPrefix string // SSE_REDIS_PREFIX: namespace for Redis keys and channels
+	Store       string // SSE_STORE: "memory" or "bolt" for durable storage
+	BoltPath    string // SSE_BOLT_PATH: database file used by the bolt store
+	DebugAddr   string // SSE_DEBUG_ADDR: internal address serving /debug/vars; "off" disables it
+
+	HeartbeatInterval time.Duration // SSE_HEARTBEAT_INTERVAL: idle ping interval on event streams, 0 to disable
+	RetryInterval     time.Duration // SSE_RETRY_INTERVAL: reconnect delay suggested to EventSource clients
+	WriteTimeout      time.Duration // SSE_WRITE_TIMEOUT: stalled stream writes drop the client after this long
+
+	BufferSize         int    // SSE_BUFFER_SIZE: events buffered per connection
+	SlowConsumerPolicy string // SSE_SLOW_CONSUMER_POLICY: drop-newest, drop-oldest, disconnect or spill
+
+	Impersonators []string // SSE_IMPERSONATORS: comma-separated users allowed to send as others; their accounts are created with adduser
+
+	LoginMaxFailures int           // SSE_LOGIN_MAX_FAILURES: consecutive failed logins before an account is locked
+	LoginLockout     time.Duration // SSE_LOGIN_LOCKOUT: how long a locked account refuses logins
+
+	SessionStore         string        // SSE_SESSION_STORE: "memory", "bolt" or "jwt" for signed session tokens; defaults to SSE_STORE
+	SessionKeys          []string      // SSE_SESSION_KEYS: comma-separated kid:alg:base64 keys for jwt sessions; the first signs
+	SessionIdleTimeout   time.Duration // SSE_SESSION_IDLE_TIMEOUT: sessions expire after this long without a request
+	SessionMaxLifetime   time.Duration // SSE_SESSION_MAX_LIFETIME: sessions end this long after login regardless of activity
+	SessionSweepInterval time.Duration // SSE_SESSION_SWEEP_INTERVAL: how often expired sessions are purged, 0 to disable
+
+	OIDCIssuer        string // SSE_OIDC_ISSUER: OpenID Connect provider for single sign-on; empty disables it
+	OIDCClientID      string // SSE_OIDC_CLIENT_ID: client registered with the provider
+	OIDCClientSecret  string // SSE_OIDC_CLIENT_SECRET: secret of that client
+	OIDCRedirectURL   string // SSE_OIDC_REDIRECT_URL: the /oidc/callback URL as the browser reaches it
+	OIDCUsernameClaim string // SSE_OIDC_USERNAME_CLAIM: ID token claim used as the username
+	OIDCPostLoginURL  string // SSE_OIDC_POST_LOGIN_URL: where the browser goes after single sign-on
+}
+
+// Load reads the configuration from the environment, applying defaults
+func Load() Config {
+	store := getEnv("SSE_STORE", "memory")
+
+	return Config{
+		Addr:        getEnv("SSE_ADDR", ":8080"),
+		Broker:      getEnv("SSE_BROKER", "memory"),
+		RedisURL:    getEnv("SSE_REDIS_URL", "redis://localhost:6379/0"),
+		RedisPrefix: getEnv("SSE_REDIS_PREFIX", "sse"),
+		Store:       store,
+		BoltPath:    getEnv("SSE_BOLT_PATH", "sse.db"),
+		DebugAddr:   getEnv("SSE_DEBUG_ADDR", "localhost:6060"),
+
+		HeartbeatInterval: getEnvDuration("SSE_HEARTBEAT_INTERVAL", 15*time.Second),
+		RetryInterval:     getEnvDuration("SSE_RETRY_INTERVAL", 3*time.Second),
+		WriteTimeout:      getEnvDuration("SSE_WRITE_TIMEOUT", 10*time.Second),
+
+		BufferSize:         getEnvInt("SSE_BUFFER_SIZE", 10),
+		SlowConsumerPolicy: getEnv("SSE_SLOW_CONSUMER_POLICY", "drop-newest"),
+
+		Impersonators: getEnvList("SSE_IMPERSONATORS"),
+
+		LoginMaxFailures: getEnvInt("SSE_LOGIN_MAX_FAILURES", 5),
+		LoginLockout:     getEnvDuration("SSE_LOGIN_LOCKOUT", 15*time.Minute),
+
+		SessionStore:         getEnv("SSE_SESSION_STORE", store),
+		SessionKeys:          getEnvList("SSE_SESSION_KEYS"),
+		SessionIdleTimeout:   getEnvDuration("SSE_SESSION_IDLE_TIMEOUT", 24*time.Hour),
+		SessionMaxLifetime:   getEnvDuration("SSE_SESSION_MAX_LIFETIME", 7*24*time.Hour),
+		SessionSweepInterval: getEnvDuration("SSE_SESSION_SWEEP_INTERVAL", 5*time.Minute),
+
+		OIDCIssuer:        getEnv("SSE_OIDC_ISSUER", ""),
+		OIDCClientID:      getEnv("SSE_OIDC_CLIENT_ID", ""),
+		OIDCClientSecret:  getEnv("SSE_OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:   getEnv("SSE_OIDC_REDIRECT_URL", "http://localhost:5173/api/oidc/callback"),
+		OIDCUsernameClaim: getEnv("SSE_OIDC_USERNAME_CLAIM", "preferred_username"),
+		OIDCPostLoginURL:  getEnv("SSE_OIDC_POST_LOGIN_URL", "/login"),
+	}
+}
+
+// getEnv returns the value of the environment variable or fallback if unset
+func getEnv(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvInt parses a positive integer from the environment variable,
+// returning fallback if it is unset or invalid
+func getEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s %q, using %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+// getEnvDuration parses a duration such as "15s" from the environment
+// variable, returning fallback if it is unset or invalid
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return d
+}
+
+// getEnvList splits a comma-separated environment variable, skipping blanks
+func getEnvList(key string) []string {
+	values := []string{}
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
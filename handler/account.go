@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sse-demo/auth"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PostRegister implements StrictServerInterface
+func (h *StrictApiHandler) PostRegister(ctx context.Context, request PostRegisterRequestObject) (PostRegisterResponseObject, error) {
+	if request.Body == nil {
+		return PostRegister400Response{}, nil
+	}
+
+	username := request.Body.Username
+	err := h.Accounts.Register(username, request.Body.Password)
+	switch {
+	case errors.Is(err, auth.ErrInvalidUsername), errors.Is(err, auth.ErrWeakPassword):
+		return PostRegister400Response{}, nil
+	case errors.Is(err, auth.ErrUserExists), errors.Is(err, auth.ErrUsernameReserved):
+		return PostRegister409Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to register user: %w", err)
+	}
+
+	log.Printf("User registered: %s", username)
+
+	// Registering also logs the user in
+	cookieStr, err := h.createSession(auth.Identity{Username: username})
+	if err != nil {
+		return nil, err
+	}
+
+	return PostRegister200JSONResponse{
+		Body: LoginResponse{
+			Success:  boolPtr(true),
+			Username: &username,
+		},
+		Headers: PostRegister200ResponseHeaders{
+			SetCookie: cookieStr,
+		},
+	}, nil
+}
+
+// PutPassword implements StrictServerInterface
+func (h *StrictApiHandler) PutPassword(ctx context.Context, request PutPasswordRequestObject) (PutPasswordResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Body == nil {
+		return PutPassword400Response{}, nil
+	}
+
+	lockedUntil, err := h.Accounts.ChangePassword(username, request.Body.CurrentPassword, request.Body.NewPassword)
+	switch {
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		log.Printf("Wrong current password for %s", username)
+		return PutPassword401Response{}, nil
+	case errors.Is(err, auth.ErrAccountLocked):
+		log.Printf("Refused password change for locked account %s", username)
+		retryAfter := int(time.Until(lockedUntil).Seconds()) + 1
+		return PutPassword429Response{Headers: PutPassword429ResponseHeaders{RetryAfter: retryAfter}}, nil
+	case errors.Is(err, auth.ErrWeakPassword):
+		return PutPassword400Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to change password: %w", err)
+	}
+
+	// Anyone else holding a session for this user is signed out; the
+	// session that made the change stays valid
+	current := ""
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		current, _ = ginCtx.Cookie(auth.SessionCookieName)
+	}
+	if err := h.SessionStore.DeleteUserSessions(username, current); err != nil {
+		return nil, fmt.Errorf("failed to end other sessions: %w", err)
+	}
+
+	log.Printf("User %s changed their password", username)
+
+	return PutPassword200JSONResponse{Success: boolPtr(true)}, nil
+}
@@ -9,32 +9,99 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
 )
 
 const (
+	BearerAuthScopes = "bearerAuth.Scopes"
 	CookieAuthScopes = "cookieAuth.Scopes"
 )
 
+// Defines values for AcknowledgmentStatusStatus.
+const (
+	Completed AcknowledgmentStatusStatus = "completed"
+	Expired   AcknowledgmentStatusStatus = "expired"
+	Pending   AcknowledgmentStatusStatus = "pending"
+)
+
+// Defines values for Priority.
+const (
+	Critical Priority = "critical"
+	High     Priority = "high"
+	Low      Priority = "low"
+	Normal   Priority = "normal"
+)
+
+// Defines values for RecurringScheduleKind.
+const (
+	RecurringScheduleKindAcknowledgment RecurringScheduleKind = "acknowledgment"
+	RecurringScheduleKindNotification   RecurringScheduleKind = "notification"
+)
+
+// Defines values for ScheduledSendKind.
+const (
+	ScheduledSendKindAcknowledgment ScheduledSendKind = "acknowledgment"
+	ScheduledSendKindNotification   ScheduledSendKind = "notification"
+)
+
+// Defines values for TokenScope.
+const (
+	Acknowledge TokenScope = "acknowledge"
+	Notify      TokenScope = "notify"
+)
+
+// Defines values for GetEventsParamsFormat.
+const (
+	Envelope GetEventsParamsFormat = "envelope"
+	Named    GetEventsParamsFormat = "named"
+)
+
 // AcknowledgeRequestPayload defines model for AcknowledgeRequestPayload.
 type AcknowledgeRequestPayload struct {
+	// Deadline Optional time after which the request expires and the requester is told who did not respond. Requests without one expire after 7 days.
+	Deadline *time.Time `json:"deadline,omitempty"`
+
 	// Message Message for the acknowledgment request
 	Message string `json:"message"`
 
-	// ToUsernames List of usernames to send acknowledgment request to
-	ToUsernames []string `json:"to_usernames"`
+	// Options Optional choices offered to recipients, e.g. Approve/Reject. Responses must pick one of them.
+	Options *[]string `json:"options,omitempty"`
+
+	// SendAt Send at this future time instead of now; see /scheduled. A deadline must come after it.
+	SendAt *time.Time `json:"send_at,omitempty"`
+
+	// ToGroups Groups whose members receive the request; the request records the resolved recipients in to_usernames
+	ToGroups *[]string `json:"to_groups,omitempty"`
+
+	// ToUsernames List of usernames to send acknowledgment request to. Required unless to_groups is set
+	ToUsernames *[]string `json:"to_usernames,omitempty"`
 }
 
 // AcknowledgeRequestResponse defines model for AcknowledgeRequestResponse.
 type AcknowledgeRequestResponse struct {
+	// RequestId Absent when the request is scheduled
 	RequestId *string `json:"request_id,omitempty"`
-	Success   *bool   `json:"success,omitempty"`
+
+	// ScheduledId ID of the scheduled send, if send_at was given
+	ScheduledId *string `json:"scheduled_id,omitempty"`
+	Success     *bool   `json:"success,omitempty"`
+
+	// ToUsernames Resolved recipients, including the members of to_groups
+	ToUsernames *[]string `json:"to_usernames,omitempty"`
 }
 
 // AcknowledgeResponsePayload defines model for AcknowledgeResponsePayload.
 type AcknowledgeResponsePayload struct {
+	// Comment Optional free-text comment
+	Comment *string `json:"comment,omitempty"`
+
+	// Option Chosen option; required when the request offers options
+	Option *string `json:"option,omitempty"`
+
 	// RequestId ID of the acknowledgment request being responded to
 	RequestId string `json:"request_id"`
 }
@@ -44,8 +111,210 @@ type AcknowledgeResponseResponse struct {
 	Success *bool `json:"success,omitempty"`
 }
 
+// AcknowledgmentOptionTally defines model for AcknowledgmentOptionTally.
+type AcknowledgmentOptionTally struct {
+	Count     int      `json:"count"`
+	Option    string   `json:"option"`
+	Usernames []string `json:"usernames"`
+}
+
+// AcknowledgmentResponseRecord defines model for AcknowledgmentResponseRecord.
+type AcknowledgmentResponseRecord struct {
+	Comment      *string `json:"comment,omitempty"`
+	FromUsername string  `json:"from_username"`
+
+	// Option Chosen option, if the request offers any
+	Option      *string   `json:"option,omitempty"`
+	RespondedAt time.Time `json:"responded_at"`
+}
+
+// AcknowledgmentStatus defines model for AcknowledgmentStatus.
+type AcknowledgmentStatus struct {
+	// Acknowledged Recipients who have acknowledged
+	Acknowledged []string `json:"acknowledged"`
+
+	// ClosedAt When the request completed or expired
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+	FromUsername string     `json:"from_username"`
+	Id           string     `json:"id"`
+	Message      string     `json:"message"`
+	Options      *[]string  `json:"options,omitempty"`
+
+	// Pending Recipients who have not acknowledged yet
+	Pending []string `json:"pending"`
+
+	// Responses Responses in the order they arrived
+	Responses []AcknowledgmentResponseRecord `json:"responses"`
+	Status    AcknowledgmentStatusStatus     `json:"status"`
+	ToGroups  *[]string                      `json:"to_groups,omitempty"`
+
+	// ToUsernames Resolved recipients, including the members of to_groups
+	ToUsernames []string `json:"to_usernames"`
+}
+
+// AcknowledgmentStatusStatus defines model for AcknowledgmentStatus.Status.
+type AcknowledgmentStatusStatus string
+
+// AcknowledgmentTally defines model for AcknowledgmentTally.
+type AcknowledgmentTally struct {
+	// Options One entry per option offered, in the order offered
+	Options []AcknowledgmentOptionTally `json:"options"`
+
+	// Pending Number of recipients who have not responded yet
+	Pending   int    `json:"pending"`
+	RequestId string `json:"request_id"`
+
+	// Responded Number of recipients who have responded
+	Responded int `json:"responded"`
+}
+
+// ApiToken defines model for ApiToken.
+type ApiToken struct {
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Hint Last characters of the secret
+	Hint string `json:"hint"`
+	Id   string `json:"id"`
+	Name string `json:"name"`
+
+	// Principal User the token acts as, and the sender of what it sends
+	Principal string       `json:"principal"`
+	Scopes    []TokenScope `json:"scopes"`
+}
+
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+
+	// NewPassword Between 8 and 72 bytes
+	NewPassword string `json:"new_password"`
+}
+
+// ChannelMembersResponse defines model for ChannelMembersResponse.
+type ChannelMembersResponse struct {
+	Channel string   `json:"channel"`
+	Members []string `json:"members"`
+}
+
+// ChannelMembershipResponse defines model for ChannelMembershipResponse.
+type ChannelMembershipResponse struct {
+	// Channel Normalized channel name
+	Channel *string `json:"channel,omitempty"`
+	Success *bool   `json:"success,omitempty"`
+}
+
+// ChannelPublishRequest defines model for ChannelPublishRequest.
+type ChannelPublishRequest struct {
+	Message string `json:"message"`
+
+	// Priority How urgently the notification should be delivered; defaults to normal. Higher priorities are delivered ahead of queued lower ones, and critical ones even during do-not-disturb.
+	Priority *Priority `json:"priority,omitempty"`
+
+	// ReadReceipt Notify the sender when a member reads the notification
+	ReadReceipt *bool `json:"read_receipt,omitempty"`
+}
+
+// ChannelPublishResponse defines model for ChannelPublishResponse.
+type ChannelPublishResponse struct {
+	// Id ID of the notification
+	Id *string `json:"id,omitempty"`
+
+	// Recipients Number of members it was sent to
+	Recipients *int  `json:"recipients,omitempty"`
+	Success    *bool `json:"success,omitempty"`
+}
+
+// ChannelSummary defines model for ChannelSummary.
+type ChannelSummary struct {
+	// Joined Whether the current user is a member
+	Joined      bool   `json:"joined"`
+	MemberCount int    `json:"member_count"`
+	Name        string `json:"name"`
+}
+
+// ChannelsResponse defines model for ChannelsResponse.
+type ChannelsResponse struct {
+	Channels []ChannelSummary `json:"channels"`
+}
+
+// CreateTokenRequest defines model for CreateTokenRequest.
+type CreateTokenRequest struct {
+	// ExpiresAt When the token stops working; omit for a token that does not expire
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+
+	// Principal User the token acts as; defaults to the current user, and others need the impersonate permission
+	Principal *string      `json:"principal,omitempty"`
+	Scopes    []TokenScope `json:"scopes"`
+}
+
+// CreateTokenResponse defines model for CreateTokenResponse.
+type CreateTokenResponse struct {
+	// Secret The bearer token; it cannot be retrieved again
+	Secret string   `json:"secret"`
+	Token  ApiToken `json:"token"`
+}
+
+// DoNotDisturb defines model for DoNotDisturb.
+type DoNotDisturb struct {
+	Enabled bool `json:"enabled"`
+
+	// Until Turn off on its own at this time; unset keeps it on until it is turned off
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// Group defines model for Group.
+type Group struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// CreatedBy Only the creator may change or delete the group
+	CreatedBy   string    `json:"created_by"`
+	Description *string   `json:"description,omitempty"`
+	Members     []string  `json:"members"`
+	Name        string    `json:"name"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// GroupCreateRequest defines model for GroupCreateRequest.
+type GroupCreateRequest struct {
+	Description *string   `json:"description,omitempty"`
+	Members     *[]string `json:"members,omitempty"`
+
+	// Name Lowercase letters, digits, '.', '_' and '-'; normalized to lowercase
+	Name string `json:"name"`
+}
+
+// GroupMembersRequest defines model for GroupMembersRequest.
+type GroupMembersRequest struct {
+	Usernames []string `json:"usernames"`
+}
+
+// GroupUpdateRequest defines model for GroupUpdateRequest.
+type GroupUpdateRequest struct {
+	Description *string `json:"description,omitempty"`
+
+	// Members Replaces the member list when set
+	Members *[]string `json:"members,omitempty"`
+}
+
+// GroupsResponse defines model for GroupsResponse.
+type GroupsResponse struct {
+	Groups []Group `json:"groups"`
+}
+
+// LoginMethodsResponse defines model for LoginMethodsResponse.
+type LoginMethodsResponse struct {
+	// Oidc Whether single sign-on through /oidc/login is available
+	Oidc     bool `json:"oidc"`
+	Password bool `json:"password"`
+}
+
 // LoginRequest defines model for LoginRequest.
 type LoginRequest struct {
+	Password string `json:"password"`
 	Username string `json:"username"`
 }
 
@@ -60,18 +329,214 @@ type LogoutResponse struct {
 	Success *bool `json:"success,omitempty"`
 }
 
+// MarkReadResponse defines model for MarkReadResponse.
+type MarkReadResponse struct {
+	// Marked Number of notifications that changed from unread to read
+	Marked  *int  `json:"marked,omitempty"`
+	Success *bool `json:"success,omitempty"`
+
+	// UnreadCount Unread notifications remaining
+	UnreadCount *int `json:"unread_count,omitempty"`
+}
+
+// Notification defines model for Notification.
+type Notification struct {
+	// Channel Channel the notification was published to, if any
+	Channel *string `json:"channel,omitempty"`
+
+	// EventType Type of the event this entry holds, if it was spilled from a slow event stream
+	EventType *string `json:"event_type,omitempty"`
+	From      *string `json:"from,omitempty"`
+	Id        *string `json:"id,omitempty"`
+	Message   *string `json:"message,omitempty"`
+
+	// Payload Payload of the spilled event
+	Payload interface{} `json:"payload,omitempty"`
+
+	// Priority How urgently the notification should be delivered; defaults to normal. Higher priorities are delivered ahead of queued lower ones, and critical ones even during do-not-disturb.
+	Priority *Priority `json:"priority,omitempty"`
+
+	// Read Whether the user has read the notification (history only)
+	Read   *bool      `json:"read,omitempty"`
+	ReadAt *time.Time `json:"read_at,omitempty"`
+
+	// ReadReceipt Whether the sender requested a read receipt
+	ReadReceipt *bool      `json:"read_receipt,omitempty"`
+	Timestamp   *time.Time `json:"timestamp,omitempty"`
+}
+
+// NotificationsResponse defines model for NotificationsResponse.
+type NotificationsResponse struct {
+	// NextCursor Cursor for the next (older) page; absent on the last page
+	NextCursor    *string        `json:"next_cursor,omitempty"`
+	Notifications []Notification `json:"notifications"`
+}
+
 // NotifyRequest defines model for NotifyRequest.
 type NotifyRequest struct {
-	FromUsername string `json:"from_username"`
-	Message      string `json:"message"`
+	// FromUsername Send as another user; requires the impersonate permission. Defaults to the session user
+	FromUsername *string `json:"from_username,omitempty"`
+	Message      string  `json:"message"`
+
+	// Priority How urgently the notification should be delivered; defaults to normal. Higher priorities are delivered ahead of queued lower ones, and critical ones even during do-not-disturb.
+	Priority *Priority `json:"priority,omitempty"`
+
+	// ReadReceipt Send a read_receipt event to the sender when a recipient reads the notification
+	ReadReceipt *bool `json:"read_receipt,omitempty"`
+
+	// SendAt Send at this future time instead of now; see /scheduled
+	SendAt *time.Time `json:"send_at,omitempty"`
+
+	// TargetGroups Groups whose members receive the notification, expanded when it is sent
+	TargetGroups *[]string `json:"target_groups,omitempty"`
 
-	// TargetUsername A specific username or 'all'
-	TargetUsername string `json:"target_username"`
+	// TargetUsername A specific username or 'all'. Required unless target_groups is set
+	TargetUsername *string `json:"target_username,omitempty"`
 }
 
 // NotifyResponse defines model for NotifyResponse.
 type NotifyResponse struct {
-	Success *bool `json:"success,omitempty"`
+	// ScheduledId ID of the scheduled send, if send_at was given
+	ScheduledId *string `json:"scheduled_id,omitempty"`
+	Success     *bool   `json:"success,omitempty"`
+}
+
+// PollResponse defines model for PollResponse.
+type PollResponse struct {
+	Events []PolledEvent `json:"events"`
+
+	// LastId Pass as after on the next poll
+	LastId int64 `json:"last_id"`
+}
+
+// PolledEvent defines model for PolledEvent.
+type PolledEvent struct {
+	Id      int64       `json:"id"`
+	Payload interface{} `json:"payload"`
+
+	// Priority How urgently the notification should be delivered; defaults to normal. Higher priorities are delivered ahead of queued lower ones, and critical ones even during do-not-disturb.
+	Priority  *Priority `json:"priority,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Type      string    `json:"type"`
+}
+
+// Priority How urgently the notification should be delivered; defaults to normal. Higher priorities are delivered ahead of queued lower ones, and critical ones even during do-not-disturb.
+type Priority string
+
+// RecurringRun defines model for RecurringRun.
+type RecurringRun struct {
+	At time.Time `json:"at"`
+
+	// Error Why the run sent nothing, e.g. a group that no longer exists
+	Error *string `json:"error,omitempty"`
+
+	// RequestId Acknowledgment request the run created
+	RequestId *string `json:"request_id,omitempty"`
+}
+
+// RecurringRunsResponse defines model for RecurringRunsResponse.
+type RecurringRunsResponse struct {
+	Runs []RecurringRun `json:"runs"`
+}
+
+// RecurringSchedule defines model for RecurringSchedule.
+type RecurringSchedule struct {
+	Acknowledgment *AcknowledgeRequestPayload `json:"acknowledgment,omitempty"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	CreatedBy      string                     `json:"created_by"`
+	Cron           string                     `json:"cron"`
+	DeadlineAfter  *int                       `json:"deadline_after,omitempty"`
+	Id             string                     `json:"id"`
+	Kind           RecurringScheduleKind      `json:"kind"`
+
+	// NextRun When the schedule runs next; absent while paused
+	NextRun      *time.Time     `json:"next_run,omitempty"`
+	Notification *NotifyRequest `json:"notification,omitempty"`
+	Paused       bool           `json:"paused"`
+	TimeZone     string         `json:"time_zone"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// RecurringScheduleKind defines model for RecurringSchedule.Kind.
+type RecurringScheduleKind string
+
+// RecurringScheduleRequest defines model for RecurringScheduleRequest.
+type RecurringScheduleRequest struct {
+	Acknowledgment *AcknowledgeRequestPayload `json:"acknowledgment,omitempty"`
+
+	// Cron Standard five-field cron expression (minute hour day-of-month month day-of-week) or a descriptor such as @daily or @every 1h
+	Cron string `json:"cron"`
+
+	// DeadlineAfter Seconds after each run that its acknowledgment request expires. Recurring acknowledgment requests take no absolute deadline.
+	DeadlineAfter *int           `json:"deadline_after,omitempty"`
+	Notification  *NotifyRequest `json:"notification,omitempty"`
+
+	// TimeZone IANA time zone the expression is evaluated in, e.g. Europe/Berlin; defaults to UTC
+	TimeZone *string `json:"time_zone,omitempty"`
+}
+
+// RecurringScheduleUpdateRequest defines model for RecurringScheduleUpdateRequest.
+type RecurringScheduleUpdateRequest struct {
+	Cron *string `json:"cron,omitempty"`
+
+	// Paused Paused schedules keep their history but do not run
+	Paused   *bool   `json:"paused,omitempty"`
+	TimeZone *string `json:"time_zone,omitempty"`
+}
+
+// RecurringSchedulesResponse defines model for RecurringSchedulesResponse.
+type RecurringSchedulesResponse struct {
+	Recurring []RecurringSchedule `json:"recurring"`
+}
+
+// RegisterRequest defines model for RegisterRequest.
+type RegisterRequest struct {
+	// Password Between 8 and 72 bytes
+	Password string `json:"password"`
+
+	// Username Letters, digits and _ . @ -, starting with a letter or digit; at most 64 characters
+	Username string `json:"username"`
+}
+
+// ScheduledSend defines model for ScheduledSend.
+type ScheduledSend struct {
+	Acknowledgment *AcknowledgeRequestPayload `json:"acknowledgment,omitempty"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	CreatedBy      string                     `json:"created_by"`
+	Id             string                     `json:"id"`
+	Kind           ScheduledSendKind          `json:"kind"`
+	Notification   *NotifyRequest             `json:"notification,omitempty"`
+	SendAt         time.Time                  `json:"send_at"`
+}
+
+// ScheduledSendKind defines model for ScheduledSend.Kind.
+type ScheduledSendKind string
+
+// ScheduledSendsResponse defines model for ScheduledSendsResponse.
+type ScheduledSendsResponse struct {
+	Scheduled []ScheduledSend `json:"scheduled"`
+}
+
+// SessionResponse defines model for SessionResponse.
+type SessionResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Issuer OIDC provider the user logged in through; absent for password logins
+	Issuer   *string `json:"issuer,omitempty"`
+	Username string  `json:"username"`
+}
+
+// TokenScope notify allows POST /notify; acknowledge allows POST /acknowledge/request
+type TokenScope string
+
+// TokensResponse defines model for TokensResponse.
+type TokensResponse struct {
+	Tokens []ApiToken `json:"tokens"`
+}
+
+// UnreadCountResponse defines model for UnreadCountResponse.
+type UnreadCountResponse struct {
+	UnreadCount int `json:"unread_count"`
 }
 
 // UsersResponse defines model for UsersResponse.
@@ -80,18 +545,97 @@ type UsersResponse struct {
 	Users *[]string `json:"users,omitempty"`
 }
 
+// GetEventsParams defines parameters for GetEvents.
+type GetEventsParams struct {
+	// Types Comma separated event types to receive, e.g. notification,acknowledgment_request; omit for all
+	Types *[]string `form:"types,omitempty" json:"types,omitempty"`
+
+	// Format Wire format. "named" sets the SSE event field to the event type and sends the payload as data. "envelope" sends unnamed frames whose data is the {type, payload, timestamp} JSON envelope, as older clients expect.
+	Format *GetEventsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// LastEventID ID of the last event received; missed events are replayed before the stream goes live
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// GetEventsParamsFormat defines parameters for GetEvents.
+type GetEventsParamsFormat string
+
+// GetEventsPollParams defines parameters for GetEventsPoll.
+type GetEventsPollParams struct {
+	// After last_id of the previous poll. Omit on the first poll to wait for new events only.
+	After *int64 `form:"after,omitempty" json:"after,omitempty"`
+
+	// Timeout Seconds to wait for an event before returning an empty batch
+	Timeout *int `form:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// GetNotificationsParams defines parameters for GetNotifications.
+type GetNotificationsParams struct {
+	// Cursor Cursor from a previous page's next_cursor; omit for the newest notifications
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of notifications to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetOidcCallbackParams defines parameters for GetOidcCallback.
+type GetOidcCallbackParams struct {
+	Code  *string `form:"code,omitempty" json:"code,omitempty"`
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+
+	// Error Set by the provider when the user did not log in
+	Error     *string `form:"error,omitempty" json:"error,omitempty"`
+	OidcState *string `form:"oidc_state,omitempty" json:"oidc_state,omitempty"`
+}
+
+// GetOidcLoginParams defines parameters for GetOidcLogin.
+type GetOidcLoginParams struct {
+	// LoginHint Passed on to the provider to preselect the account
+	LoginHint *string `form:"login_hint,omitempty" json:"login_hint,omitempty"`
+}
+
 // PostAcknowledgeRequestJSONRequestBody defines body for PostAcknowledgeRequest for application/json ContentType.
 type PostAcknowledgeRequestJSONRequestBody = AcknowledgeRequestPayload
 
 // PostAcknowledgeResponseJSONRequestBody defines body for PostAcknowledgeResponse for application/json ContentType.
 type PostAcknowledgeResponseJSONRequestBody = AcknowledgeResponsePayload
 
+// PostChannelPublishJSONRequestBody defines body for PostChannelPublish for application/json ContentType.
+type PostChannelPublishJSONRequestBody = ChannelPublishRequest
+
+// PutDoNotDisturbJSONRequestBody defines body for PutDoNotDisturb for application/json ContentType.
+type PutDoNotDisturbJSONRequestBody = DoNotDisturb
+
+// PostGroupJSONRequestBody defines body for PostGroup for application/json ContentType.
+type PostGroupJSONRequestBody = GroupCreateRequest
+
+// PutGroupJSONRequestBody defines body for PutGroup for application/json ContentType.
+type PutGroupJSONRequestBody = GroupUpdateRequest
+
+// PostGroupMembersJSONRequestBody defines body for PostGroupMembers for application/json ContentType.
+type PostGroupMembersJSONRequestBody = GroupMembersRequest
+
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody = LoginRequest
 
 // PostNotifyJSONRequestBody defines body for PostNotify for application/json ContentType.
 type PostNotifyJSONRequestBody = NotifyRequest
 
+// PutPasswordJSONRequestBody defines body for PutPassword for application/json ContentType.
+type PutPasswordJSONRequestBody = ChangePasswordRequest
+
+// PostRecurringJSONRequestBody defines body for PostRecurring for application/json ContentType.
+type PostRecurringJSONRequestBody = RecurringScheduleRequest
+
+// PutRecurringScheduleJSONRequestBody defines body for PutRecurringSchedule for application/json ContentType.
+type PutRecurringScheduleJSONRequestBody = RecurringScheduleUpdateRequest
+
+// PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
+type PostRegisterJSONRequestBody = RegisterRequest
+
+// PostTokenJSONRequestBody defines body for PostToken for application/json ContentType.
+type PostTokenJSONRequestBody = CreateTokenRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Sends an acknowledgment request to user(s) (requires authentication)
@@ -100,21 +644,138 @@ type ServerInterface interface {
 	// Responds to an acknowledgment request (requires authentication)
 	// (POST /acknowledge/response)
 	PostAcknowledgeResponse(c *gin.Context)
+	// Gets the status of an acknowledgment request (requires authentication)
+	// (GET /acknowledge/{id})
+	GetAcknowledgment(c *gin.Context, id string)
+	// Summarises the responses to an acknowledgment request per option (requires authentication)
+	// (GET /acknowledge/{id}/tally)
+	GetAcknowledgmentTally(c *gin.Context, id string)
+	// Lists the channels and their member counts (requires authentication)
+	// (GET /channels)
+	GetChannels(c *gin.Context)
+	// Joins a channel, creating it if needed (requires authentication)
+	// (POST /channels/{name}/join)
+	PostChannelJoin(c *gin.Context, name string)
+	// Leaves a channel (requires authentication)
+	// (POST /channels/{name}/leave)
+	PostChannelLeave(c *gin.Context, name string)
+	// Lists the members of a channel (requires authentication)
+	// (GET /channels/{name}/members)
+	GetChannelMembers(c *gin.Context, name string)
+	// Sends a notification to every member of a channel (requires authentication)
+	// (POST /channels/{name}/publish)
+	PostChannelPublish(c *gin.Context, name string)
+	// Gets the current user's do-not-disturb setting (requires authentication)
+	// (GET /dnd)
+	GetDoNotDisturb(c *gin.Context)
+	// Turns do-not-disturb on or off for the current user. While on, only critical notifications are delivered live; the rest still reach the notification history (requires authentication)
+	// (PUT /dnd)
+	PutDoNotDisturb(c *gin.Context)
 	// Subscribes to the SSE notification stream (requires authentication)
 	// (GET /events)
-	GetEvents(c *gin.Context)
+	GetEvents(c *gin.Context, params GetEventsParams)
+	// Long-polls the event stream, for networks that buffer SSE (requires authentication)
+	// (GET /events/poll)
+	GetEventsPoll(c *gin.Context, params GetEventsPollParams)
+	// Lists the recipient groups (requires authentication)
+	// (GET /groups)
+	GetGroups(c *gin.Context)
+	// Creates a recipient group owned by the current user (requires authentication)
+	// (POST /groups)
+	PostGroup(c *gin.Context)
+	// Deletes a group; only its creator may (requires authentication)
+	// (DELETE /groups/{name})
+	DeleteGroup(c *gin.Context, name string)
+	// Gets a recipient group (requires authentication)
+	// (GET /groups/{name})
+	GetGroup(c *gin.Context, name string)
+	// Replaces a group's description and/or members; only its creator may (requires authentication)
+	// (PUT /groups/{name})
+	PutGroup(c *gin.Context, name string)
+	// Adds members to a group; only its creator may (requires authentication)
+	// (POST /groups/{name}/members)
+	PostGroupMembers(c *gin.Context, name string)
+	// Removes a member from a group; only its creator may (requires authentication)
+	// (DELETE /groups/{name}/members/{username})
+	DeleteGroupMember(c *gin.Context, name string, username string)
 	// Logs a user in and creates a session
 	// (POST /login)
 	PostLogin(c *gin.Context)
+	// Lists the ways users can log in
+	// (GET /login/methods)
+	GetLoginMethods(c *gin.Context)
 	// Logs a user out and destroys the session
 	// (POST /logout)
 	PostLogout(c *gin.Context)
+	// Lists the user's notification history, newest first (requires authentication)
+	// (GET /notifications)
+	GetNotifications(c *gin.Context, params GetNotificationsParams)
+	// Marks all notifications as read (requires authentication)
+	// (POST /notifications/read-all)
+	PostNotificationsReadAll(c *gin.Context)
+	// Gets the number of unread notifications (requires authentication)
+	// (GET /notifications/unread-count)
+	GetNotificationsUnreadCount(c *gin.Context)
+	// Marks a notification as read (requires authentication)
+	// (POST /notifications/{id}/read)
+	PostNotificationRead(c *gin.Context, id string)
 	// Broadcasts a notification (requires authentication)
 	// (POST /notify)
 	PostNotify(c *gin.Context)
+	// Completes single sign-on; the provider redirects the browser here
+	// (GET /oidc/callback)
+	GetOidcCallback(c *gin.Context, params GetOidcCallbackParams)
+	// Starts single sign-on by redirecting to the OIDC provider
+	// (GET /oidc/login)
+	GetOidcLogin(c *gin.Context, params GetOidcLoginParams)
+	// Changes the current user's password and signs out their other sessions (requires authentication)
+	// (PUT /password)
+	PutPassword(c *gin.Context)
+	// Lists the current user's recurring schedules, oldest first (requires authentication)
+	// (GET /recurring)
+	GetRecurring(c *gin.Context)
+	// Creates a schedule that sends a notification or acknowledgment request on every tick of a cron expression (requires authentication)
+	// (POST /recurring)
+	PostRecurring(c *gin.Context)
+	// Deletes a recurring schedule and its history; only its creator may (requires authentication)
+	// (DELETE /recurring/{id})
+	DeleteRecurringSchedule(c *gin.Context, id string)
+	// Gets a recurring schedule (requires authentication)
+	// (GET /recurring/{id})
+	GetRecurringSchedule(c *gin.Context, id string)
+	// Changes a recurring schedule's cron expression, time zone or paused state; only its creator may (requires authentication)
+	// (PUT /recurring/{id})
+	PutRecurringSchedule(c *gin.Context, id string)
+	// Lists the latest executions of a recurring schedule, newest first (requires authentication)
+	// (GET /recurring/{id}/runs)
+	GetRecurringRuns(c *gin.Context, id string)
+	// Registers a user with a password and logs them in
+	// (POST /register)
+	PostRegister(c *gin.Context)
+	// Lists the current user's pending scheduled sends, soonest first (requires authentication)
+	// (GET /scheduled)
+	GetScheduled(c *gin.Context)
+	// Cancels a pending scheduled send (requires authentication)
+	// (DELETE /scheduled/{id})
+	DeleteScheduled(c *gin.Context, id string)
+	// Gets the user the current session belongs to (requires authentication)
+	// (GET /session)
+	GetSession(c *gin.Context)
+	// Lists the API tokens the current user created (requires authentication)
+	// (GET /tokens)
+	GetTokens(c *gin.Context)
+	// Creates an API token for calling the API with Authorization: Bearer (requires authentication)
+	// (POST /tokens)
+	PostToken(c *gin.Context)
+	// Revokes an API token; only its creator may (requires authentication)
+	// (DELETE /tokens/{id})
+	DeleteToken(c *gin.Context, id string)
 	// Gets list of currently connected users (requires authentication)
 	// (GET /users)
 	GetUsers(c *gin.Context)
+	// Opens a WebSocket carrying the event stream and accepting actions (requires authentication)
+	// (GET /ws)
+	GetWs(c *gin.Context)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -131,6 +792,8 @@ func (siw *ServerInterfaceWrapper) PostAcknowledgeRequest(c *gin.Context) {
 
 	c.Set(CookieAuthScopes, []string{})
 
+	c.Set(BearerAuthScopes, []string{"acknowledge"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -156,8 +819,19 @@ func (siw *ServerInterfaceWrapper) PostAcknowledgeResponse(c *gin.Context) {
 	siw.Handler.PostAcknowledgeResponse(c)
 }
 
-// GetEvents operation middleware
-func (siw *ServerInterfaceWrapper) GetEvents(c *gin.Context) {
+// GetAcknowledgment operation middleware
+func (siw *ServerInterfaceWrapper) GetAcknowledgment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(CookieAuthScopes, []string{})
 
@@ -168,11 +842,24 @@ func (siw *ServerInterfaceWrapper) GetEvents(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetEvents(c)
+	siw.Handler.GetAcknowledgment(c, id)
 }
 
-// PostLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
+// GetAcknowledgmentTally operation middleware
+func (siw *ServerInterfaceWrapper) GetAcknowledgmentTally(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -181,11 +868,11 @@ func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostLogin(c)
+	siw.Handler.GetAcknowledgmentTally(c, id)
 }
 
-// PostLogout operation middleware
-func (siw *ServerInterfaceWrapper) PostLogout(c *gin.Context) {
+// GetChannels operation middleware
+func (siw *ServerInterfaceWrapper) GetChannels(c *gin.Context) {
 
 	c.Set(CookieAuthScopes, []string{})
 
@@ -196,11 +883,22 @@ func (siw *ServerInterfaceWrapper) PostLogout(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostLogout(c)
+	siw.Handler.GetChannels(c)
 }
 
-// PostNotify operation middleware
-func (siw *ServerInterfaceWrapper) PostNotify(c *gin.Context) {
+// PostChannelJoin operation middleware
+func (siw *ServerInterfaceWrapper) PostChannelJoin(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(CookieAuthScopes, []string{})
 
@@ -211,11 +909,22 @@ func (siw *ServerInterfaceWrapper) PostNotify(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostNotify(c)
+	siw.Handler.PostChannelJoin(c, name)
 }
 
-// GetUsers operation middleware
-func (siw *ServerInterfaceWrapper) GetUsers(c *gin.Context) {
+// PostChannelLeave operation middleware
+func (siw *ServerInterfaceWrapper) PostChannelLeave(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(CookieAuthScopes, []string{})
 
@@ -226,309 +935,3809 @@ func (siw *ServerInterfaceWrapper) GetUsers(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUsers(c)
+	siw.Handler.PostChannelLeave(c, name)
 }
 
-// GinServerOptions provides options for the Gin server.
-type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
-}
+// GetChannelMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetChannelMembers(c *gin.Context) {
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router gin.IRouter, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, GinServerOptions{})
-}
+	var err error
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	router.POST(options.BaseURL+"/acknowledge/request", wrapper.PostAcknowledgeRequest)
-	router.POST(options.BaseURL+"/acknowledge/response", wrapper.PostAcknowledgeResponse)
-	router.GET(options.BaseURL+"/events", wrapper.GetEvents)
-	router.POST(options.BaseURL+"/login", wrapper.PostLogin)
-	router.POST(options.BaseURL+"/logout", wrapper.PostLogout)
-	router.POST(options.BaseURL+"/notify", wrapper.PostNotify)
-	router.GET(options.BaseURL+"/users", wrapper.GetUsers)
+	siw.Handler.GetChannelMembers(c, name)
 }
 
-type PostAcknowledgeRequestRequestObject struct {
-	Body *PostAcknowledgeRequestJSONRequestBody
-}
+// PostChannelPublish operation middleware
+func (siw *ServerInterfaceWrapper) PostChannelPublish(c *gin.Context) {
 
-type PostAcknowledgeRequestResponseObject interface {
-	VisitPostAcknowledgeRequestResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type PostAcknowledgeRequest200JSONResponse AcknowledgeRequestResponse
+	// ------------- Path parameter "name" -------------
+	var name string
 
-func (response PostAcknowledgeRequest200JSONResponse) VisitPostAcknowledgeRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	c.Set(CookieAuthScopes, []string{})
 
-type PostAcknowledgeRequest400Response struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-func (response PostAcknowledgeRequest400Response) VisitPostAcknowledgeRequestResponse(w http.ResponseWriter) error {
-	w.WriteHeader(400)
-	return nil
+	siw.Handler.PostChannelPublish(c, name)
 }
 
-type PostAcknowledgeRequest401Response struct {
-}
+// GetDoNotDisturb operation middleware
+func (siw *ServerInterfaceWrapper) GetDoNotDisturb(c *gin.Context) {
 
-func (response PostAcknowledgeRequest401Response) VisitPostAcknowledgeRequestResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
-	return nil
-}
+	c.Set(CookieAuthScopes, []string{})
 
-type PostAcknowledgeResponseRequestObject struct {
-	Body *PostAcknowledgeResponseJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type PostAcknowledgeResponseResponseObject interface {
-	VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error
+	siw.Handler.GetDoNotDisturb(c)
 }
 
-type PostAcknowledgeResponse200JSONResponse AcknowledgeResponseResponse
+// PutDoNotDisturb operation middleware
+func (siw *ServerInterfaceWrapper) PutDoNotDisturb(c *gin.Context) {
 
-func (response PostAcknowledgeResponse200JSONResponse) VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	c.Set(CookieAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type PostAcknowledgeResponse400Response struct {
+	siw.Handler.PutDoNotDisturb(c)
 }
 
-func (response PostAcknowledgeResponse400Response) VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error {
-	w.WriteHeader(400)
-	return nil
-}
+// GetEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetEvents(c *gin.Context) {
 
-type PostAcknowledgeResponse401Response struct {
-}
+	var err error
 
-func (response PostAcknowledgeResponse401Response) VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
-	return nil
-}
+	c.Set(CookieAuthScopes, []string{})
 
-type GetEventsRequestObject struct {
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsParams
 
-type GetEventsResponseObject interface {
-	VisitGetEventsResponse(w http.ResponseWriter) error
-}
+	// ------------- Optional query parameter "types" -------------
 
-type GetEvents200TexteventStreamResponse struct {
-	Body          io.Reader
-	ContentLength int64
-}
+	err = runtime.BindQueryParameter("form", false, false, "types", c.Request.URL.Query(), &params.Types)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter types: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response GetEvents200TexteventStreamResponse) VisitGetEventsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/event-stream")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
 	}
-	w.WriteHeader(200)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Last-Event-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Last-Event-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
 	}
-	_, err := io.Copy(w, response.Body)
-	return err
-}
 
-type GetEvents401Response struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-func (response GetEvents401Response) VisitGetEventsResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
-	return nil
+	siw.Handler.GetEvents(c, params)
 }
 
-type PostLoginRequestObject struct {
-	Body *PostLoginJSONRequestBody
-}
+// GetEventsPoll operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsPoll(c *gin.Context) {
 
-type PostLoginResponseObject interface {
-	VisitPostLoginResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type PostLogin200ResponseHeaders struct {
-	SetCookie string
-}
+	c.Set(CookieAuthScopes, []string{})
 
-type PostLogin200JSONResponse struct {
-	Body    LoginResponse
-	Headers PostLogin200ResponseHeaders
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsPollParams
 
-func (response PostLogin200JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Set-Cookie", fmt.Sprint(response.Headers.SetCookie))
-	w.WriteHeader(200)
+	// ------------- Optional query parameter "after" -------------
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	err = runtime.BindQueryParameter("form", true, false, "after", c.Request.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter after: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type PostLogin400Response struct {
-}
+	// ------------- Optional query parameter "timeout" -------------
 
-func (response PostLogin400Response) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.WriteHeader(400)
-	return nil
-}
+	err = runtime.BindQueryParameter("form", true, false, "timeout", c.Request.URL.Query(), &params.Timeout)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timeout: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type PostLogin401Response struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-func (response PostLogin401Response) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
-	return nil
+	siw.Handler.GetEventsPoll(c, params)
 }
 
-type PostLogoutRequestObject struct {
+// GetGroups operation middleware
+func (siw *ServerInterfaceWrapper) GetGroups(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroups(c)
 }
 
-type PostLogoutResponseObject interface {
-	VisitPostLogoutResponse(w http.ResponseWriter) error
+// PostGroup operation middleware
+func (siw *ServerInterfaceWrapper) PostGroup(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostGroup(c)
 }
 
-type PostLogout200JSONResponse LogoutResponse
+// DeleteGroup operation middleware
+func (siw *ServerInterfaceWrapper) DeleteGroup(c *gin.Context) {
 
-func (response PostLogout200JSONResponse) VisitPostLogoutResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteGroup(c, name)
 }
 
-type PostLogout401Response struct {
+// GetGroup operation middleware
+func (siw *ServerInterfaceWrapper) GetGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroup(c, name)
 }
 
-func (response PostLogout401Response) VisitPostLogoutResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
-	return nil
+// PutGroup operation middleware
+func (siw *ServerInterfaceWrapper) PutGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutGroup(c, name)
 }
 
-type PostNotifyRequestObject struct {
-	Body *PostNotifyJSONRequestBody
+// PostGroupMembers operation middleware
+func (siw *ServerInterfaceWrapper) PostGroupMembers(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostGroupMembers(c, name)
 }
 
-type PostNotifyResponseObject interface {
-	VisitPostNotifyResponse(w http.ResponseWriter) error
+// DeleteGroupMember operation middleware
+func (siw *ServerInterfaceWrapper) DeleteGroupMember(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", c.Param("username"), &username, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter username: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteGroupMember(c, name, username)
+}
+
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogin(c)
+}
+
+// GetLoginMethods operation middleware
+func (siw *ServerInterfaceWrapper) GetLoginMethods(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLoginMethods(c)
+}
+
+// PostLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostLogout(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogout(c)
+}
+
+// GetNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetNotifications(c *gin.Context) {
+
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotifications(c, params)
+}
+
+// PostNotificationsReadAll operation middleware
+func (siw *ServerInterfaceWrapper) PostNotificationsReadAll(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostNotificationsReadAll(c)
+}
+
+// GetNotificationsUnreadCount operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationsUnreadCount(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotificationsUnreadCount(c)
+}
+
+// PostNotificationRead operation middleware
+func (siw *ServerInterfaceWrapper) PostNotificationRead(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostNotificationRead(c, id)
+}
+
+// PostNotify operation middleware
+func (siw *ServerInterfaceWrapper) PostNotify(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{"notify"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostNotify(c)
+}
+
+// GetOidcCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetOidcCallback(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOidcCallbackParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", c.Request.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", c.Request.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter state: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", c.Request.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter error: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("oidc_state"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "oidc_state", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter oidc_state: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.OidcState = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOidcCallback(c, params)
+}
+
+// GetOidcLogin operation middleware
+func (siw *ServerInterfaceWrapper) GetOidcLogin(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOidcLoginParams
+
+	// ------------- Optional query parameter "login_hint" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "login_hint", c.Request.URL.Query(), &params.LoginHint)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter login_hint: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOidcLogin(c, params)
+}
+
+// PutPassword operation middleware
+func (siw *ServerInterfaceWrapper) PutPassword(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutPassword(c)
+}
+
+// GetRecurring operation middleware
+func (siw *ServerInterfaceWrapper) GetRecurring(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetRecurring(c)
+}
+
+// PostRecurring operation middleware
+func (siw *ServerInterfaceWrapper) PostRecurring(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRecurring(c)
+}
+
+// DeleteRecurringSchedule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRecurringSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteRecurringSchedule(c, id)
+}
+
+// GetRecurringSchedule operation middleware
+func (siw *ServerInterfaceWrapper) GetRecurringSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetRecurringSchedule(c, id)
+}
+
+// PutRecurringSchedule operation middleware
+func (siw *ServerInterfaceWrapper) PutRecurringSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutRecurringSchedule(c, id)
+}
+
+// GetRecurringRuns operation middleware
+func (siw *ServerInterfaceWrapper) GetRecurringRuns(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetRecurringRuns(c, id)
+}
+
+// PostRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRegister(c)
+}
+
+// GetScheduled operation middleware
+func (siw *ServerInterfaceWrapper) GetScheduled(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetScheduled(c)
+}
+
+// DeleteScheduled operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScheduled(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteScheduled(c, id)
+}
+
+// GetSession operation middleware
+func (siw *ServerInterfaceWrapper) GetSession(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSession(c)
+}
+
+// GetTokens operation middleware
+func (siw *ServerInterfaceWrapper) GetTokens(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTokens(c)
+}
+
+// PostToken operation middleware
+func (siw *ServerInterfaceWrapper) PostToken(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostToken(c)
+}
+
+// DeleteToken operation middleware
+func (siw *ServerInterfaceWrapper) DeleteToken(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteToken(c, id)
+}
+
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsers(c)
+}
+
+// GetWs operation middleware
+func (siw *ServerInterfaceWrapper) GetWs(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWs(c)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.POST(options.BaseURL+"/acknowledge/request", wrapper.PostAcknowledgeRequest)
+	router.POST(options.BaseURL+"/acknowledge/response", wrapper.PostAcknowledgeResponse)
+	router.GET(options.BaseURL+"/acknowledge/:id", wrapper.GetAcknowledgment)
+	router.GET(options.BaseURL+"/acknowledge/:id/tally", wrapper.GetAcknowledgmentTally)
+	router.GET(options.BaseURL+"/channels", wrapper.GetChannels)
+	router.POST(options.BaseURL+"/channels/:name/join", wrapper.PostChannelJoin)
+	router.POST(options.BaseURL+"/channels/:name/leave", wrapper.PostChannelLeave)
+	router.GET(options.BaseURL+"/channels/:name/members", wrapper.GetChannelMembers)
+	router.POST(options.BaseURL+"/channels/:name/publish", wrapper.PostChannelPublish)
+	router.GET(options.BaseURL+"/dnd", wrapper.GetDoNotDisturb)
+	router.PUT(options.BaseURL+"/dnd", wrapper.PutDoNotDisturb)
+	router.GET(options.BaseURL+"/events", wrapper.GetEvents)
+	router.GET(options.BaseURL+"/events/poll", wrapper.GetEventsPoll)
+	router.GET(options.BaseURL+"/groups", wrapper.GetGroups)
+	router.POST(options.BaseURL+"/groups", wrapper.PostGroup)
+	router.DELETE(options.BaseURL+"/groups/:name", wrapper.DeleteGroup)
+	router.GET(options.BaseURL+"/groups/:name", wrapper.GetGroup)
+	router.PUT(options.BaseURL+"/groups/:name", wrapper.PutGroup)
+	router.POST(options.BaseURL+"/groups/:name/members", wrapper.PostGroupMembers)
+	router.DELETE(options.BaseURL+"/groups/:name/members/:username", wrapper.DeleteGroupMember)
+	router.POST(options.BaseURL+"/login", wrapper.PostLogin)
+	router.GET(options.BaseURL+"/login/methods", wrapper.GetLoginMethods)
+	router.POST(options.BaseURL+"/logout", wrapper.PostLogout)
+	router.GET(options.BaseURL+"/notifications", wrapper.GetNotifications)
+	router.POST(options.BaseURL+"/notifications/read-all", wrapper.PostNotificationsReadAll)
+	router.GET(options.BaseURL+"/notifications/unread-count", wrapper.GetNotificationsUnreadCount)
+	router.POST(options.BaseURL+"/notifications/:id/read", wrapper.PostNotificationRead)
+	router.POST(options.BaseURL+"/notify", wrapper.PostNotify)
+	router.GET(options.BaseURL+"/oidc/callback", wrapper.GetOidcCallback)
+	router.GET(options.BaseURL+"/oidc/login", wrapper.GetOidcLogin)
+	router.PUT(options.BaseURL+"/password", wrapper.PutPassword)
+	router.GET(options.BaseURL+"/recurring", wrapper.GetRecurring)
+	router.POST(options.BaseURL+"/recurring", wrapper.PostRecurring)
+	router.DELETE(options.BaseURL+"/recurring/:id", wrapper.DeleteRecurringSchedule)
+	router.GET(options.BaseURL+"/recurring/:id", wrapper.GetRecurringSchedule)
+	router.PUT(options.BaseURL+"/recurring/:id", wrapper.PutRecurringSchedule)
+	router.GET(options.BaseURL+"/recurring/:id/runs", wrapper.GetRecurringRuns)
+	router.POST(options.BaseURL+"/register", wrapper.PostRegister)
+	router.GET(options.BaseURL+"/scheduled", wrapper.GetScheduled)
+	router.DELETE(options.BaseURL+"/scheduled/:id", wrapper.DeleteScheduled)
+	router.GET(options.BaseURL+"/session", wrapper.GetSession)
+	router.GET(options.BaseURL+"/tokens", wrapper.GetTokens)
+	router.POST(options.BaseURL+"/tokens", wrapper.PostToken)
+	router.DELETE(options.BaseURL+"/tokens/:id", wrapper.DeleteToken)
+	router.GET(options.BaseURL+"/users", wrapper.GetUsers)
+	router.GET(options.BaseURL+"/ws", wrapper.GetWs)
+}
+
+type PostAcknowledgeRequestRequestObject struct {
+	Body *PostAcknowledgeRequestJSONRequestBody
+}
+
+type PostAcknowledgeRequestResponseObject interface {
+	VisitPostAcknowledgeRequestResponse(w http.ResponseWriter) error
+}
+
+type PostAcknowledgeRequest200JSONResponse AcknowledgeRequestResponse
+
+func (response PostAcknowledgeRequest200JSONResponse) VisitPostAcknowledgeRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAcknowledgeRequest400Response struct {
+}
+
+func (response PostAcknowledgeRequest400Response) VisitPostAcknowledgeRequestResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostAcknowledgeRequest401Response struct {
+}
+
+func (response PostAcknowledgeRequest401Response) VisitPostAcknowledgeRequestResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAcknowledgeRequest403Response struct {
+}
+
+func (response PostAcknowledgeRequest403Response) VisitPostAcknowledgeRequestResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostAcknowledgeResponseRequestObject struct {
+	Body *PostAcknowledgeResponseJSONRequestBody
+}
+
+type PostAcknowledgeResponseResponseObject interface {
+	VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error
+}
+
+type PostAcknowledgeResponse200JSONResponse AcknowledgeResponseResponse
+
+func (response PostAcknowledgeResponse200JSONResponse) VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAcknowledgeResponse400Response struct {
+}
+
+func (response PostAcknowledgeResponse400Response) VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostAcknowledgeResponse401Response struct {
+}
+
+func (response PostAcknowledgeResponse401Response) VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAcknowledgeResponse403Response struct {
+}
+
+func (response PostAcknowledgeResponse403Response) VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostAcknowledgeResponse404Response struct {
+}
+
+func (response PostAcknowledgeResponse404Response) VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAcknowledgeResponse409Response struct {
+}
+
+func (response PostAcknowledgeResponse409Response) VisitPostAcknowledgeResponseResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type GetAcknowledgmentRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetAcknowledgmentResponseObject interface {
+	VisitGetAcknowledgmentResponse(w http.ResponseWriter) error
+}
+
+type GetAcknowledgment200JSONResponse AcknowledgmentStatus
+
+func (response GetAcknowledgment200JSONResponse) VisitGetAcknowledgmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAcknowledgment401Response struct {
+}
+
+func (response GetAcknowledgment401Response) VisitGetAcknowledgmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetAcknowledgment403Response struct {
+}
+
+func (response GetAcknowledgment403Response) VisitGetAcknowledgmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetAcknowledgment404Response struct {
+}
+
+func (response GetAcknowledgment404Response) VisitGetAcknowledgmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetAcknowledgmentTallyRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetAcknowledgmentTallyResponseObject interface {
+	VisitGetAcknowledgmentTallyResponse(w http.ResponseWriter) error
+}
+
+type GetAcknowledgmentTally200JSONResponse AcknowledgmentTally
+
+func (response GetAcknowledgmentTally200JSONResponse) VisitGetAcknowledgmentTallyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAcknowledgmentTally401Response struct {
+}
+
+func (response GetAcknowledgmentTally401Response) VisitGetAcknowledgmentTallyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetAcknowledgmentTally403Response struct {
+}
+
+func (response GetAcknowledgmentTally403Response) VisitGetAcknowledgmentTallyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetAcknowledgmentTally404Response struct {
+}
+
+func (response GetAcknowledgmentTally404Response) VisitGetAcknowledgmentTallyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetChannelsRequestObject struct {
+}
+
+type GetChannelsResponseObject interface {
+	VisitGetChannelsResponse(w http.ResponseWriter) error
+}
+
+type GetChannels200JSONResponse ChannelsResponse
+
+func (response GetChannels200JSONResponse) VisitGetChannelsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannels401Response struct {
+}
+
+func (response GetChannels401Response) VisitGetChannelsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostChannelJoinRequestObject struct {
+	Name string `json:"name"`
+}
+
+type PostChannelJoinResponseObject interface {
+	VisitPostChannelJoinResponse(w http.ResponseWriter) error
+}
+
+type PostChannelJoin200JSONResponse ChannelMembershipResponse
+
+func (response PostChannelJoin200JSONResponse) VisitPostChannelJoinResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostChannelJoin400Response struct {
+}
+
+func (response PostChannelJoin400Response) VisitPostChannelJoinResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostChannelJoin401Response struct {
+}
+
+func (response PostChannelJoin401Response) VisitPostChannelJoinResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostChannelLeaveRequestObject struct {
+	Name string `json:"name"`
+}
+
+type PostChannelLeaveResponseObject interface {
+	VisitPostChannelLeaveResponse(w http.ResponseWriter) error
+}
+
+type PostChannelLeave200JSONResponse ChannelMembershipResponse
+
+func (response PostChannelLeave200JSONResponse) VisitPostChannelLeaveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostChannelLeave400Response struct {
+}
+
+func (response PostChannelLeave400Response) VisitPostChannelLeaveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostChannelLeave401Response struct {
+}
+
+func (response PostChannelLeave401Response) VisitPostChannelLeaveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostChannelLeave404Response struct {
+}
+
+func (response PostChannelLeave404Response) VisitPostChannelLeaveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetChannelMembersRequestObject struct {
+	Name string `json:"name"`
+}
+
+type GetChannelMembersResponseObject interface {
+	VisitGetChannelMembersResponse(w http.ResponseWriter) error
+}
+
+type GetChannelMembers200JSONResponse ChannelMembersResponse
+
+func (response GetChannelMembers200JSONResponse) VisitGetChannelMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelMembers400Response struct {
+}
+
+func (response GetChannelMembers400Response) VisitGetChannelMembersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetChannelMembers401Response struct {
+}
+
+func (response GetChannelMembers401Response) VisitGetChannelMembersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetChannelMembers404Response struct {
+}
+
+func (response GetChannelMembers404Response) VisitGetChannelMembersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostChannelPublishRequestObject struct {
+	Name string `json:"name"`
+	Body *PostChannelPublishJSONRequestBody
+}
+
+type PostChannelPublishResponseObject interface {
+	VisitPostChannelPublishResponse(w http.ResponseWriter) error
+}
+
+type PostChannelPublish200JSONResponse ChannelPublishResponse
+
+func (response PostChannelPublish200JSONResponse) VisitPostChannelPublishResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostChannelPublish400Response struct {
+}
+
+func (response PostChannelPublish400Response) VisitPostChannelPublishResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostChannelPublish401Response struct {
+}
+
+func (response PostChannelPublish401Response) VisitPostChannelPublishResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostChannelPublish403Response struct {
+}
+
+func (response PostChannelPublish403Response) VisitPostChannelPublishResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetDoNotDisturbRequestObject struct {
+}
+
+type GetDoNotDisturbResponseObject interface {
+	VisitGetDoNotDisturbResponse(w http.ResponseWriter) error
+}
+
+type GetDoNotDisturb200JSONResponse DoNotDisturb
+
+func (response GetDoNotDisturb200JSONResponse) VisitGetDoNotDisturbResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDoNotDisturb401Response struct {
+}
+
+func (response GetDoNotDisturb401Response) VisitGetDoNotDisturbResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PutDoNotDisturbRequestObject struct {
+	Body *PutDoNotDisturbJSONRequestBody
+}
+
+type PutDoNotDisturbResponseObject interface {
+	VisitPutDoNotDisturbResponse(w http.ResponseWriter) error
+}
+
+type PutDoNotDisturb200JSONResponse DoNotDisturb
+
+func (response PutDoNotDisturb200JSONResponse) VisitPutDoNotDisturbResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutDoNotDisturb400Response struct {
+}
+
+func (response PutDoNotDisturb400Response) VisitPutDoNotDisturbResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PutDoNotDisturb401Response struct {
+}
+
+func (response PutDoNotDisturb401Response) VisitPutDoNotDisturbResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetEventsRequestObject struct {
+	Params GetEventsParams
+}
+
+type GetEventsResponseObject interface {
+	VisitGetEventsResponse(w http.ResponseWriter) error
+}
+
+type GetEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetEvents200TexteventStreamResponse) VisitGetEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetEvents400Response struct {
+}
+
+func (response GetEvents400Response) VisitGetEventsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetEvents401Response struct {
+}
+
+func (response GetEvents401Response) VisitGetEventsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetEventsPollRequestObject struct {
+	Params GetEventsPollParams
+}
+
+type GetEventsPollResponseObject interface {
+	VisitGetEventsPollResponse(w http.ResponseWriter) error
+}
+
+type GetEventsPoll200JSONResponse PollResponse
+
+func (response GetEventsPoll200JSONResponse) VisitGetEventsPollResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsPoll400Response struct {
+}
+
+func (response GetEventsPoll400Response) VisitGetEventsPollResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetEventsPoll401Response struct {
+}
+
+func (response GetEventsPoll401Response) VisitGetEventsPollResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetGroupsRequestObject struct {
+}
+
+type GetGroupsResponseObject interface {
+	VisitGetGroupsResponse(w http.ResponseWriter) error
+}
+
+type GetGroups200JSONResponse GroupsResponse
+
+func (response GetGroups200JSONResponse) VisitGetGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroups401Response struct {
+}
+
+func (response GetGroups401Response) VisitGetGroupsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostGroupRequestObject struct {
+	Body *PostGroupJSONRequestBody
+}
+
+type PostGroupResponseObject interface {
+	VisitPostGroupResponse(w http.ResponseWriter) error
+}
+
+type PostGroup200JSONResponse Group
+
+func (response PostGroup200JSONResponse) VisitPostGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroup400Response struct {
+}
+
+func (response PostGroup400Response) VisitPostGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostGroup401Response struct {
+}
+
+func (response PostGroup401Response) VisitPostGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostGroup409Response struct {
+}
+
+func (response PostGroup409Response) VisitPostGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type DeleteGroupRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DeleteGroupResponseObject interface {
+	VisitDeleteGroupResponse(w http.ResponseWriter) error
+}
+
+type DeleteGroup200JSONResponse NotifyResponse
+
+func (response DeleteGroup200JSONResponse) VisitDeleteGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroup401Response struct {
+}
+
+func (response DeleteGroup401Response) VisitDeleteGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteGroup403Response struct {
+}
+
+func (response DeleteGroup403Response) VisitDeleteGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteGroup404Response struct {
+}
+
+func (response DeleteGroup404Response) VisitDeleteGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetGroupRequestObject struct {
+	Name string `json:"name"`
+}
+
+type GetGroupResponseObject interface {
+	VisitGetGroupResponse(w http.ResponseWriter) error
+}
+
+type GetGroup200JSONResponse Group
+
+func (response GetGroup200JSONResponse) VisitGetGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroup401Response struct {
+}
+
+func (response GetGroup401Response) VisitGetGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetGroup404Response struct {
+}
+
+func (response GetGroup404Response) VisitGetGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PutGroupRequestObject struct {
+	Name string `json:"name"`
+	Body *PutGroupJSONRequestBody
+}
+
+type PutGroupResponseObject interface {
+	VisitPutGroupResponse(w http.ResponseWriter) error
+}
+
+type PutGroup200JSONResponse Group
+
+func (response PutGroup200JSONResponse) VisitPutGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroup401Response struct {
+}
+
+func (response PutGroup401Response) VisitPutGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PutGroup403Response struct {
+}
+
+func (response PutGroup403Response) VisitPutGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PutGroup404Response struct {
+}
+
+func (response PutGroup404Response) VisitPutGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostGroupMembersRequestObject struct {
+	Name string `json:"name"`
+	Body *PostGroupMembersJSONRequestBody
+}
+
+type PostGroupMembersResponseObject interface {
+	VisitPostGroupMembersResponse(w http.ResponseWriter) error
+}
+
+type PostGroupMembers200JSONResponse Group
+
+func (response PostGroupMembers200JSONResponse) VisitPostGroupMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupMembers400Response struct {
+}
+
+func (response PostGroupMembers400Response) VisitPostGroupMembersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostGroupMembers401Response struct {
+}
+
+func (response PostGroupMembers401Response) VisitPostGroupMembersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostGroupMembers403Response struct {
+}
+
+func (response PostGroupMembers403Response) VisitPostGroupMembersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostGroupMembers404Response struct {
+}
+
+func (response PostGroupMembers404Response) VisitPostGroupMembersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DeleteGroupMemberRequestObject struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+type DeleteGroupMemberResponseObject interface {
+	VisitDeleteGroupMemberResponse(w http.ResponseWriter) error
+}
+
+type DeleteGroupMember200JSONResponse Group
+
+func (response DeleteGroupMember200JSONResponse) VisitDeleteGroupMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupMember401Response struct {
+}
+
+func (response DeleteGroupMember401Response) VisitDeleteGroupMemberResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteGroupMember403Response struct {
+}
+
+func (response DeleteGroupMember403Response) VisitDeleteGroupMemberResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteGroupMember404Response struct {
+}
+
+func (response DeleteGroupMember404Response) VisitDeleteGroupMemberResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostLoginRequestObject struct {
+	Body *PostLoginJSONRequestBody
+}
+
+type PostLoginResponseObject interface {
+	VisitPostLoginResponse(w http.ResponseWriter) error
+}
+
+type PostLogin200ResponseHeaders struct {
+	SetCookie string
+}
+
+type PostLogin200JSONResponse struct {
+	Body    LoginResponse
+	Headers PostLogin200ResponseHeaders
+}
+
+func (response PostLogin200JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Set-Cookie", fmt.Sprint(response.Headers.SetCookie))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostLogin400Response struct {
+}
+
+func (response PostLogin400Response) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostLogin401Response struct {
+}
+
+func (response PostLogin401Response) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostLogin429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostLogin429Response struct {
+	Headers PostLogin429ResponseHeaders
+}
+
+func (response PostLogin429Response) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+	return nil
+}
+
+type GetLoginMethodsRequestObject struct {
+}
+
+type GetLoginMethodsResponseObject interface {
+	VisitGetLoginMethodsResponse(w http.ResponseWriter) error
+}
+
+type GetLoginMethods200JSONResponse LoginMethodsResponse
+
+func (response GetLoginMethods200JSONResponse) VisitGetLoginMethodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogoutRequestObject struct {
+}
+
+type PostLogoutResponseObject interface {
+	VisitPostLogoutResponse(w http.ResponseWriter) error
+}
+
+type PostLogout200JSONResponse LogoutResponse
+
+func (response PostLogout200JSONResponse) VisitPostLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogout401Response struct {
+}
+
+func (response PostLogout401Response) VisitPostLogoutResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetNotificationsRequestObject struct {
+	Params GetNotificationsParams
+}
+
+type GetNotificationsResponseObject interface {
+	VisitGetNotificationsResponse(w http.ResponseWriter) error
+}
+
+type GetNotifications200JSONResponse NotificationsResponse
+
+func (response GetNotifications200JSONResponse) VisitGetNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNotifications400Response struct {
+}
+
+func (response GetNotifications400Response) VisitGetNotificationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetNotifications401Response struct {
+}
+
+func (response GetNotifications401Response) VisitGetNotificationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostNotificationsReadAllRequestObject struct {
+}
+
+type PostNotificationsReadAllResponseObject interface {
+	VisitPostNotificationsReadAllResponse(w http.ResponseWriter) error
+}
+
+type PostNotificationsReadAll200JSONResponse MarkReadResponse
+
+func (response PostNotificationsReadAll200JSONResponse) VisitPostNotificationsReadAllResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNotificationsReadAll401Response struct {
+}
+
+func (response PostNotificationsReadAll401Response) VisitPostNotificationsReadAllResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetNotificationsUnreadCountRequestObject struct {
+}
+
+type GetNotificationsUnreadCountResponseObject interface {
+	VisitGetNotificationsUnreadCountResponse(w http.ResponseWriter) error
+}
+
+type GetNotificationsUnreadCount200JSONResponse UnreadCountResponse
+
+func (response GetNotificationsUnreadCount200JSONResponse) VisitGetNotificationsUnreadCountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNotificationsUnreadCount401Response struct {
+}
+
+func (response GetNotificationsUnreadCount401Response) VisitGetNotificationsUnreadCountResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostNotificationReadRequestObject struct {
+	Id string `json:"id"`
+}
+
+type PostNotificationReadResponseObject interface {
+	VisitPostNotificationReadResponse(w http.ResponseWriter) error
+}
+
+type PostNotificationRead200JSONResponse MarkReadResponse
+
+func (response PostNotificationRead200JSONResponse) VisitPostNotificationReadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNotificationRead401Response struct {
+}
+
+func (response PostNotificationRead401Response) VisitPostNotificationReadResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostNotificationRead404Response struct {
+}
+
+func (response PostNotificationRead404Response) VisitPostNotificationReadResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostNotifyRequestObject struct {
+	Body *PostNotifyJSONRequestBody
+}
+
+type PostNotifyResponseObject interface {
+	VisitPostNotifyResponse(w http.ResponseWriter) error
+}
+
+type PostNotify200JSONResponse NotifyResponse
+
+func (response PostNotify200JSONResponse) VisitPostNotifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNotify400Response struct {
+}
+
+func (response PostNotify400Response) VisitPostNotifyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostNotify401Response struct {
+}
+
+func (response PostNotify401Response) VisitPostNotifyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostNotify403Response struct {
+}
+
+func (response PostNotify403Response) VisitPostNotifyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetOidcCallbackRequestObject struct {
+	Params GetOidcCallbackParams
+}
+
+type GetOidcCallbackResponseObject interface {
+	VisitGetOidcCallbackResponse(w http.ResponseWriter) error
+}
+
+type GetOidcCallback302ResponseHeaders struct {
+	Location  string
+	SetCookie string
+}
+
+type GetOidcCallback302Response struct {
+	Headers GetOidcCallback302ResponseHeaders
+}
+
+func (response GetOidcCallback302Response) VisitGetOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.Header().Set("Set-Cookie", fmt.Sprint(response.Headers.SetCookie))
+	w.WriteHeader(302)
+	return nil
+}
+
+type GetOidcCallback400Response struct {
+}
+
+func (response GetOidcCallback400Response) VisitGetOidcCallbackResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetOidcCallback401Response struct {
+}
+
+func (response GetOidcCallback401Response) VisitGetOidcCallbackResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetOidcCallback404Response struct {
+}
+
+func (response GetOidcCallback404Response) VisitGetOidcCallbackResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetOidcCallback409Response struct {
+}
+
+func (response GetOidcCallback409Response) VisitGetOidcCallbackResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type GetOidcLoginRequestObject struct {
+	Params GetOidcLoginParams
+}
+
+type GetOidcLoginResponseObject interface {
+	VisitGetOidcLoginResponse(w http.ResponseWriter) error
+}
+
+type GetOidcLogin302ResponseHeaders struct {
+	Location  string
+	SetCookie string
+}
+
+type GetOidcLogin302Response struct {
+	Headers GetOidcLogin302ResponseHeaders
+}
+
+func (response GetOidcLogin302Response) VisitGetOidcLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.Header().Set("Set-Cookie", fmt.Sprint(response.Headers.SetCookie))
+	w.WriteHeader(302)
+	return nil
+}
+
+type GetOidcLogin404Response struct {
+}
+
+func (response GetOidcLogin404Response) VisitGetOidcLoginResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PutPasswordRequestObject struct {
+	Body *PutPasswordJSONRequestBody
+}
+
+type PutPasswordResponseObject interface {
+	VisitPutPasswordResponse(w http.ResponseWriter) error
+}
+
+type PutPassword200JSONResponse NotifyResponse
+
+func (response PutPassword200JSONResponse) VisitPutPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutPassword400Response struct {
+}
+
+func (response PutPassword400Response) VisitPutPasswordResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PutPassword401Response struct {
+}
+
+func (response PutPassword401Response) VisitPutPasswordResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PutPassword429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PutPassword429Response struct {
+	Headers PutPassword429ResponseHeaders
+}
+
+func (response PutPassword429Response) VisitPutPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+	return nil
+}
+
+type GetRecurringRequestObject struct {
+}
+
+type GetRecurringResponseObject interface {
+	VisitGetRecurringResponse(w http.ResponseWriter) error
+}
+
+type GetRecurring200JSONResponse RecurringSchedulesResponse
+
+func (response GetRecurring200JSONResponse) VisitGetRecurringResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRecurring401Response struct {
+}
+
+func (response GetRecurring401Response) VisitGetRecurringResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostRecurringRequestObject struct {
+	Body *PostRecurringJSONRequestBody
+}
+
+type PostRecurringResponseObject interface {
+	VisitPostRecurringResponse(w http.ResponseWriter) error
+}
+
+type PostRecurring200JSONResponse RecurringSchedule
+
+func (response PostRecurring200JSONResponse) VisitPostRecurringResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRecurring400Response struct {
+}
+
+func (response PostRecurring400Response) VisitPostRecurringResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostRecurring401Response struct {
+}
+
+func (response PostRecurring401Response) VisitPostRecurringResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostRecurring403Response struct {
+}
+
+func (response PostRecurring403Response) VisitPostRecurringResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteRecurringScheduleRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteRecurringScheduleResponseObject interface {
+	VisitDeleteRecurringScheduleResponse(w http.ResponseWriter) error
+}
+
+type DeleteRecurringSchedule200JSONResponse NotifyResponse
+
+func (response DeleteRecurringSchedule200JSONResponse) VisitDeleteRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRecurringSchedule401Response struct {
+}
+
+func (response DeleteRecurringSchedule401Response) VisitDeleteRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteRecurringSchedule403Response struct {
+}
+
+func (response DeleteRecurringSchedule403Response) VisitDeleteRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteRecurringSchedule404Response struct {
+}
+
+func (response DeleteRecurringSchedule404Response) VisitDeleteRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetRecurringScheduleRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetRecurringScheduleResponseObject interface {
+	VisitGetRecurringScheduleResponse(w http.ResponseWriter) error
+}
+
+type GetRecurringSchedule200JSONResponse RecurringSchedule
+
+func (response GetRecurringSchedule200JSONResponse) VisitGetRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRecurringSchedule401Response struct {
+}
+
+func (response GetRecurringSchedule401Response) VisitGetRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetRecurringSchedule403Response struct {
+}
+
+func (response GetRecurringSchedule403Response) VisitGetRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetRecurringSchedule404Response struct {
+}
+
+func (response GetRecurringSchedule404Response) VisitGetRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PutRecurringScheduleRequestObject struct {
+	Id   string `json:"id"`
+	Body *PutRecurringScheduleJSONRequestBody
+}
+
+type PutRecurringScheduleResponseObject interface {
+	VisitPutRecurringScheduleResponse(w http.ResponseWriter) error
+}
+
+type PutRecurringSchedule200JSONResponse RecurringSchedule
+
+func (response PutRecurringSchedule200JSONResponse) VisitPutRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutRecurringSchedule400Response struct {
+}
+
+func (response PutRecurringSchedule400Response) VisitPutRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PutRecurringSchedule401Response struct {
+}
+
+func (response PutRecurringSchedule401Response) VisitPutRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PutRecurringSchedule403Response struct {
+}
+
+func (response PutRecurringSchedule403Response) VisitPutRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PutRecurringSchedule404Response struct {
+}
+
+func (response PutRecurringSchedule404Response) VisitPutRecurringScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetRecurringRunsRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetRecurringRunsResponseObject interface {
+	VisitGetRecurringRunsResponse(w http.ResponseWriter) error
+}
+
+type GetRecurringRuns200JSONResponse RecurringRunsResponse
+
+func (response GetRecurringRuns200JSONResponse) VisitGetRecurringRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRecurringRuns401Response struct {
+}
+
+func (response GetRecurringRuns401Response) VisitGetRecurringRunsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetRecurringRuns403Response struct {
+}
+
+func (response GetRecurringRuns403Response) VisitGetRecurringRunsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetRecurringRuns404Response struct {
+}
+
+func (response GetRecurringRuns404Response) VisitGetRecurringRunsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostRegisterRequestObject struct {
+	Body *PostRegisterJSONRequestBody
+}
+
+type PostRegisterResponseObject interface {
+	VisitPostRegisterResponse(w http.ResponseWriter) error
+}
+
+type PostRegister200ResponseHeaders struct {
+	SetCookie string
+}
+
+type PostRegister200JSONResponse struct {
+	Body    LoginResponse
+	Headers PostRegister200ResponseHeaders
+}
+
+func (response PostRegister200JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Set-Cookie", fmt.Sprint(response.Headers.SetCookie))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostRegister400Response struct {
+}
+
+func (response PostRegister400Response) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostRegister409Response struct {
+}
+
+func (response PostRegister409Response) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type GetScheduledRequestObject struct {
+}
+
+type GetScheduledResponseObject interface {
+	VisitGetScheduledResponse(w http.ResponseWriter) error
+}
+
+type GetScheduled200JSONResponse ScheduledSendsResponse
+
+func (response GetScheduled200JSONResponse) VisitGetScheduledResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScheduled401Response struct {
+}
+
+func (response GetScheduled401Response) VisitGetScheduledResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteScheduledRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteScheduledResponseObject interface {
+	VisitDeleteScheduledResponse(w http.ResponseWriter) error
+}
+
+type DeleteScheduled200JSONResponse NotifyResponse
+
+func (response DeleteScheduled200JSONResponse) VisitDeleteScheduledResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteScheduled401Response struct {
+}
+
+func (response DeleteScheduled401Response) VisitDeleteScheduledResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteScheduled403Response struct {
+}
+
+func (response DeleteScheduled403Response) VisitDeleteScheduledResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteScheduled404Response struct {
+}
+
+func (response DeleteScheduled404Response) VisitDeleteScheduledResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetSessionRequestObject struct {
+}
+
+type GetSessionResponseObject interface {
+	VisitGetSessionResponse(w http.ResponseWriter) error
+}
+
+type GetSession200JSONResponse SessionResponse
+
+func (response GetSession200JSONResponse) VisitGetSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSession401Response struct {
+}
+
+func (response GetSession401Response) VisitGetSessionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetTokensRequestObject struct {
+}
+
+type GetTokensResponseObject interface {
+	VisitGetTokensResponse(w http.ResponseWriter) error
+}
+
+type GetTokens200JSONResponse TokensResponse
+
+func (response GetTokens200JSONResponse) VisitGetTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTokens401Response struct {
+}
+
+func (response GetTokens401Response) VisitGetTokensResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostTokenRequestObject struct {
+	Body *PostTokenJSONRequestBody
+}
+
+type PostTokenResponseObject interface {
+	VisitPostTokenResponse(w http.ResponseWriter) error
+}
+
+type PostToken200JSONResponse CreateTokenResponse
+
+func (response PostToken200JSONResponse) VisitPostTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostToken400Response struct {
+}
+
+func (response PostToken400Response) VisitPostTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostToken401Response struct {
+}
+
+func (response PostToken401Response) VisitPostTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostToken403Response struct {
+}
+
+func (response PostToken403Response) VisitPostTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteTokenRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteTokenResponseObject interface {
+	VisitDeleteTokenResponse(w http.ResponseWriter) error
+}
+
+type DeleteToken200JSONResponse NotifyResponse
+
+func (response DeleteToken200JSONResponse) VisitDeleteTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteToken401Response struct {
+}
+
+func (response DeleteToken401Response) VisitDeleteTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteToken403Response struct {
+}
+
+func (response DeleteToken403Response) VisitDeleteTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteToken404Response struct {
+}
+
+func (response DeleteToken404Response) VisitDeleteTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetUsersRequestObject struct {
+}
+
+type GetUsersResponseObject interface {
+	VisitGetUsersResponse(w http.ResponseWriter) error
+}
+
+type GetUsers200JSONResponse UsersResponse
+
+func (response GetUsers200JSONResponse) VisitGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsers401Response struct {
+}
+
+func (response GetUsers401Response) VisitGetUsersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetWsRequestObject struct {
+}
+
+type GetWsResponseObject interface {
+	VisitGetWsResponse(w http.ResponseWriter) error
+}
+
+type GetWs101Response struct {
+}
+
+func (response GetWs101Response) VisitGetWsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(101)
+	return nil
+}
+
+type GetWs400Response struct {
+}
+
+func (response GetWs400Response) VisitGetWsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetWs401Response struct {
+}
+
+func (response GetWs401Response) VisitGetWsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Sends an acknowledgment request to user(s) (requires authentication)
+	// (POST /acknowledge/request)
+	PostAcknowledgeRequest(ctx context.Context, request PostAcknowledgeRequestRequestObject) (PostAcknowledgeRequestResponseObject, error)
+	// Responds to an acknowledgment request (requires authentication)
+	// (POST /acknowledge/response)
+	PostAcknowledgeResponse(ctx context.Context, request PostAcknowledgeResponseRequestObject) (PostAcknowledgeResponseResponseObject, error)
+	// Gets the status of an acknowledgment request (requires authentication)
+	// (GET /acknowledge/{id})
+	GetAcknowledgment(ctx context.Context, request GetAcknowledgmentRequestObject) (GetAcknowledgmentResponseObject, error)
+	// Summarises the responses to an acknowledgment request per option (requires authentication)
+	// (GET /acknowledge/{id}/tally)
+	GetAcknowledgmentTally(ctx context.Context, request GetAcknowledgmentTallyRequestObject) (GetAcknowledgmentTallyResponseObject, error)
+	// Lists the channels and their member counts (requires authentication)
+	// (GET /channels)
+	GetChannels(ctx context.Context, request GetChannelsRequestObject) (GetChannelsResponseObject, error)
+	// Joins a channel, creating it if needed (requires authentication)
+	// (POST /channels/{name}/join)
+	PostChannelJoin(ctx context.Context, request PostChannelJoinRequestObject) (PostChannelJoinResponseObject, error)
+	// Leaves a channel (requires authentication)
+	// (POST /channels/{name}/leave)
+	PostChannelLeave(ctx context.Context, request PostChannelLeaveRequestObject) (PostChannelLeaveResponseObject, error)
+	// Lists the members of a channel (requires authentication)
+	// (GET /channels/{name}/members)
+	GetChannelMembers(ctx context.Context, request GetChannelMembersRequestObject) (GetChannelMembersResponseObject, error)
+	// Sends a notification to every member of a channel (requires authentication)
+	// (POST /channels/{name}/publish)
+	PostChannelPublish(ctx context.Context, request PostChannelPublishRequestObject) (PostChannelPublishResponseObject, error)
+	// Gets the current user's do-not-disturb setting (requires authentication)
+	// (GET /dnd)
+	GetDoNotDisturb(ctx context.Context, request GetDoNotDisturbRequestObject) (GetDoNotDisturbResponseObject, error)
+	// Turns do-not-disturb on or off for the current user. While on, only critical notifications are delivered live; the rest still reach the notification history (requires authentication)
+	// (PUT /dnd)
+	PutDoNotDisturb(ctx context.Context, request PutDoNotDisturbRequestObject) (PutDoNotDisturbResponseObject, error)
+	// Subscribes to the SSE notification stream (requires authentication)
+	// (GET /events)
+	GetEvents(ctx context.Context, request GetEventsRequestObject) (GetEventsResponseObject, error)
+	// Long-polls the event stream, for networks that buffer SSE (requires authentication)
+	// (GET /events/poll)
+	GetEventsPoll(ctx context.Context, request GetEventsPollRequestObject) (GetEventsPollResponseObject, error)
+	// Lists the recipient groups (requires authentication)
+	// (GET /groups)
+	GetGroups(ctx context.Context, request GetGroupsRequestObject) (GetGroupsResponseObject, error)
+	// Creates a recipient group owned by the current user (requires authentication)
+	// (POST /groups)
+	PostGroup(ctx context.Context, request PostGroupRequestObject) (PostGroupResponseObject, error)
+	// Deletes a group; only its creator may (requires authentication)
+	// (DELETE /groups/{name})
+	DeleteGroup(ctx context.Context, request DeleteGroupRequestObject) (DeleteGroupResponseObject, error)
+	// Gets a recipient group (requires authentication)
+	// (GET /groups/{name})
+	GetGroup(ctx context.Context, request GetGroupRequestObject) (GetGroupResponseObject, error)
+	// Replaces a group's description and/or members; only its creator may (requires authentication)
+	// (PUT /groups/{name})
+	PutGroup(ctx context.Context, request PutGroupRequestObject) (PutGroupResponseObject, error)
+	// Adds members to a group; only its creator may (requires authentication)
+	// (POST /groups/{name}/members)
+	PostGroupMembers(ctx context.Context, request PostGroupMembersRequestObject) (PostGroupMembersResponseObject, error)
+	// Removes a member from a group; only its creator may (requires authentication)
+	// (DELETE /groups/{name}/members/{username})
+	DeleteGroupMember(ctx context.Context, request DeleteGroupMemberRequestObject) (DeleteGroupMemberResponseObject, error)
+	// Logs a user in and creates a session
+	// (POST /login)
+	PostLogin(ctx context.Context, request PostLoginRequestObject) (PostLoginResponseObject, error)
+	// Lists the ways users can log in
+	// (GET /login/methods)
+	GetLoginMethods(ctx context.Context, request GetLoginMethodsRequestObject) (GetLoginMethodsResponseObject, error)
+	// Logs a user out and destroys the session
+	// (POST /logout)
+	PostLogout(ctx context.Context, request PostLogoutRequestObject) (PostLogoutResponseObject, error)
+	// Lists the user's notification history, newest first (requires authentication)
+	// (GET /notifications)
+	GetNotifications(ctx context.Context, request GetNotificationsRequestObject) (GetNotificationsResponseObject, error)
+	// Marks all notifications as read (requires authentication)
+	// (POST /notifications/read-all)
+	PostNotificationsReadAll(ctx context.Context, request PostNotificationsReadAllRequestObject) (PostNotificationsReadAllResponseObject, error)
+	// Gets the number of unread notifications (requires authentication)
+	// (GET /notifications/unread-count)
+	GetNotificationsUnreadCount(ctx context.Context, request GetNotificationsUnreadCountRequestObject) (GetNotificationsUnreadCountResponseObject, error)
+	// Marks a notification as read (requires authentication)
+	// (POST /notifications/{id}/read)
+	PostNotificationRead(ctx context.Context, request PostNotificationReadRequestObject) (PostNotificationReadResponseObject, error)
+	// Broadcasts a notification (requires authentication)
+	// (POST /notify)
+	PostNotify(ctx context.Context, request PostNotifyRequestObject) (PostNotifyResponseObject, error)
+	// Completes single sign-on; the provider redirects the browser here
+	// (GET /oidc/callback)
+	GetOidcCallback(ctx context.Context, request GetOidcCallbackRequestObject) (GetOidcCallbackResponseObject, error)
+	// Starts single sign-on by redirecting to the OIDC provider
+	// (GET /oidc/login)
+	GetOidcLogin(ctx context.Context, request GetOidcLoginRequestObject) (GetOidcLoginResponseObject, error)
+	// Changes the current user's password and signs out their other sessions (requires authentication)
+	// (PUT /password)
+	PutPassword(ctx context.Context, request PutPasswordRequestObject) (PutPasswordResponseObject, error)
+	// Lists the current user's recurring schedules, oldest first (requires authentication)
+	// (GET /recurring)
+	GetRecurring(ctx context.Context, request GetRecurringRequestObject) (GetRecurringResponseObject, error)
+	// Creates a schedule that sends a notification or acknowledgment request on every tick of a cron expression (requires authentication)
+	// (POST /recurring)
+	PostRecurring(ctx context.Context, request PostRecurringRequestObject) (PostRecurringResponseObject, error)
+	// Deletes a recurring schedule and its history; only its creator may (requires authentication)
+	// (DELETE /recurring/{id})
+	DeleteRecurringSchedule(ctx context.Context, request DeleteRecurringScheduleRequestObject) (DeleteRecurringScheduleResponseObject, error)
+	// Gets a recurring schedule (requires authentication)
+	// (GET /recurring/{id})
+	GetRecurringSchedule(ctx context.Context, request GetRecurringScheduleRequestObject) (GetRecurringScheduleResponseObject, error)
+	// Changes a recurring schedule's cron expression, time zone or paused state; only its creator may (requires authentication)
+	// (PUT /recurring/{id})
+	PutRecurringSchedule(ctx context.Context, request PutRecurringScheduleRequestObject) (PutRecurringScheduleResponseObject, error)
+	// Lists the latest executions of a recurring schedule, newest first (requires authentication)
+	// (GET /recurring/{id}/runs)
+	GetRecurringRuns(ctx context.Context, request GetRecurringRunsRequestObject) (GetRecurringRunsResponseObject, error)
+	// Registers a user with a password and logs them in
+	// (POST /register)
+	PostRegister(ctx context.Context, request PostRegisterRequestObject) (PostRegisterResponseObject, error)
+	// Lists the current user's pending scheduled sends, soonest first (requires authentication)
+	// (GET /scheduled)
+	GetScheduled(ctx context.Context, request GetScheduledRequestObject) (GetScheduledResponseObject, error)
+	// Cancels a pending scheduled send (requires authentication)
+	// (DELETE /scheduled/{id})
+	DeleteScheduled(ctx context.Context, request DeleteScheduledRequestObject) (DeleteScheduledResponseObject, error)
+	// Gets the user the current session belongs to (requires authentication)
+	// (GET /session)
+	GetSession(ctx context.Context, request GetSessionRequestObject) (GetSessionResponseObject, error)
+	// Lists the API tokens the current user created (requires authentication)
+	// (GET /tokens)
+	GetTokens(ctx context.Context, request GetTokensRequestObject) (GetTokensResponseObject, error)
+	// Creates an API token for calling the API with Authorization: Bearer (requires authentication)
+	// (POST /tokens)
+	PostToken(ctx context.Context, request PostTokenRequestObject) (PostTokenResponseObject, error)
+	// Revokes an API token; only its creator may (requires authentication)
+	// (DELETE /tokens/{id})
+	DeleteToken(ctx context.Context, request DeleteTokenRequestObject) (DeleteTokenResponseObject, error)
+	// Gets list of currently connected users (requires authentication)
+	// (GET /users)
+	GetUsers(ctx context.Context, request GetUsersRequestObject) (GetUsersResponseObject, error)
+	// Opens a WebSocket carrying the event stream and accepting actions (requires authentication)
+	// (GET /ws)
+	GetWs(ctx context.Context, request GetWsRequestObject) (GetWsResponseObject, error)
+}
+
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// PostAcknowledgeRequest operation middleware
+func (sh *strictHandler) PostAcknowledgeRequest(ctx *gin.Context) {
+	var request PostAcknowledgeRequestRequestObject
+
+	var body PostAcknowledgeRequestJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAcknowledgeRequest(ctx, request.(PostAcknowledgeRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAcknowledgeRequest")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAcknowledgeRequestResponseObject); ok {
+		if err := validResponse.VisitPostAcknowledgeRequestResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAcknowledgeResponse operation middleware
+func (sh *strictHandler) PostAcknowledgeResponse(ctx *gin.Context) {
+	var request PostAcknowledgeResponseRequestObject
+
+	var body PostAcknowledgeResponseJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAcknowledgeResponse(ctx, request.(PostAcknowledgeResponseRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAcknowledgeResponse")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAcknowledgeResponseResponseObject); ok {
+		if err := validResponse.VisitPostAcknowledgeResponseResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAcknowledgment operation middleware
+func (sh *strictHandler) GetAcknowledgment(ctx *gin.Context, id string) {
+	var request GetAcknowledgmentRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAcknowledgment(ctx, request.(GetAcknowledgmentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAcknowledgment")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAcknowledgmentResponseObject); ok {
+		if err := validResponse.VisitGetAcknowledgmentResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAcknowledgmentTally operation middleware
+func (sh *strictHandler) GetAcknowledgmentTally(ctx *gin.Context, id string) {
+	var request GetAcknowledgmentTallyRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAcknowledgmentTally(ctx, request.(GetAcknowledgmentTallyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAcknowledgmentTally")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAcknowledgmentTallyResponseObject); ok {
+		if err := validResponse.VisitGetAcknowledgmentTallyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetChannels operation middleware
+func (sh *strictHandler) GetChannels(ctx *gin.Context) {
+	var request GetChannelsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetChannels(ctx, request.(GetChannelsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetChannels")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetChannelsResponseObject); ok {
+		if err := validResponse.VisitGetChannelsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostChannelJoin operation middleware
+func (sh *strictHandler) PostChannelJoin(ctx *gin.Context, name string) {
+	var request PostChannelJoinRequestObject
+
+	request.Name = name
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostChannelJoin(ctx, request.(PostChannelJoinRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostChannelJoin")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostChannelJoinResponseObject); ok {
+		if err := validResponse.VisitPostChannelJoinResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostChannelLeave operation middleware
+func (sh *strictHandler) PostChannelLeave(ctx *gin.Context, name string) {
+	var request PostChannelLeaveRequestObject
+
+	request.Name = name
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostChannelLeave(ctx, request.(PostChannelLeaveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostChannelLeave")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostChannelLeaveResponseObject); ok {
+		if err := validResponse.VisitPostChannelLeaveResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetChannelMembers operation middleware
+func (sh *strictHandler) GetChannelMembers(ctx *gin.Context, name string) {
+	var request GetChannelMembersRequestObject
+
+	request.Name = name
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetChannelMembers(ctx, request.(GetChannelMembersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetChannelMembers")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetChannelMembersResponseObject); ok {
+		if err := validResponse.VisitGetChannelMembersResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostChannelPublish operation middleware
+func (sh *strictHandler) PostChannelPublish(ctx *gin.Context, name string) {
+	var request PostChannelPublishRequestObject
+
+	request.Name = name
+
+	var body PostChannelPublishJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostChannelPublish(ctx, request.(PostChannelPublishRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostChannelPublish")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostChannelPublishResponseObject); ok {
+		if err := validResponse.VisitPostChannelPublishResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDoNotDisturb operation middleware
+func (sh *strictHandler) GetDoNotDisturb(ctx *gin.Context) {
+	var request GetDoNotDisturbRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDoNotDisturb(ctx, request.(GetDoNotDisturbRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDoNotDisturb")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetDoNotDisturbResponseObject); ok {
+		if err := validResponse.VisitGetDoNotDisturbResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutDoNotDisturb operation middleware
+func (sh *strictHandler) PutDoNotDisturb(ctx *gin.Context) {
+	var request PutDoNotDisturbRequestObject
+
+	var body PutDoNotDisturbJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutDoNotDisturb(ctx, request.(PutDoNotDisturbRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutDoNotDisturb")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutDoNotDisturbResponseObject); ok {
+		if err := validResponse.VisitPutDoNotDisturbResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEvents operation middleware
+func (sh *strictHandler) GetEvents(ctx *gin.Context, params GetEventsParams) {
+	var request GetEventsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEvents(ctx, request.(GetEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEvents")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetEventsResponseObject); ok {
+		if err := validResponse.VisitGetEventsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEventsPoll operation middleware
+func (sh *strictHandler) GetEventsPoll(ctx *gin.Context, params GetEventsPollParams) {
+	var request GetEventsPollRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEventsPoll(ctx, request.(GetEventsPollRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEventsPoll")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetEventsPollResponseObject); ok {
+		if err := validResponse.VisitGetEventsPollResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetGroups operation middleware
+func (sh *strictHandler) GetGroups(ctx *gin.Context) {
+	var request GetGroupsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroups(ctx, request.(GetGroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroups")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupsResponseObject); ok {
+		if err := validResponse.VisitGetGroupsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostGroup operation middleware
+func (sh *strictHandler) PostGroup(ctx *gin.Context) {
+	var request PostGroupRequestObject
+
+	var body PostGroupJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostGroup(ctx, request.(PostGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostGroupResponseObject); ok {
+		if err := validResponse.VisitPostGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteGroup operation middleware
+func (sh *strictHandler) DeleteGroup(ctx *gin.Context, name string) {
+	var request DeleteGroupRequestObject
+
+	request.Name = name
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteGroup(ctx, request.(DeleteGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteGroupResponseObject); ok {
+		if err := validResponse.VisitDeleteGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetGroup operation middleware
+func (sh *strictHandler) GetGroup(ctx *gin.Context, name string) {
+	var request GetGroupRequestObject
+
+	request.Name = name
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroup(ctx, request.(GetGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupResponseObject); ok {
+		if err := validResponse.VisitGetGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutGroup operation middleware
+func (sh *strictHandler) PutGroup(ctx *gin.Context, name string) {
+	var request PutGroupRequestObject
+
+	request.Name = name
+
+	var body PutGroupJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutGroup(ctx, request.(PutGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutGroupResponseObject); ok {
+		if err := validResponse.VisitPutGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostGroupMembers operation middleware
+func (sh *strictHandler) PostGroupMembers(ctx *gin.Context, name string) {
+	var request PostGroupMembersRequestObject
+
+	request.Name = name
+
+	var body PostGroupMembersJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostGroupMembers(ctx, request.(PostGroupMembersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostGroupMembers")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostGroupMembersResponseObject); ok {
+		if err := validResponse.VisitPostGroupMembersResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteGroupMember operation middleware
+func (sh *strictHandler) DeleteGroupMember(ctx *gin.Context, name string, username string) {
+	var request DeleteGroupMemberRequestObject
+
+	request.Name = name
+	request.Username = username
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteGroupMember(ctx, request.(DeleteGroupMemberRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteGroupMember")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteGroupMemberResponseObject); ok {
+		if err := validResponse.VisitDeleteGroupMemberResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostLogin operation middleware
+func (sh *strictHandler) PostLogin(ctx *gin.Context) {
+	var request PostLoginRequestObject
+
+	var body PostLoginJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLogin(ctx, request.(PostLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLogin")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostLoginResponseObject); ok {
+		if err := validResponse.VisitPostLoginResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLoginMethods operation middleware
+func (sh *strictHandler) GetLoginMethods(ctx *gin.Context) {
+	var request GetLoginMethodsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLoginMethods(ctx, request.(GetLoginMethodsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLoginMethods")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetLoginMethodsResponseObject); ok {
+		if err := validResponse.VisitGetLoginMethodsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostLogout operation middleware
+func (sh *strictHandler) PostLogout(ctx *gin.Context) {
+	var request PostLogoutRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLogout(ctx, request.(PostLogoutRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLogout")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostLogoutResponseObject); ok {
+		if err := validResponse.VisitPostLogoutResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetNotifications operation middleware
+func (sh *strictHandler) GetNotifications(ctx *gin.Context, params GetNotificationsParams) {
+	var request GetNotificationsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNotifications(ctx, request.(GetNotificationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNotifications")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetNotificationsResponseObject); ok {
+		if err := validResponse.VisitGetNotificationsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostNotificationsReadAll operation middleware
+func (sh *strictHandler) PostNotificationsReadAll(ctx *gin.Context) {
+	var request PostNotificationsReadAllRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostNotificationsReadAll(ctx, request.(PostNotificationsReadAllRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostNotificationsReadAll")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostNotificationsReadAllResponseObject); ok {
+		if err := validResponse.VisitPostNotificationsReadAllResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetNotificationsUnreadCount operation middleware
+func (sh *strictHandler) GetNotificationsUnreadCount(ctx *gin.Context) {
+	var request GetNotificationsUnreadCountRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNotificationsUnreadCount(ctx, request.(GetNotificationsUnreadCountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNotificationsUnreadCount")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetNotificationsUnreadCountResponseObject); ok {
+		if err := validResponse.VisitGetNotificationsUnreadCountResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostNotificationRead operation middleware
+func (sh *strictHandler) PostNotificationRead(ctx *gin.Context, id string) {
+	var request PostNotificationReadRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostNotificationRead(ctx, request.(PostNotificationReadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostNotificationRead")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostNotificationReadResponseObject); ok {
+		if err := validResponse.VisitPostNotificationReadResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostNotify operation middleware
+func (sh *strictHandler) PostNotify(ctx *gin.Context) {
+	var request PostNotifyRequestObject
+
+	var body PostNotifyJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostNotify(ctx, request.(PostNotifyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostNotify")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostNotifyResponseObject); ok {
+		if err := validResponse.VisitPostNotifyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetOidcCallback operation middleware
+func (sh *strictHandler) GetOidcCallback(ctx *gin.Context, params GetOidcCallbackParams) {
+	var request GetOidcCallbackRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOidcCallback(ctx, request.(GetOidcCallbackRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOidcCallback")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetOidcCallbackResponseObject); ok {
+		if err := validResponse.VisitGetOidcCallbackResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetOidcLogin operation middleware
+func (sh *strictHandler) GetOidcLogin(ctx *gin.Context, params GetOidcLoginParams) {
+	var request GetOidcLoginRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOidcLogin(ctx, request.(GetOidcLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOidcLogin")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetOidcLoginResponseObject); ok {
+		if err := validResponse.VisitGetOidcLoginResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutPassword operation middleware
+func (sh *strictHandler) PutPassword(ctx *gin.Context) {
+	var request PutPasswordRequestObject
+
+	var body PutPasswordJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutPassword(ctx, request.(PutPasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutPassword")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutPasswordResponseObject); ok {
+		if err := validResponse.VisitPutPasswordResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostNotify200JSONResponse NotifyResponse
+// GetRecurring operation middleware
+func (sh *strictHandler) GetRecurring(ctx *gin.Context) {
+	var request GetRecurringRequestObject
 
-func (response PostNotify200JSONResponse) VisitPostNotifyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRecurring(ctx, request.(GetRecurringRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRecurring")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(ctx, request)
 
-type PostNotify400Response struct {
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetRecurringResponseObject); ok {
+		if err := validResponse.VisitGetRecurringResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response PostNotify400Response) VisitPostNotifyResponse(w http.ResponseWriter) error {
-	w.WriteHeader(400)
-	return nil
-}
+// PostRecurring operation middleware
+func (sh *strictHandler) PostRecurring(ctx *gin.Context) {
+	var request PostRecurringRequestObject
 
-type PostNotify401Response struct {
-}
+	var body PostRecurringJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
-func (response PostNotify401Response) VisitPostNotifyResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
-	return nil
-}
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRecurring(ctx, request.(PostRecurringRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRecurring")
+	}
 
-type GetUsersRequestObject struct {
-}
+	response, err := handler(ctx, request)
 
-type GetUsersResponseObject interface {
-	VisitGetUsersResponse(w http.ResponseWriter) error
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostRecurringResponseObject); ok {
+		if err := validResponse.VisitPostRecurringResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetUsers200JSONResponse UsersResponse
+// DeleteRecurringSchedule operation middleware
+func (sh *strictHandler) DeleteRecurringSchedule(ctx *gin.Context, id string) {
+	var request DeleteRecurringScheduleRequestObject
 
-func (response GetUsers200JSONResponse) VisitGetUsersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteRecurringSchedule(ctx, request.(DeleteRecurringScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteRecurringSchedule")
+	}
 
-type GetUsers401Response struct {
-}
+	response, err := handler(ctx, request)
 
-func (response GetUsers401Response) VisitGetUsersResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
-	return nil
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteRecurringScheduleResponseObject); ok {
+		if err := validResponse.VisitDeleteRecurringScheduleResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Sends an acknowledgment request to user(s) (requires authentication)
-	// (POST /acknowledge/request)
-	PostAcknowledgeRequest(ctx context.Context, request PostAcknowledgeRequestRequestObject) (PostAcknowledgeRequestResponseObject, error)
-	// Responds to an acknowledgment request (requires authentication)
-	// (POST /acknowledge/response)
-	PostAcknowledgeResponse(ctx context.Context, request PostAcknowledgeResponseRequestObject) (PostAcknowledgeResponseResponseObject, error)
-	// Subscribes to the SSE notification stream (requires authentication)
-	// (GET /events)
-	GetEvents(ctx context.Context, request GetEventsRequestObject) (GetEventsResponseObject, error)
-	// Logs a user in and creates a session
-	// (POST /login)
-	PostLogin(ctx context.Context, request PostLoginRequestObject) (PostLoginResponseObject, error)
-	// Logs a user out and destroys the session
-	// (POST /logout)
-	PostLogout(ctx context.Context, request PostLogoutRequestObject) (PostLogoutResponseObject, error)
-	// Broadcasts a notification (requires authentication)
-	// (POST /notify)
-	PostNotify(ctx context.Context, request PostNotifyRequestObject) (PostNotifyResponseObject, error)
-	// Gets list of currently connected users (requires authentication)
-	// (GET /users)
-	GetUsers(ctx context.Context, request GetUsersRequestObject) (GetUsersResponseObject, error)
-}
+// GetRecurringSchedule operation middleware
+func (sh *strictHandler) GetRecurringSchedule(ctx *gin.Context, id string) {
+	var request GetRecurringScheduleRequestObject
 
-type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+	request.Id = id
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRecurringSchedule(ctx, request.(GetRecurringScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRecurringSchedule")
+	}
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetRecurringScheduleResponseObject); ok {
+		if err := validResponse.VisitGetRecurringScheduleResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// PostAcknowledgeRequest operation middleware
-func (sh *strictHandler) PostAcknowledgeRequest(ctx *gin.Context) {
-	var request PostAcknowledgeRequestRequestObject
+// PutRecurringSchedule operation middleware
+func (sh *strictHandler) PutRecurringSchedule(ctx *gin.Context, id string) {
+	var request PutRecurringScheduleRequestObject
 
-	var body PostAcknowledgeRequestJSONRequestBody
+	request.Id = id
+
+	var body PutRecurringScheduleJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -537,10 +4746,10 @@ func (sh *strictHandler) PostAcknowledgeRequest(ctx *gin.Context) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PostAcknowledgeRequest(ctx, request.(PostAcknowledgeRequestRequestObject))
+		return sh.ssi.PutRecurringSchedule(ctx, request.(PutRecurringScheduleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostAcknowledgeRequest")
+		handler = middleware(handler, "PutRecurringSchedule")
 	}
 
 	response, err := handler(ctx, request)
@@ -548,8 +4757,8 @@ func (sh *strictHandler) PostAcknowledgeRequest(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PostAcknowledgeRequestResponseObject); ok {
-		if err := validResponse.VisitPostAcknowledgeRequestResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PutRecurringScheduleResponseObject); ok {
+		if err := validResponse.VisitPutRecurringScheduleResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -557,11 +4766,38 @@ func (sh *strictHandler) PostAcknowledgeRequest(ctx *gin.Context) {
 	}
 }
 
-// PostAcknowledgeResponse operation middleware
-func (sh *strictHandler) PostAcknowledgeResponse(ctx *gin.Context) {
-	var request PostAcknowledgeResponseRequestObject
+// GetRecurringRuns operation middleware
+func (sh *strictHandler) GetRecurringRuns(ctx *gin.Context, id string) {
+	var request GetRecurringRunsRequestObject
 
-	var body PostAcknowledgeResponseJSONRequestBody
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRecurringRuns(ctx, request.(GetRecurringRunsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRecurringRuns")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetRecurringRunsResponseObject); ok {
+		if err := validResponse.VisitGetRecurringRunsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostRegister operation middleware
+func (sh *strictHandler) PostRegister(ctx *gin.Context) {
+	var request PostRegisterRequestObject
+
+	var body PostRegisterJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -570,10 +4806,10 @@ func (sh *strictHandler) PostAcknowledgeResponse(ctx *gin.Context) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PostAcknowledgeResponse(ctx, request.(PostAcknowledgeResponseRequestObject))
+		return sh.ssi.PostRegister(ctx, request.(PostRegisterRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostAcknowledgeResponse")
+		handler = middleware(handler, "PostRegister")
 	}
 
 	response, err := handler(ctx, request)
@@ -581,8 +4817,8 @@ func (sh *strictHandler) PostAcknowledgeResponse(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PostAcknowledgeResponseResponseObject); ok {
-		if err := validResponse.VisitPostAcknowledgeResponseResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PostRegisterResponseObject); ok {
+		if err := validResponse.VisitPostRegisterResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -590,15 +4826,15 @@ func (sh *strictHandler) PostAcknowledgeResponse(ctx *gin.Context) {
 	}
 }
 
-// GetEvents operation middleware
-func (sh *strictHandler) GetEvents(ctx *gin.Context) {
-	var request GetEventsRequestObject
+// GetScheduled operation middleware
+func (sh *strictHandler) GetScheduled(ctx *gin.Context) {
+	var request GetScheduledRequestObject
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetEvents(ctx, request.(GetEventsRequestObject))
+		return sh.ssi.GetScheduled(ctx, request.(GetScheduledRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetEvents")
+		handler = middleware(handler, "GetScheduled")
 	}
 
 	response, err := handler(ctx, request)
@@ -606,8 +4842,8 @@ func (sh *strictHandler) GetEvents(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetEventsResponseObject); ok {
-		if err := validResponse.VisitGetEventsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetScheduledResponseObject); ok {
+		if err := validResponse.VisitGetScheduledResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -615,23 +4851,42 @@ func (sh *strictHandler) GetEvents(ctx *gin.Context) {
 	}
 }
 
-// PostLogin operation middleware
-func (sh *strictHandler) PostLogin(ctx *gin.Context) {
-	var request PostLoginRequestObject
+// DeleteScheduled operation middleware
+func (sh *strictHandler) DeleteScheduled(ctx *gin.Context, id string) {
+	var request DeleteScheduledRequestObject
 
-	var body PostLoginJSONRequestBody
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.Status(http.StatusBadRequest)
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteScheduled(ctx, request.(DeleteScheduledRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteScheduled")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
 		ctx.Error(err)
-		return
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteScheduledResponseObject); ok {
+		if err := validResponse.VisitDeleteScheduledResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
 	}
-	request.Body = &body
+}
+
+// GetSession operation middleware
+func (sh *strictHandler) GetSession(ctx *gin.Context) {
+	var request GetSessionRequestObject
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PostLogin(ctx, request.(PostLoginRequestObject))
+		return sh.ssi.GetSession(ctx, request.(GetSessionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostLogin")
+		handler = middleware(handler, "GetSession")
 	}
 
 	response, err := handler(ctx, request)
@@ -639,8 +4894,8 @@ func (sh *strictHandler) PostLogin(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PostLoginResponseObject); ok {
-		if err := validResponse.VisitPostLoginResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetSessionResponseObject); ok {
+		if err := validResponse.VisitGetSessionResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -648,15 +4903,15 @@ func (sh *strictHandler) PostLogin(ctx *gin.Context) {
 	}
 }
 
-// PostLogout operation middleware
-func (sh *strictHandler) PostLogout(ctx *gin.Context) {
-	var request PostLogoutRequestObject
+// GetTokens operation middleware
+func (sh *strictHandler) GetTokens(ctx *gin.Context) {
+	var request GetTokensRequestObject
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PostLogout(ctx, request.(PostLogoutRequestObject))
+		return sh.ssi.GetTokens(ctx, request.(GetTokensRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostLogout")
+		handler = middleware(handler, "GetTokens")
 	}
 
 	response, err := handler(ctx, request)
@@ -664,8 +4919,8 @@ func (sh *strictHandler) PostLogout(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PostLogoutResponseObject); ok {
-		if err := validResponse.VisitPostLogoutResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetTokensResponseObject); ok {
+		if err := validResponse.VisitGetTokensResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -673,11 +4928,11 @@ func (sh *strictHandler) PostLogout(ctx *gin.Context) {
 	}
 }
 
-// PostNotify operation middleware
-func (sh *strictHandler) PostNotify(ctx *gin.Context) {
-	var request PostNotifyRequestObject
+// PostToken operation middleware
+func (sh *strictHandler) PostToken(ctx *gin.Context) {
+	var request PostTokenRequestObject
 
-	var body PostNotifyJSONRequestBody
+	var body PostTokenJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -686,10 +4941,10 @@ func (sh *strictHandler) PostNotify(ctx *gin.Context) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PostNotify(ctx, request.(PostNotifyRequestObject))
+		return sh.ssi.PostToken(ctx, request.(PostTokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostNotify")
+		handler = middleware(handler, "PostToken")
 	}
 
 	response, err := handler(ctx, request)
@@ -697,8 +4952,35 @@ func (sh *strictHandler) PostNotify(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PostNotifyResponseObject); ok {
-		if err := validResponse.VisitPostNotifyResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PostTokenResponseObject); ok {
+		if err := validResponse.VisitPostTokenResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteToken operation middleware
+func (sh *strictHandler) DeleteToken(ctx *gin.Context, id string) {
+	var request DeleteTokenRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteToken(ctx, request.(DeleteTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteToken")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteTokenResponseObject); ok {
+		if err := validResponse.VisitDeleteTokenResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -730,3 +5012,28 @@ func (sh *strictHandler) GetUsers(ctx *gin.Context) {
 		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// GetWs operation middleware
+func (sh *strictHandler) GetWs(ctx *gin.Context) {
+	var request GetWsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWs(ctx, request.(GetWsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWs")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetWsResponseObject); ok {
+		if err := validResponse.VisitGetWsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sse-demo/service"
+	"sse-demo/types"
+	"strings"
+)
+
+// GetChannels implements StrictServerInterface
+func (h *StrictApiHandler) GetChannels(ctx context.Context, request GetChannelsRequestObject) (GetChannelsResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := h.Service.ListChannels()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channels: %w", err)
+	}
+
+	response := ChannelsResponse{
+		Channels: make([]ChannelSummary, 0, len(channels)),
+	}
+	for _, channel := range channels {
+		response.Channels = append(response.Channels, ChannelSummary{
+			Name:        channel.Name,
+			MemberCount: len(channel.Members),
+			Joined:      slices.Contains(channel.Members, username),
+		})
+	}
+
+	return GetChannels200JSONResponse(response), nil
+}
+
+// PostChannelJoin implements StrictServerInterface
+func (h *StrictApiHandler) PostChannelJoin(ctx context.Context, request PostChannelJoinRequestObject) (PostChannelJoinResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := h.Service.JoinChannel(request.Name, username)
+	if errors.Is(err, service.ErrInvalidChannel) {
+		return PostChannelJoin400Response{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to join channel: %w", err)
+	}
+
+	return PostChannelJoin200JSONResponse{
+		Success: boolPtr(true),
+		Channel: &channel,
+	}, nil
+}
+
+// PostChannelLeave implements StrictServerInterface
+func (h *StrictApiHandler) PostChannelLeave(ctx context.Context, request PostChannelLeaveRequestObject) (PostChannelLeaveResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := h.Service.LeaveChannel(request.Name, username)
+	switch {
+	case errors.Is(err, service.ErrInvalidChannel):
+		return PostChannelLeave400Response{}, nil
+	case errors.Is(err, service.ErrNotChannelMember):
+		return PostChannelLeave404Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to leave channel: %w", err)
+	}
+
+	return PostChannelLeave200JSONResponse{
+		Success: boolPtr(true),
+		Channel: &channel,
+	}, nil
+}
+
+// GetChannelMembers implements StrictServerInterface
+func (h *StrictApiHandler) GetChannelMembers(ctx context.Context, request GetChannelMembersRequestObject) (GetChannelMembersResponseObject, error) {
+	channel, members, err := h.Service.ChannelMembers(request.Name)
+	switch {
+	case errors.Is(err, service.ErrInvalidChannel):
+		return GetChannelMembers400Response{}, nil
+	case errors.Is(err, service.ErrChannelNotFound):
+		return GetChannelMembers404Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to list channel members: %w", err)
+	}
+
+	return GetChannelMembers200JSONResponse{
+		Channel: channel,
+		Members: members,
+	}, nil
+}
+
+// PostChannelPublish implements StrictServerInterface
+func (h *StrictApiHandler) PostChannelPublish(ctx context.Context, request PostChannelPublishRequestObject) (PostChannelPublishResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Body == nil || strings.TrimSpace(request.Body.Message) == "" {
+		return PostChannelPublish400Response{}, nil
+	}
+
+	req := types.NotifyRequest{
+		FromUsername: username,
+		Message:      request.Body.Message,
+	}
+	if request.Body.ReadReceipt != nil {
+		req.ReadReceipt = *request.Body.ReadReceipt
+	}
+	if request.Body.Priority != nil {
+		req.Priority = types.Priority(*request.Body.Priority)
+		if !req.Priority.Valid() {
+			return PostChannelPublish400Response{}, nil
+		}
+	}
+
+	notification, members, err := h.Service.PublishToChannel(request.Name, req)
+	switch {
+	case errors.Is(err, service.ErrInvalidChannel):
+		return PostChannelPublish400Response{}, nil
+	case errors.Is(err, service.ErrNotChannelMember):
+		return PostChannelPublish403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to publish to channel: %w", err)
+	}
+
+	recipients := len(members)
+	return PostChannelPublish200JSONResponse{
+		Success:    boolPtr(true),
+		Id:         &notification.Id,
+		Recipients: &recipients,
+	}, nil
+}
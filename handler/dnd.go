@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sse-demo/types"
+	"time"
+)
+
+// GetDoNotDisturb implements StrictServerInterface
+func (h *StrictApiHandler) GetDoNotDisturb(ctx context.Context, request GetDoNotDisturbRequestObject) (GetDoNotDisturbResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dnd := h.Service.GetDoNotDisturb(username)
+	return GetDoNotDisturb200JSONResponse(DoNotDisturb{Enabled: dnd.Enabled, Until: dnd.Until}), nil
+}
+
+// PutDoNotDisturb implements StrictServerInterface
+func (h *StrictApiHandler) PutDoNotDisturb(ctx context.Context, request PutDoNotDisturbRequestObject) (PutDoNotDisturbResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Body == nil {
+		return PutDoNotDisturb400Response{}, nil
+	}
+	if request.Body.Enabled && request.Body.Until != nil && !request.Body.Until.After(time.Now()) {
+		return PutDoNotDisturb400Response{}, nil
+	}
+
+	dnd := types.DoNotDisturb{
+		Enabled: request.Body.Enabled,
+		Until:   request.Body.Until,
+	}
+	if err := h.Service.SetDoNotDisturb(username, dnd); err != nil {
+		return nil, fmt.Errorf("failed to set do-not-disturb: %w", err)
+	}
+
+	dnd = h.Service.GetDoNotDisturb(username)
+	return PutDoNotDisturb200JSONResponse(DoNotDisturb{Enabled: dnd.Enabled, Until: dnd.Until}), nil
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sse-demo/service"
+	"sse-demo/types"
+)
+
+// GetGroups implements StrictServerInterface
+func (h *StrictApiHandler) GetGroups(ctx context.Context, request GetGroupsRequestObject) (GetGroupsResponseObject, error) {
+	groups, err := h.Service.ListGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	response := GroupsResponse{
+		Groups: make([]Group, 0, len(groups)),
+	}
+	for _, group := range groups {
+		response.Groups = append(response.Groups, toGroup(group))
+	}
+
+	return GetGroups200JSONResponse(response), nil
+}
+
+// PostGroup implements StrictServerInterface
+func (h *StrictApiHandler) PostGroup(ctx context.Context, request PostGroupRequestObject) (PostGroupResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Body == nil {
+		return PostGroup400Response{}, nil
+	}
+
+	group := types.Group{
+		Name:      request.Body.Name,
+		CreatedBy: username,
+	}
+	if request.Body.Description != nil {
+		group.Description = *request.Body.Description
+	}
+	if request.Body.Members != nil {
+		group.Members = *request.Body.Members
+	}
+
+	group, err = h.Service.CreateGroup(group)
+	switch {
+	case errors.Is(err, service.ErrInvalidGroup):
+		return PostGroup400Response{}, nil
+	case errors.Is(err, service.ErrGroupExists):
+		return PostGroup409Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	return PostGroup200JSONResponse(toGroup(group)), nil
+}
+
+// GetGroup implements StrictServerInterface
+func (h *StrictApiHandler) GetGroup(ctx context.Context, request GetGroupRequestObject) (GetGroupResponseObject, error) {
+	group, err := h.Service.GetGroup(request.Name)
+	if errors.Is(err, service.ErrGroupNotFound) {
+		return GetGroup404Response{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	return GetGroup200JSONResponse(toGroup(group)), nil
+}
+
+// PutGroup implements StrictServerInterface
+func (h *StrictApiHandler) PutGroup(ctx context.Context, request PutGroupRequestObject) (PutGroupResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Body == nil {
+		return nil, fmt.Errorf("request body is required")
+	}
+
+	group, err := h.Service.UpdateGroup(request.Name, username, request.Body.Description, request.Body.Members)
+	switch {
+	case errors.Is(err, service.ErrGroupNotFound):
+		return PutGroup404Response{}, nil
+	case errors.Is(err, service.ErrNotGroupOwner):
+		return PutGroup403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to update group: %w", err)
+	}
+
+	return PutGroup200JSONResponse(toGroup(group)), nil
+}
+
+// DeleteGroup implements StrictServerInterface
+func (h *StrictApiHandler) DeleteGroup(ctx context.Context, request DeleteGroupRequestObject) (DeleteGroupResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.Service.DeleteGroup(request.Name, username)
+	switch {
+	case errors.Is(err, service.ErrGroupNotFound):
+		return DeleteGroup404Response{}, nil
+	case errors.Is(err, service.ErrNotGroupOwner):
+		return DeleteGroup403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	return DeleteGroup200JSONResponse{Success: boolPtr(true)}, nil
+}
+
+// PostGroupMembers implements StrictServerInterface
+func (h *StrictApiHandler) PostGroupMembers(ctx context.Context, request PostGroupMembersRequestObject) (PostGroupMembersResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Body == nil || len(request.Body.Usernames) == 0 {
+		return PostGroupMembers400Response{}, nil
+	}
+
+	group, err := h.Service.AddGroupMembers(request.Name, username, request.Body.Usernames)
+	switch {
+	case errors.Is(err, service.ErrGroupNotFound):
+		return PostGroupMembers404Response{}, nil
+	case errors.Is(err, service.ErrNotGroupOwner):
+		return PostGroupMembers403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to add group members: %w", err)
+	}
+
+	return PostGroupMembers200JSONResponse(toGroup(group)), nil
+}
+
+// DeleteGroupMember implements StrictServerInterface
+func (h *StrictApiHandler) DeleteGroupMember(ctx context.Context, request DeleteGroupMemberRequestObject) (DeleteGroupMemberResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := h.Service.RemoveGroupMember(request.Name, username, request.Username)
+	switch {
+	case errors.Is(err, service.ErrGroupNotFound), errors.Is(err, service.ErrNotGroupMember):
+		return DeleteGroupMember404Response{}, nil
+	case errors.Is(err, service.ErrNotGroupOwner):
+		return DeleteGroupMember403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to remove group member: %w", err)
+	}
+
+	return DeleteGroupMember200JSONResponse(toGroup(group)), nil
+}
+
+// toGroup converts a group to the API model
+func toGroup(group types.Group) Group {
+	g := Group{
+		Name:      group.Name,
+		Members:   group.Members,
+		CreatedBy: group.CreatedBy,
+		CreatedAt: group.CreatedAt,
+		UpdatedAt: group.UpdatedAt,
+	}
+	if group.Description != "" {
+		g.Description = &group.Description
+	}
+	return g
+}
@@ -3,12 +3,17 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"slices"
 	"sse-demo/auth"
 	"sse-demo/service"
 	"sse-demo/types"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -19,16 +24,61 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+// currentUsername returns the authenticated username that AuthMiddleware
+// stored in the request context
+func currentUsername(ctx context.Context) (string, error) {
+	ginCtx, ok := ctx.(*gin.Context)
+	if !ok {
+		return "", fmt.Errorf("context is not a gin.Context")
+	}
+
+	username, ok := auth.GetUsernameFromContext(ginCtx)
+	if !ok || username == "" {
+		return "", fmt.Errorf("no authenticated user in context")
+	}
+	return username, nil
+}
+
+// actingUser returns who a request acts as: the authenticated user, or
+// onBehalfOf if it names someone else and the user may impersonate others.
+// It returns false when the user may not act for onBehalfOf.
+func (h *StrictApiHandler) actingUser(ctx context.Context, onBehalfOf *string) (string, bool, error) {
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	if onBehalfOf == nil || *onBehalfOf == "" || *onBehalfOf == username {
+		return username, true, nil
+	}
+
+	if !h.Permissions.Has(username, auth.PermissionImpersonate) {
+		log.Printf("User %s is not allowed to act as %s", username, *onBehalfOf)
+		return "", false, nil
+	}
+	log.Printf("User %s is acting as %s", username, *onBehalfOf)
+	return *onBehalfOf, true, nil
+}
+
 // StrictApiHandler implements the generated StrictServerInterface
 type StrictApiHandler struct {
 	Service       *service.NotificationService
-	SessionStore  *auth.SessionStore
+	SessionStore  auth.SessionStore
+	Accounts      *auth.Accounts
+	Tokens        *auth.Tokens
+	SSO           SSOConfig
+	Permissions   auth.Permissions
+	Stream        StreamConfig
 }
 
-func NewStrictApiHandler(svc *service.NotificationService, sessionStore *auth.SessionStore) *StrictApiHandler {
+func NewStrictApiHandler(svc *service.NotificationService, sessionStore auth.SessionStore, accounts *auth.Accounts, tokens *auth.Tokens, permissions auth.Permissions, stream StreamConfig, sso SSOConfig) *StrictApiHandler {
 	return &StrictApiHandler{
 		Service:      svc,
 		SessionStore: sessionStore,
+		Accounts:     accounts,
+		Tokens:       tokens,
+		SSO:          sso,
+		Permissions:  permissions,
+		Stream:       stream,
 	}
 }
 
@@ -39,20 +89,27 @@ func (h *StrictApiHandler) PostLogin(ctx context.Context, request PostLoginReque
 	}
 
 	username := request.Body.Username
-	if username == "" {
-		return nil, fmt.Errorf("username is required")
+	if username == "" || request.Body.Password == "" {
+		return PostLogin400Response{}, nil
 	}
 
-	// Create a new session (24 hour duration)
-	session, err := h.SessionStore.CreateSession(username, 24*time.Hour)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+	lockedUntil, err := h.Accounts.Authenticate(username, request.Body.Password)
+	switch {
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		log.Printf("Failed login for %s", username)
+		return PostLogin401Response{}, nil
+	case errors.Is(err, auth.ErrAccountLocked):
+		log.Printf("Refused login for locked account %s", username)
+		retryAfter := int(time.Until(lockedUntil).Seconds()) + 1
+		return PostLogin429Response{Headers: PostLogin429ResponseHeaders{RetryAfter: retryAfter}}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
 	}
 
-	log.Printf("User logged in: %s (session: %s)", username, session.ID)
-
-	// Create cookie string for Set-Cookie header
-	cookieStr := fmt.Sprintf("%s=%s; Path=/; HttpOnly; Max-Age=%d", auth.SessionCookieName, session.ID, 24*60*60)
+	cookieStr, err := h.createSession(auth.Identity{Username: username})
+	if err != nil {
+		return nil, err
+	}
 
 	return PostLogin200JSONResponse{
 		Body: LoginResponse{
@@ -65,17 +122,85 @@ func (h *StrictApiHandler) PostLogin(ctx context.Context, request PostLoginReque
 	}, nil
 }
 
+// createSession starts a session for the user and returns the Set-Cookie
+// header value that hands it to the browser
+func (h *StrictApiHandler) createSession(identity auth.Identity) (string, error) {
+	session, err := h.SessionStore.CreateSession(identity)
+	if err != nil {
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	log.Printf("User logged in: %s (session: %s)", identity.Username, session.ID)
+
+	return auth.SessionCookieHeader(session), nil
+}
+
 // PostNotify implements StrictServerInterface
 func (h *StrictApiHandler) PostNotify(ctx context.Context, request PostNotifyRequestObject) (PostNotifyResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	if request.Body == nil {
 		return nil, fmt.Errorf("request body is required")
 	}
 
+	// The sender is the session user unless they may impersonate others
+	from, ok, err := h.actingUser(ctx, request.Body.FromUsername)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return PostNotify403Response{}, nil
+	}
+
 	// Convert handler's NotifyRequest to types.NotifyRequest
 	typesReq := types.NotifyRequest{
-		FromUsername:   request.Body.FromUsername,
-		Message:        request.Body.Message,
-		TargetUsername: request.Body.TargetUsername,
+		FromUsername: from,
+		Message:      request.Body.Message,
+	}
+	if request.Body.TargetUsername != nil {
+		typesReq.TargetUsername = *request.Body.TargetUsername
+	}
+	if request.Body.TargetGroups != nil {
+		typesReq.TargetGroups = *request.Body.TargetGroups
+	}
+	if request.Body.ReadReceipt != nil {
+		typesReq.ReadReceipt = *request.Body.ReadReceipt
+	}
+	if request.Body.Priority != nil {
+		typesReq.Priority = types.Priority(*request.Body.Priority)
+		if !typesReq.Priority.Valid() {
+			return PostNotify400Response{}, nil
+		}
+	}
+
+	// Reject unknown groups and empty targets now; the groups are expanded
+	// again when the notification is sent
+	if typesReq.TargetUsername != "all" {
+		if _, err := h.Service.ResolveRecipients([]string{typesReq.TargetUsername}, typesReq.TargetGroups); err != nil {
+			log.Printf("Rejecting notification from %s: %v", username, err)
+			return PostNotify400Response{}, nil
+		}
+	}
+
+	// Hand future sends to the scheduler
+	if request.Body.SendAt != nil {
+		if !request.Body.SendAt.After(time.Now()) {
+			return PostNotify400Response{}, nil
+		}
+		send, err := h.Service.ScheduleSend(types.ScheduledSend{
+			Kind:         types.ScheduledKindNotification,
+			SendAt:       *request.Body.SendAt,
+			CreatedBy:    username,
+			Notification: &typesReq,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule notification: %w", err)
+		}
+		return PostNotify200JSONResponse{Success: boolPtr(true), ScheduledId: &send.ID}, nil
 	}
 
 	// Pass the request to the service to broadcast
@@ -95,8 +220,10 @@ func (h *StrictApiHandler) PostLogout(ctx context.Context, request PostLogoutReq
 	// Get session ID from cookie
 	sessionID, err := ginCtx.Cookie(auth.SessionCookieName)
 	if err == nil && sessionID != "" {
-		// Delete the session
-		h.SessionStore.DeleteSession(sessionID)
+		// Delete the session; the cookie is cleared either way
+		if err := h.SessionStore.DeleteSession(sessionID); err != nil {
+			log.Printf("Failed to delete session: %v", err)
+		}
 	}
 
 	// Clear the session cookie
@@ -115,55 +242,170 @@ func (h *StrictApiHandler) GetEvents(ctx context.Context, request GetEventsReque
 		return nil, fmt.Errorf("context is not a gin.Context")
 	}
 
-	// Get username from session cookie
-	sessionID, err := ginCtx.Cookie(auth.SessionCookieName)
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
 	if err != nil {
-		ginCtx.JSON(http.StatusUnauthorized, map[string]string{"error": "Session not found"})
-		return nil, fmt.Errorf("session not found")
+		return nil, err
 	}
 
-	session, exists := h.SessionStore.GetSession(sessionID)
-	if !exists {
-		ginCtx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid or expired session"})
-		return nil, fmt.Errorf("invalid or expired session")
+	format := Named
+	if request.Params.Format != nil {
+		format = *request.Params.Format
+	}
+	if format != Named && format != Envelope {
+		return GetEvents400Response{}, nil
 	}
 
-	username := session.Username
+	// Only the requested event types are written; an empty filter passes everything
+	filter := make(map[types.EventType]bool)
+	if request.Params.Types != nil {
+		for _, t := range *request.Params.Types {
+			if t = strings.TrimSpace(t); t != "" {
+				filter[types.EventType(t)] = true
+			}
+		}
+	}
 
 	// Set headers for SSE
 	ginCtx.Header("Content-Type", "text/event-stream")
 	ginCtx.Header("Cache-Control", "no-cache")
 	ginCtx.Header("Connection", "keep-alive")
 
-	// Get the channel for this user
-	clientChan := h.Service.AddClient(username)
-	defer h.Service.RemoveClient(username)
+	// Resume from the Last-Event-ID sent by a reconnecting EventSource
+	var lastEventID *uint64
+	if request.Params.LastEventID != nil {
+		if id, err := strconv.ParseUint(*request.Params.LastEventID, 10, 64); err == nil {
+			lastEventID = &id
+		} else {
+			log.Printf("Ignoring invalid Last-Event-ID %q from %s", *request.Params.LastEventID, username)
+		}
+	}
+
+	// Get the channel for this user along with any events missed while offline
+	client, missed := h.Service.AddClient(username, lastEventID)
+	defer h.Service.RemoveClient(client)
 
 	// Get http.Flusher from ResponseWriter
 	writer := ginCtx.Writer
-	flusher, ok := writer.(http.Flusher)
-	if !ok {
+	if _, ok := writer.(http.Flusher); !ok {
 		ginCtx.JSON(http.StatusInternalServerError, map[string]string{"error": "Streaming unsupported!"})
 		return nil, fmt.Errorf("streaming unsupported")
 	}
+	stream := newStreamWriter(writer, h.Stream.WriteTimeout)
+	stream.arm()
+
+	// Tell EventSource how long to wait before reconnecting
+	if h.Stream.RetryInterval > 0 {
+		fmt.Fprintf(stream, "retry: %d\n\n", h.Stream.RetryInterval.Milliseconds())
+	}
 
 	// Send an initial "connected" event
 	welcomeMsg, _ := json.Marshal(map[string]string{"message": "Connected"})
-	fmt.Fprintf(writer, "data: %s\n\n", string(welcomeMsg))
-	flusher.Flush()
+	if format == Named {
+		fmt.Fprintf(stream, "event: connected\ndata: %s\n\n", string(welcomeMsg))
+	} else {
+		fmt.Fprintf(stream, "data: %s\n\n", string(welcomeMsg))
+	}
+
+	// Replay missed events before going live
+	for _, event := range missed {
+		if len(filter) > 0 && !filter[event.Type] {
+			continue
+		}
+		if err := writeEvent(stream, event, format); err != nil {
+			log.Printf("Error replaying to client %s: %v", username, err)
+			return nil, err
+		}
+	}
+	if err := stream.flush(); err != nil {
+		log.Printf("Error replaying to client %s: %v", username, err)
+		return nil, err
+	}
+
+	// The stream outlives the request that authenticated it, so each
+	// heartbeat also checks that its session was not logged out, revoked or
+	// left idle. The check does not renew the session.
+	sessionID := ""
+	if session, ok := auth.GetSessionFromContext(ginCtx); ok {
+		sessionID = session.ID
+	}
+
+	// Heartbeats keep proxies from closing an idle stream and surface dead
+	// peers, whose writes stall until the write timeout drops them
+	var heartbeat <-chan time.Time
+	if h.Stream.HeartbeatInterval > 0 {
+		ticker := time.NewTicker(h.Stream.HeartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	// The ID last written; live events are labelled with the queue's resume
+	// point, which never goes back behind it
+	var sentID uint64
+	if len(missed) > 0 {
+		sentID = missed[len(missed)-1].ID
+	}
 
 	// Listen for messages on the channel or client disconnect
 	for {
 		select {
-		case msg := <-clientChan:
-			// Send the message with proper SSE format
-			_, err := fmt.Fprintf(writer, "data: %s\n\n", msg)
+		case _, ok := <-client.Events.Ready():
+			if !ok {
+				// Queue was closed by the service
+				return nil, nil
+			}
+
+			// Write the queued events one at a time, so an urgent event that
+			// arrives meanwhile goes out next
+			for msg, ok := client.Events.Pop(); ok; msg, ok = client.Events.Pop() {
+				if len(filter) > 0 && !filter[msg.Type] {
+					continue
+				}
+
+				// An event that overtook earlier ones goes out without an ID,
+				// so a reconnect still replays the ones it overtook
+				if msg.ID != 0 {
+					msg.ID = 0
+					if resume := client.Events.ResumeID(); resume > sentID {
+						msg.ID, sentID = resume, resume
+					}
+				}
+
+				// Send the message with proper SSE format
+				stream.arm()
+				err := writeEvent(stream, msg, format)
+				if err == nil {
+					err = stream.flush()
+				}
+				if err != nil {
+					return nil, h.dropStream(username, err)
+				}
+			}
+
+		case <-client.Closed:
+			// The service dropped this connection; tell the client why. It
+			// can reconnect with Last-Event-ID to catch up.
+			log.Printf("Closing stream of %s: %s", username, client.CloseEvent.Payload)
+			stream.arm()
+			if err := writeEvent(stream, client.CloseEvent, format); err == nil {
+				stream.flush()
+			}
+			return nil, nil
+
+		case <-heartbeat:
+			if _, ok := h.SessionStore.LookupSession(sessionID); !ok {
+				log.Printf("Closing stream of %s: session ended", username)
+				return nil, nil
+			}
+
+			stream.arm()
+			_, err := fmt.Fprint(stream, ": ping\n\n")
+			if err == nil {
+				err = stream.flush()
+			}
 			if err != nil {
-				// Client likely disconnected
-				log.Printf("Error writing to client %s: %v", username, err)
-				return nil, err
+				return nil, h.dropStream(username, err)
 			}
-			flusher.Flush()
 
 		case <-ginCtx.Request.Context().Done():
 			// Client disconnected
@@ -173,9 +415,230 @@ func (h *StrictApiHandler) GetEvents(ctx context.Context, request GetEventsReque
 	}
 }
 
+// dropStream logs why writing to a client's stream failed and returns the
+// error that ends the handler
+func (h *StrictApiHandler) dropStream(username string, err error) error {
+	if isStalled(err) {
+		log.Printf("Write to client %s stalled for over %s, dropping connection", username, h.Stream.WriteTimeout)
+	} else {
+		// Client likely disconnected
+		log.Printf("Error writing to client %s: %v", username, err)
+	}
+	return err
+}
+
+// writeEvent writes a single event in SSE format, including its ID so the
+// browser can send it back as Last-Event-ID when reconnecting. Events without
+// an ID are not part of the user's stream and leave the last ID unchanged.
+// Named events carry the type in the event field; envelope events are unnamed
+// and carry the whole types.SSEEvent as data.
+func writeEvent(w io.Writer, event service.Event, format GetEventsParamsFormat) error {
+	if event.ID != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.ID); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if format == Envelope {
+		_, err = fmt.Fprintf(w, "data: %s\n\n", event.Data)
+	} else {
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, event.Payload)
+	}
+	return err
+}
+
+// defaultPollTimeout is how long GetEventsPoll waits when no timeout is given
+const defaultPollTimeout = 25
+
+// GetEventsPoll implements StrictServerInterface. It long-polls the same
+// per-user stream as GetEvents for clients whose network buffers SSE.
+func (h *StrictApiHandler) GetEventsPoll(ctx context.Context, request GetEventsPollRequestObject) (GetEventsPollResponseObject, error) {
+	ginCtx, ok := ctx.(*gin.Context)
+	if !ok {
+		return nil, fmt.Errorf("context is not a gin.Context")
+	}
+
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := defaultPollTimeout
+	if request.Params.Timeout != nil {
+		timeout = *request.Params.Timeout
+	}
+	if timeout < 1 || timeout > 60 {
+		return GetEventsPoll400Response{}, nil
+	}
+
+	var after *uint64
+	if request.Params.After != nil {
+		if *request.Params.After < 0 {
+			return GetEventsPoll400Response{}, nil
+		}
+		id := uint64(*request.Params.After)
+		after = &id
+	}
+
+	events, lastID := h.Service.PollEvents(ginCtx.Request.Context(), username, after, time.Duration(timeout)*time.Second)
+
+	response := PollResponse{
+		Events: make([]PolledEvent, len(events)),
+		LastId: int64(lastID),
+	}
+	for i, event := range events {
+		response.Events[i] = PolledEvent{
+			Id:        int64(event.ID),
+			Type:      string(event.Type),
+			Payload:   json.RawMessage(event.Payload),
+			Timestamp: event.Timestamp,
+		}
+		if event.Priority != "" {
+			priority := Priority(event.Priority)
+			response.Events[i].Priority = &priority
+		}
+	}
+
+	return GetEventsPoll200JSONResponse(response), nil
+}
+
+// GetNotifications implements StrictServerInterface
+func (h *StrictApiHandler) GetNotifications(ctx context.Context, request GetNotificationsRequestObject) (GetNotificationsResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := ""
+	if request.Params.Cursor != nil {
+		cursor = *request.Params.Cursor
+	}
+
+	limit := 20
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+	if limit < 1 || limit > 100 {
+		return GetNotifications400Response{}, nil
+	}
+
+	notifications, next, err := h.Service.ListNotifications(username, cursor, limit)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return GetNotifications400Response{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	response := NotificationsResponse{
+		Notifications: make([]Notification, 0, len(notifications)),
+	}
+	for _, n := range notifications {
+		response.Notifications = append(response.Notifications, toNotification(n))
+	}
+	if next != "" {
+		response.NextCursor = &next
+	}
+
+	return GetNotifications200JSONResponse(response), nil
+}
+
+// toNotification converts an inbox item to the API model
+func toNotification(item types.InboxItem) Notification {
+	n := Notification{
+		Id:          &item.Id,
+		From:        &item.From,
+		Message:     &item.Message,
+		Timestamp:   &item.Timestamp,
+		ReadReceipt: &item.ReadReceipt,
+		Read:        &item.Read,
+		ReadAt:      item.ReadAt,
+	}
+	if item.Channel != "" {
+		n.Channel = &item.Channel
+	}
+	if item.Priority != "" {
+		priority := Priority(item.Priority)
+		n.Priority = &priority
+	}
+	if item.EventType != "" {
+		eventType := string(item.EventType)
+		n.EventType = &eventType
+		n.Payload = item.Payload
+	}
+	return n
+}
+
+// GetNotificationsUnreadCount implements StrictServerInterface
+func (h *StrictApiHandler) GetNotificationsUnreadCount(ctx context.Context, request GetNotificationsUnreadCountRequestObject) (GetNotificationsUnreadCountResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := h.Service.UnreadCount(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return GetNotificationsUnreadCount200JSONResponse(UnreadCountResponse{
+		UnreadCount: unread,
+	}), nil
+}
+
+// PostNotificationRead implements StrictServerInterface
+func (h *StrictApiHandler) PostNotificationRead(ctx context.Context, request PostNotificationReadRequestObject) (PostNotificationReadResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := h.Service.MarkNotificationRead(username, request.Id)
+	if errors.Is(err, service.ErrNotificationNotFound) {
+		return PostNotificationRead404Response{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	return PostNotificationRead200JSONResponse(MarkReadResponse{
+		Success:     boolPtr(true),
+		UnreadCount: &unread,
+	}), nil
+}
+
+// PostNotificationsReadAll implements StrictServerInterface
+func (h *StrictApiHandler) PostNotificationsReadAll(ctx context.Context, request PostNotificationsReadAllRequestObject) (PostNotificationsReadAllResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	marked, err := h.Service.MarkAllNotificationsRead(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	unread := 0
+	return PostNotificationsReadAll200JSONResponse(MarkReadResponse{
+		Success:     boolPtr(true),
+		Marked:      &marked,
+		UnreadCount: &unread,
+	}), nil
+}
+
 // GetUsers implements StrictServerInterface
 func (h *StrictApiHandler) GetUsers(ctx context.Context, request GetUsersRequestObject) (GetUsersResponseObject, error) {
-	users := h.Service.GetConnectedUsers()
+	users, err := h.Service.GetConnectedUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connected users: %w", err)
+	}
 	return GetUsers200JSONResponse(UsersResponse{
 		Users: &users,
 	}), nil
@@ -183,75 +646,242 @@ func (h *StrictApiHandler) GetUsers(ctx context.Context, request GetUsersRequest
 
 // PostAcknowledgeRequest implements StrictServerInterface
 func (h *StrictApiHandler) PostAcknowledgeRequest(ctx context.Context, request PostAcknowledgeRequestRequestObject) (PostAcknowledgeRequestResponseObject, error) {
-	// Derive the Gin context to get authenticated username
-	ginCtx, ok := ctx.(*gin.Context)
-	if !ok {
-		return nil, fmt.Errorf("context is not a gin.Context")
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	sessionID, err := ginCtx.Cookie(auth.SessionCookieName)
-	if err != nil {
-		return nil, fmt.Errorf("session not found")
+	if request.Body == nil {
+		return PostAcknowledgeRequest400Response{}, nil
 	}
 
-	session, exists := h.SessionStore.GetSession(sessionID)
-	if !exists {
-		return nil, fmt.Errorf("invalid or expired session")
+	var toUsernames, toGroups []string
+	if request.Body.ToUsernames != nil {
+		toUsernames = *request.Body.ToUsernames
+	}
+	if request.Body.ToGroups != nil {
+		toGroups = *request.Body.ToGroups
+	}
+	if len(toUsernames) == 0 && len(toGroups) == 0 {
+		return PostAcknowledgeRequest400Response{}, nil
 	}
 
-	if request.Body == nil {
-		return nil, fmt.Errorf("request body is required")
+	if request.Body.Message == "" {
+		return PostAcknowledgeRequest400Response{}, nil
 	}
 
-	if len(request.Body.ToUsernames) == 0 {
-		return nil, fmt.Errorf("to_usernames is required and must not be empty")
+	if request.Body.Deadline != nil && !request.Body.Deadline.After(time.Now()) {
+		return PostAcknowledgeRequest400Response{}, nil
 	}
 
-	if request.Body.Message == "" {
-		return nil, fmt.Errorf("message is required")
+	var options []string
+	if request.Body.Options != nil {
+		for _, option := range *request.Body.Options {
+			if option == "" {
+				return PostAcknowledgeRequest400Response{}, nil
+			}
+			if slices.Contains(options, option) {
+				return PostAcknowledgeRequest400Response{}, nil
+			}
+			options = append(options, option)
+		}
+	}
+
+	input := types.AcknowledgeRequest{
+		FromUsername: username,
+		ToUsernames:  toUsernames,
+		ToGroups:     toGroups,
+		Message:      request.Body.Message,
+		Options:      options,
+		Deadline:     request.Body.Deadline,
+	}
+
+	// Hand future sends to the scheduler once the recipients check out
+	if request.Body.SendAt != nil {
+		sendAt := *request.Body.SendAt
+		if !sendAt.After(time.Now()) || (input.Deadline != nil && !input.Deadline.After(sendAt)) {
+			return PostAcknowledgeRequest400Response{}, nil
+		}
+		if _, err := h.Service.ResolveRecipients(toUsernames, toGroups); err != nil {
+			return PostAcknowledgeRequest400Response{}, nil
+		}
+		send, err := h.Service.ScheduleSend(types.ScheduledSend{
+			Kind:           types.ScheduledKindAcknowledgment,
+			SendAt:         sendAt,
+			CreatedBy:      username,
+			Acknowledgment: &input,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule acknowledgment request: %w", err)
+		}
+		return PostAcknowledgeRequest200JSONResponse(AcknowledgeRequestResponse{
+			Success:     boolPtr(true),
+			ScheduledId: &send.ID,
+		}), nil
 	}
 
 	// Create the acknowledgment request via service
-	requestID := h.Service.CreateAcknowledgmentRequest(
-		session.Username,
-		request.Body.ToUsernames,
-		request.Body.Message,
-	)
-
-	return PostAcknowledgeRequest200JSONResponse(AcknowledgeRequestResponse{
-		Success:    boolPtr(true),
-		RequestId:  &requestID,
+	requestID, err := h.Service.CreateAcknowledgmentRequest(input)
+	if errors.Is(err, service.ErrGroupNotFound) || errors.Is(err, service.ErrNoRecipients) {
+		return PostAcknowledgeRequest400Response{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create acknowledgment request: %w", err)
+	}
+
+	response := AcknowledgeRequestResponse{
+		Success:   boolPtr(true),
+		RequestId: &requestID,
+	}
+	if info, err := h.Service.GetAcknowledgmentStatus(requestID); err == nil {
+		response.ToUsernames = &info.ToUsernames
+	}
+
+	return PostAcknowledgeRequest200JSONResponse(response), nil
+}
+
+// GetAcknowledgment implements StrictServerInterface
+func (h *StrictApiHandler) GetAcknowledgment(ctx context.Context, request GetAcknowledgmentRequestObject) (GetAcknowledgmentResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := h.Service.GetAcknowledgmentStatus(request.Id)
+	if errors.Is(err, service.ErrAcknowledgmentNotFound) {
+		return GetAcknowledgment404Response{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get acknowledgment request: %w", err)
+	}
+
+	// Only the requester and the recipients may see who has responded
+	if info.FromUsername != username && !slices.Contains(info.ToUsernames, username) {
+		return GetAcknowledgment403Response{}, nil
+	}
+
+	responses := make([]AcknowledgmentResponseRecord, 0, len(info.Responses))
+	for _, resp := range info.Responses {
+		responses = append(responses, toAcknowledgmentResponseRecord(resp))
+	}
+
+	return GetAcknowledgment200JSONResponse(AcknowledgmentStatus{
+		Id:           info.ID,
+		FromUsername: info.FromUsername,
+		ToUsernames:  info.ToUsernames,
+		ToGroups:     &info.ToGroups,
+		Message:      info.Message,
+		Options:      &info.Options,
+		CreatedAt:    info.CreatedAt,
+		Deadline:     info.Deadline,
+		Status:       AcknowledgmentStatusStatus(info.Status),
+		ClosedAt:     info.ClosedAt,
+		Acknowledged: info.Acknowledged,
+		Pending:      info.Pending,
+		Responses:    responses,
 	}), nil
 }
 
-// PostAcknowledgeResponse implements StrictServerInterface
-func (h *StrictApiHandler) PostAcknowledgeResponse(ctx context.Context, request PostAcknowledgeResponseRequestObject) (PostAcknowledgeResponseResponseObject, error) {
-	// Derive the Gin context to get authenticated username
-	ginCtx, ok := ctx.(*gin.Context)
-	if !ok {
-		return nil, fmt.Errorf("context is not a gin.Context")
+// toAcknowledgmentResponseRecord converts a recorded response to the API model
+func toAcknowledgmentResponseRecord(resp types.AcknowledgmentResponse) AcknowledgmentResponseRecord {
+	record := AcknowledgmentResponseRecord{
+		FromUsername: resp.FromUsername,
+		RespondedAt:  resp.RespondedAt,
+	}
+	if resp.Option != "" {
+		record.Option = &resp.Option
+	}
+	if resp.Comment != "" {
+		record.Comment = &resp.Comment
 	}
+	return record
+}
 
-	sessionID, err := ginCtx.Cookie(auth.SessionCookieName)
+// GetAcknowledgmentTally implements StrictServerInterface
+func (h *StrictApiHandler) GetAcknowledgmentTally(ctx context.Context, request GetAcknowledgmentTallyRequestObject) (GetAcknowledgmentTallyResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("session not found")
+		return nil, err
 	}
 
-	session, exists := h.SessionStore.GetSession(sessionID)
-	if !exists {
-		return nil, fmt.Errorf("invalid or expired session")
+	info, err := h.Service.GetAcknowledgmentStatus(request.Id)
+	if errors.Is(err, service.ErrAcknowledgmentNotFound) {
+		return GetAcknowledgmentTally404Response{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get acknowledgment request: %w", err)
 	}
 
-	if request.Body == nil {
-		return nil, fmt.Errorf("request body is required")
+	// Same visibility as the request status
+	if info.FromUsername != username && !slices.Contains(info.ToUsernames, username) {
+		return GetAcknowledgmentTally403Response{}, nil
 	}
 
-	if request.Body.RequestId == "" {
-		return nil, fmt.Errorf("request_id is required")
+	tally, err := h.Service.GetAcknowledgmentTally(request.Id)
+	if errors.Is(err, service.ErrAcknowledgmentNotFound) {
+		return GetAcknowledgmentTally404Response{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to tally acknowledgment request: %w", err)
+	}
+
+	options := make([]AcknowledgmentOptionTally, 0, len(tally.Options))
+	for _, option := range tally.Options {
+		options = append(options, AcknowledgmentOptionTally{
+			Option:    option.Option,
+			Count:     option.Count,
+			Usernames: option.Usernames,
+		})
+	}
+
+	return GetAcknowledgmentTally200JSONResponse(AcknowledgmentTally{
+		RequestId: tally.RequestID,
+		Options:   options,
+		Responded: tally.Responded,
+		Pending:   tally.Pending,
+	}), nil
+}
+
+// PostAcknowledgeResponse implements StrictServerInterface
+func (h *StrictApiHandler) PostAcknowledgeResponse(ctx context.Context, request PostAcknowledgeResponseRequestObject) (PostAcknowledgeResponseResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Body == nil || request.Body.RequestId == "" {
+		return PostAcknowledgeResponse400Response{}, nil
+	}
+
+	resp := types.AcknowledgmentResponse{
+		RequestID:    request.Body.RequestId,
+		FromUsername: username,
+	}
+	if request.Body.Option != nil {
+		resp.Option = *request.Body.Option
+	}
+	if request.Body.Comment != nil {
+		resp.Comment = *request.Body.Comment
 	}
 
 	// Record the acknowledgment via service
-	h.Service.RecordAcknowledgment(request.Body.RequestId, session.Username)
+	err = h.Service.RecordAcknowledgment(resp)
+	switch {
+	case errors.Is(err, service.ErrInvalidOption):
+		return PostAcknowledgeResponse400Response{}, nil
+	case errors.Is(err, service.ErrAcknowledgmentNotFound):
+		return PostAcknowledgeResponse404Response{}, nil
+	case errors.Is(err, service.ErrNotRecipient):
+		return PostAcknowledgeResponse403Response{}, nil
+	case errors.Is(err, service.ErrAcknowledgmentClosed):
+		return PostAcknowledgeResponse409Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to record acknowledgment: %w", err)
+	}
 
 	return PostAcknowledgeResponse200JSONResponse(AcknowledgeResponseResponse{
 		Success: boolPtr(true),
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"bufio"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sse-demo/auth"
+	"sse-demo/service"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestMain silences the log for the package's tests, as the handlers log
+// every connection, and restores it afterwards
+func TestMain(m *testing.M) {
+	out := log.Writer()
+	log.SetOutput(io.Discard)
+	code := m.Run()
+	log.SetOutput(out)
+	os.Exit(code)
+}
+
+// newTestHandler serves the API with in-memory stores, authenticating
+// requests like the server does
+func newTestHandler(t *testing.T, stream StreamConfig) (*StrictApiHandler, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc, err := service.NewNotificationService(service.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(svc.Close)
+
+	accounts, err := auth.NewAccounts(auth.NewMemoryUserStore(), 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions := auth.NewMemorySessionStore(auth.SessionLifetime{Idle: time.Hour, Max: 24 * time.Hour})
+	tokens := auth.NewTokens(auth.NewMemoryTokenStore())
+
+	h := NewStrictApiHandler(svc, sessions, accounts, tokens, auth.Permissions{}, stream, SSOConfig{})
+	r := gin.New()
+	RegisterHandlersWithOptions(r, NewStrictHandler(h, nil), GinServerOptions{
+		Middlewares: []MiddlewareFunc{AuthMiddleware(sessions, tokens)},
+	})
+	return h, r
+}
+
+// newTestSession logs username in and returns the session cookie
+func newTestSession(t *testing.T, h *StrictApiHandler, username string) *http.Cookie {
+	t.Helper()
+
+	session, err := h.SessionStore.CreateSession(auth.Identity{Username: username})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &http.Cookie{Name: auth.SessionCookieName, Value: session.ID}
+}
+
+func TestGetEventsEndsWithSession(t *testing.T) {
+	h, r := newTestHandler(t, StreamConfig{HeartbeatInterval: 10 * time.Millisecond})
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		end  func(sessionID string) error
+	}{
+		{"logout", h.SessionStore.DeleteSession},
+		{"other sessions signed out", func(string) error { return h.SessionStore.DeleteUserSessions("alice", "") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cookie := newTestSession(t, h, "alice")
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/events", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.AddCookie(cookie)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			body := bufio.NewReader(resp.Body)
+			line, err := body.ReadString('\n')
+			if err != nil || !strings.HasPrefix(line, "event: connected") {
+				t.Fatalf("stream started with %q, %v", line, err)
+			}
+
+			if err := tt.end(cookie.Value); err != nil {
+				t.Fatal(err)
+			}
+
+			ended := make(chan error, 1)
+			go func() {
+				_, err := io.Copy(io.Discard, body)
+				ended <- err
+			}()
+			select {
+			case err := <-ended:
+				if err != nil {
+					t.Errorf("stream ended with %v, want a clean close", err)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("stream kept running after its session ended")
+			}
+		})
+	}
+}
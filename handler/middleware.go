@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"sse-demo/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthMiddleware enforces the cookieAuth and bearerAuth security schemes
+// from the OpenAPI spec. The generated wrappers set CookieAuthScopes on
+// operations that declare it; only those require a valid session, so
+// /login stays open. An API token is accepted only by operations that also
+// declare bearerAuth, and only if it grants every scope they list.
+func AuthMiddleware(sessionStore auth.SessionStore, tokens *auth.Tokens) MiddlewareFunc {
+	authenticate := auth.AuthMiddleware(sessionStore, tokens)
+
+	return func(c *gin.Context) {
+		if _, secured := c.Get(CookieAuthScopes); !secured {
+			return
+		}
+		authenticate(c)
+		if c.IsAborted() {
+			return
+		}
+
+		token, ok := auth.GetTokenFromContext(c)
+		if !ok {
+			return
+		}
+		scopes, accepted := c.Get(BearerAuthScopes)
+		required, _ := scopes.([]string)
+		if !accepted || slices.ContainsFunc(required, func(scope string) bool {
+			return !token.HasScope(auth.Scope(scope))
+		}) {
+			c.JSON(http.StatusForbidden, map[string]string{
+				"error": "API token not allowed for this operation",
+			})
+			c.Abort()
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sse-demo/auth"
+	"strings"
+	"testing"
+)
+
+func TestAuthMiddlewareBearerScopes(t *testing.T) {
+	h, r := newTestHandler(t, StreamConfig{})
+
+	secret := func(scopes ...auth.Scope) string {
+		_, secret, err := h.Tokens.Create("bob", "bob", "test", scopes, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return secret
+	}
+	notify := secret(auth.ScopeNotify)
+	acknowledge := secret(auth.ScopeAcknowledge)
+	cookie := newTestSession(t, h, "bob")
+
+	const notifyBody = `{"from_username":"bob","message":"hello","target_username":"all"}`
+	const acknowledgeBody = `{"from_username":"bob","message":"read this?","target_username":"all"}`
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		bearer string
+		cookie bool
+		want   int
+	}{
+		{"scope granted", http.MethodPost, "/notify", notifyBody, notify, false, http.StatusOK},
+		{"other scope only", http.MethodPost, "/notify", notifyBody, acknowledge, false, http.StatusForbidden},
+		{"scope of another route", http.MethodPost, "/acknowledge/request", acknowledgeBody, notify, false, http.StatusForbidden},
+		{"cookie-only route", http.MethodGet, "/users", "", notify, false, http.StatusForbidden},
+		{"cookie-only route with every scope", http.MethodGet, "/tokens", "", secret(auth.ScopeNotify, auth.ScopeAcknowledge), false, http.StatusForbidden},
+		{"unknown token", http.MethodGet, "/users", "", "not-a-token", false, http.StatusUnauthorized},
+		{"no credentials", http.MethodGet, "/users", "", "", false, http.StatusUnauthorized},
+		{"session on cookie-only route", http.MethodGet, "/users", "", "", true, http.StatusOK},
+		{"open route with a token", http.MethodGet, "/login/methods", "", acknowledge, false, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if tt.bearer != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			if tt.cookie {
+				req.AddCookie(cookie)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("%s %s returned %d, want %d: %s", tt.method, tt.path, w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sse-demo/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcStateCookieName binds a single sign-on callback to the browser that
+// started the login, so a login cannot be completed in someone else's browser
+const oidcStateCookieName = "oidc_state"
+
+// SSOConfig configures single sign-on through an OIDC provider
+type SSOConfig struct {
+	Provider     *auth.OIDCProvider // Nil when single sign-on is not configured
+	PostLoginURL string             // Where the browser goes once logged in
+}
+
+// GetLoginMethods implements StrictServerInterface
+func (h *StrictApiHandler) GetLoginMethods(ctx context.Context, request GetLoginMethodsRequestObject) (GetLoginMethodsResponseObject, error) {
+	return GetLoginMethods200JSONResponse{
+		Password: true,
+		Oidc:     h.SSO.Provider != nil,
+	}, nil
+}
+
+// GetOidcLogin implements StrictServerInterface
+func (h *StrictApiHandler) GetOidcLogin(ctx context.Context, request GetOidcLoginRequestObject) (GetOidcLoginResponseObject, error) {
+	if h.SSO.Provider == nil {
+		return GetOidcLogin404Response{}, nil
+	}
+
+	loginHint := ""
+	if request.Params.LoginHint != nil {
+		loginHint = *request.Params.LoginHint
+	}
+
+	state, url, err := h.SSO.Provider.AuthCodeURL(loginHint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start OIDC login: %w", err)
+	}
+
+	// SameSite=Lax still sends the cookie on the provider's top-level redirect back
+	return GetOidcLogin302Response{
+		Headers: GetOidcLogin302ResponseHeaders{
+			Location:  url,
+			SetCookie: fmt.Sprintf("%s=%s; Path=/; HttpOnly; SameSite=Lax; Max-Age=%d", oidcStateCookieName, state, 10*60),
+		},
+	}, nil
+}
+
+// oidcLoginResponse is the redirect that completes a single sign-on. Unlike
+// the generated GetOidcCallback302Response it adds the session cookie rather
+// than setting it, so the expired oidc_state cookie goes out alongside.
+type oidcLoginResponse struct {
+	location      string
+	sessionCookie string
+}
+
+// VisitGetOidcCallbackResponse implements GetOidcCallbackResponseObject
+func (response oidcLoginResponse) VisitGetOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", response.location)
+	w.Header().Add("Set-Cookie", response.sessionCookie)
+	w.WriteHeader(http.StatusFound)
+	return nil
+}
+
+// GetOidcCallback implements StrictServerInterface
+func (h *StrictApiHandler) GetOidcCallback(ctx context.Context, request GetOidcCallbackRequestObject) (GetOidcCallbackResponseObject, error) {
+	if h.SSO.Provider == nil {
+		return GetOidcCallback404Response{}, nil
+	}
+
+	// The state is spent whatever the outcome, so drop the cookie holding it
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		ginCtx.Writer.Header().Add("Set-Cookie", fmt.Sprintf("%s=; Path=/; HttpOnly; SameSite=Lax; Max-Age=0", oidcStateCookieName))
+	}
+
+	params := request.Params
+	if params.Error != nil {
+		log.Printf("OIDC provider refused the login: %s", *params.Error)
+		return GetOidcCallback401Response{}, nil
+	}
+	if params.Code == nil || params.State == nil || params.OidcState == nil || *params.State != *params.OidcState {
+		return GetOidcCallback400Response{}, nil
+	}
+
+	identity, err := h.SSO.Provider.Exchange(ctx, *params.State, *params.Code)
+	switch {
+	case errors.Is(err, auth.ErrInvalidOIDCState):
+		return GetOidcCallback400Response{}, nil
+	case errors.Is(err, auth.ErrOIDCVerification):
+		log.Printf("OIDC login failed: %v", err)
+		return GetOidcCallback401Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to complete OIDC login: %w", err)
+	}
+
+	// Single sign-on logs into the account linked to the identity, never
+	// into a password account that happens to share its username
+	identity.Username, err = h.Accounts.Link(identity)
+	switch {
+	case errors.Is(err, auth.ErrUserExists), errors.Is(err, auth.ErrUsernameReserved), errors.Is(err, auth.ErrInvalidUsername):
+		log.Printf("OIDC login of %s at %s refused: %v", identity.Subject, identity.Issuer, err)
+		return GetOidcCallback409Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to link OIDC identity: %w", err)
+	}
+
+	cookieStr, err := h.createSession(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("User %s logged in through %s as %s", identity.Username, identity.Issuer, identity.Subject)
+
+	return oidcLoginResponse{
+		location:      h.SSO.PostLoginURL,
+		sessionCookie: cookieStr,
+	}, nil
+}
+
+// GetSession implements StrictServerInterface
+func (h *StrictApiHandler) GetSession(ctx context.Context, request GetSessionRequestObject) (GetSessionResponseObject, error) {
+	ginCtx, ok := ctx.(*gin.Context)
+	if !ok {
+		return nil, fmt.Errorf("context is not a gin.Context")
+	}
+
+	// The auth middleware has already checked and renewed the session
+	session, exists := auth.GetSessionFromContext(ginCtx)
+	if !exists {
+		return GetSession401Response{}, nil
+	}
+
+	response := SessionResponse{
+		Username:  session.Username,
+		ExpiresAt: session.ExpiresAt,
+	}
+	if session.Issuer != "" {
+		response.Issuer = &session.Issuer
+	}
+	return GetSession200JSONResponse(response), nil
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sse-demo/auth"
+	"sse-demo/auth/mockidp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestSSORouter serves the API with single sign-on through a mock
+// identity provider. alice already has a password account.
+func newTestSSORouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	idp, err := mockidp.New("test-client", "test-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(idp.Close)
+
+	provider, err := auth.NewOIDCProvider(context.Background(), auth.OIDCConfig{
+		Issuer:        idp.URL,
+		ClientID:      idp.ClientID,
+		ClientSecret:  idp.ClientSecret,
+		RedirectURL:   "http://app.test/oidc/callback",
+		UsernameClaim: "preferred_username",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts, err := auth.NewAccounts(auth.NewMemoryUserStore(), 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := accounts.Register("alice", "password1"); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions := auth.NewMemorySessionStore(auth.SessionLifetime{Idle: time.Hour, Max: 24 * time.Hour})
+	h := NewStrictApiHandler(nil, sessions, accounts, nil, auth.Permissions{}, StreamConfig{}, SSOConfig{
+		Provider:     provider,
+		PostLoginURL: "/login",
+	})
+
+	r := gin.New()
+	RegisterHandlers(r, NewStrictHandler(h, nil))
+	return r
+}
+
+// startSSO starts a login for loginHint and follows it through the provider,
+// returning the oidc_state cookie and the callback URL the provider sent the
+// browser back to
+func startSSO(t *testing.T, r *gin.Engine, loginHint string) (*http.Cookie, *url.URL) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/oidc/login?login_hint="+loginHint, nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("/oidc/login answered %d, want a redirect", rec.Code)
+	}
+	var state *http.Cookie
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == oidcStateCookieName {
+			state = cookie
+		}
+	}
+	if state == nil {
+		t.Fatal("/oidc/login did not set the oidc_state cookie")
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state, callback
+}
+
+func TestOidcCallback(t *testing.T) {
+	r := newTestSSORouter(t)
+
+	tests := []struct {
+		name      string
+		loginHint string
+		// stateCookie returns the oidc_state value the browser sends back
+		stateCookie func(started *http.Cookie) string
+		wantStatus  int
+	}{
+		{"new single sign-on user", "bob", func(c *http.Cookie) string { return c.Value }, http.StatusFound},
+		{"returning single sign-on user", "bob", func(c *http.Cookie) string { return c.Value }, http.StatusFound},
+		{"state from another browser", "bob", func(*http.Cookie) string { return "someone-elses-state" }, http.StatusBadRequest},
+		{"username of a password account", "alice", func(c *http.Cookie) string { return c.Value }, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started, callback := startSSO(t, r, tt.loginHint)
+
+			req := httptest.NewRequest(http.MethodGet, "/oidc/callback?"+callback.RawQuery, nil)
+			req.AddCookie(&http.Cookie{Name: oidcStateCookieName, Value: tt.stateCookie(started)})
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("/oidc/callback answered %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			cookies := make(map[string]*http.Cookie)
+			for _, cookie := range rec.Result().Cookies() {
+				cookies[cookie.Name] = cookie
+			}
+			if state := cookies[oidcStateCookieName]; state == nil || state.MaxAge >= 0 {
+				t.Errorf("oidc_state cookie was not cleared: %v", rec.Header().Values("Set-Cookie"))
+			}
+			session := cookies[auth.SessionCookieName]
+			if (session != nil) != (tt.wantStatus == http.StatusFound) {
+				t.Errorf("session cookie set = %v on a %d", session != nil, rec.Code)
+			}
+			if session != nil && !strings.HasPrefix(rec.Header().Get("Location"), "/login") {
+				t.Errorf("redirected to %q after logging in", rec.Header().Get("Location"))
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sse-demo/service"
+	"sse-demo/types"
+	"time"
+)
+
+// GetRecurring implements StrictServerInterface
+func (h *StrictApiHandler) GetRecurring(ctx context.Context, request GetRecurringRequestObject) (GetRecurringResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := h.Service.ListRecurring(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring schedules: %w", err)
+	}
+
+	response := RecurringSchedulesResponse{
+		Recurring: make([]RecurringSchedule, 0, len(schedules)),
+	}
+	for _, schedule := range schedules {
+		response.Recurring = append(response.Recurring, h.toRecurringSchedule(schedule))
+	}
+
+	return GetRecurring200JSONResponse(response), nil
+}
+
+// PostRecurring implements StrictServerInterface
+func (h *StrictApiHandler) PostRecurring(ctx context.Context, request PostRecurringRequestObject) (PostRecurringResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body := request.Body
+	if body == nil || (body.Notification == nil) == (body.Acknowledgment == nil) {
+		return PostRecurring400Response{}, nil
+	}
+
+	schedule := types.RecurringSchedule{
+		Cron:      body.Cron,
+		CreatedBy: username,
+	}
+	if body.TimeZone != nil {
+		schedule.TimeZone = *body.TimeZone
+	}
+
+	if n := body.Notification; n != nil {
+		// Every run sends now; deadlines only make sense for acknowledgments
+		if n.SendAt != nil || body.DeadlineAfter != nil || n.Message == "" {
+			return PostRecurring400Response{}, nil
+		}
+
+		// The sender is the session user unless they may impersonate others
+		from, ok, err := h.actingUser(ctx, n.FromUsername)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return PostRecurring403Response{}, nil
+		}
+
+		req := types.NotifyRequest{
+			FromUsername: from,
+			Message:      n.Message,
+		}
+		if n.TargetUsername != nil {
+			req.TargetUsername = *n.TargetUsername
+		}
+		if n.TargetGroups != nil {
+			req.TargetGroups = *n.TargetGroups
+		}
+		if n.ReadReceipt != nil {
+			req.ReadReceipt = *n.ReadReceipt
+		}
+		if n.Priority != nil {
+			req.Priority = types.Priority(*n.Priority)
+			if !req.Priority.Valid() {
+				return PostRecurring400Response{}, nil
+			}
+		}
+		if req.TargetUsername != "all" {
+			if _, err := h.Service.ResolveRecipients([]string{req.TargetUsername}, req.TargetGroups); err != nil {
+				return PostRecurring400Response{}, nil
+			}
+		}
+
+		schedule.Kind = types.ScheduledKindNotification
+		schedule.Notification = &req
+	}
+
+	if a := body.Acknowledgment; a != nil {
+		// Each run gets its own deadline through deadline_after
+		if a.SendAt != nil || a.Deadline != nil || a.Message == "" {
+			return PostRecurring400Response{}, nil
+		}
+		if body.DeadlineAfter != nil {
+			if *body.DeadlineAfter <= 0 {
+				return PostRecurring400Response{}, nil
+			}
+			schedule.DeadlineAfter = time.Duration(*body.DeadlineAfter) * time.Second
+		}
+
+		req := types.AcknowledgeRequest{
+			FromUsername: username,
+			Message:      a.Message,
+		}
+		if a.ToUsernames != nil {
+			req.ToUsernames = *a.ToUsernames
+		}
+		if a.ToGroups != nil {
+			req.ToGroups = *a.ToGroups
+		}
+		if a.Options != nil {
+			for _, option := range *a.Options {
+				if option == "" || slices.Contains(req.Options, option) {
+					return PostRecurring400Response{}, nil
+				}
+				req.Options = append(req.Options, option)
+			}
+		}
+		if _, err := h.Service.ResolveRecipients(req.ToUsernames, req.ToGroups); err != nil {
+			return PostRecurring400Response{}, nil
+		}
+
+		schedule.Kind = types.ScheduledKindAcknowledgment
+		schedule.Acknowledgment = &req
+	}
+
+	schedule, err = h.Service.CreateRecurring(schedule)
+	if errors.Is(err, service.ErrInvalidCron) {
+		return PostRecurring400Response{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recurring schedule: %w", err)
+	}
+
+	return PostRecurring200JSONResponse(h.toRecurringSchedule(schedule)), nil
+}
+
+// GetRecurringSchedule implements StrictServerInterface
+func (h *StrictApiHandler) GetRecurringSchedule(ctx context.Context, request GetRecurringScheduleRequestObject) (GetRecurringScheduleResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := h.Service.GetRecurring(request.Id, username)
+	switch {
+	case errors.Is(err, service.ErrRecurringNotFound):
+		return GetRecurringSchedule404Response{}, nil
+	case errors.Is(err, service.ErrNotRecurringOwner):
+		return GetRecurringSchedule403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get recurring schedule: %w", err)
+	}
+
+	return GetRecurringSchedule200JSONResponse(h.toRecurringSchedule(schedule)), nil
+}
+
+// PutRecurringSchedule implements StrictServerInterface
+func (h *StrictApiHandler) PutRecurringSchedule(ctx context.Context, request PutRecurringScheduleRequestObject) (PutRecurringScheduleResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Body == nil {
+		return PutRecurringSchedule400Response{}, nil
+	}
+
+	schedule, err := h.Service.UpdateRecurring(request.Id, username, request.Body.Cron, request.Body.TimeZone, request.Body.Paused)
+	switch {
+	case errors.Is(err, service.ErrRecurringNotFound):
+		return PutRecurringSchedule404Response{}, nil
+	case errors.Is(err, service.ErrNotRecurringOwner):
+		return PutRecurringSchedule403Response{}, nil
+	case errors.Is(err, service.ErrInvalidCron):
+		return PutRecurringSchedule400Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to update recurring schedule: %w", err)
+	}
+
+	return PutRecurringSchedule200JSONResponse(h.toRecurringSchedule(schedule)), nil
+}
+
+// DeleteRecurringSchedule implements StrictServerInterface
+func (h *StrictApiHandler) DeleteRecurringSchedule(ctx context.Context, request DeleteRecurringScheduleRequestObject) (DeleteRecurringScheduleResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.Service.DeleteRecurring(request.Id, username)
+	switch {
+	case errors.Is(err, service.ErrRecurringNotFound):
+		return DeleteRecurringSchedule404Response{}, nil
+	case errors.Is(err, service.ErrNotRecurringOwner):
+		return DeleteRecurringSchedule403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to delete recurring schedule: %w", err)
+	}
+
+	return DeleteRecurringSchedule200JSONResponse{Success: boolPtr(true)}, nil
+}
+
+// GetRecurringRuns implements StrictServerInterface
+func (h *StrictApiHandler) GetRecurringRuns(ctx context.Context, request GetRecurringRunsRequestObject) (GetRecurringRunsResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := h.Service.RecurringRuns(request.Id, username)
+	switch {
+	case errors.Is(err, service.ErrRecurringNotFound):
+		return GetRecurringRuns404Response{}, nil
+	case errors.Is(err, service.ErrNotRecurringOwner):
+		return GetRecurringRuns403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get recurring runs: %w", err)
+	}
+
+	response := RecurringRunsResponse{
+		Runs: make([]RecurringRun, 0, len(runs)),
+	}
+	for _, run := range runs {
+		r := RecurringRun{At: run.At}
+		if run.RequestID != "" {
+			r.RequestId = &run.RequestID
+		}
+		if run.Error != "" {
+			r.Error = &run.Error
+		}
+		response.Runs = append(response.Runs, r)
+	}
+
+	return GetRecurringRuns200JSONResponse(response), nil
+}
+
+// toRecurringSchedule converts a recurring schedule to the API model. What it
+// sends is described the same way as a scheduled send.
+func (h *StrictApiHandler) toRecurringSchedule(schedule types.RecurringSchedule) RecurringSchedule {
+	send := toScheduledSend(types.ScheduledSend{
+		Kind:           schedule.Kind,
+		Notification:   schedule.Notification,
+		Acknowledgment: schedule.Acknowledgment,
+	})
+
+	r := RecurringSchedule{
+		Id:             schedule.ID,
+		Kind:           RecurringScheduleKind(schedule.Kind),
+		Cron:           schedule.Cron,
+		TimeZone:       schedule.TimeZone,
+		Paused:         schedule.Paused,
+		CreatedBy:      schedule.CreatedBy,
+		CreatedAt:      schedule.CreatedAt,
+		UpdatedAt:      schedule.UpdatedAt,
+		Notification:   send.Notification,
+		Acknowledgment: send.Acknowledgment,
+	}
+	if schedule.DeadlineAfter > 0 {
+		seconds := int(schedule.DeadlineAfter / time.Second)
+		r.DeadlineAfter = &seconds
+	}
+	if next, ok := h.Service.NextRecurringRun(schedule.ID); ok {
+		r.NextRun = &next
+	}
+	return r
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sse-demo/service"
+	"sse-demo/types"
+)
+
+// GetScheduled implements StrictServerInterface
+func (h *StrictApiHandler) GetScheduled(ctx context.Context, request GetScheduledRequestObject) (GetScheduledResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sends, err := h.Service.ListScheduled(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled sends: %w", err)
+	}
+
+	response := ScheduledSendsResponse{
+		Scheduled: make([]ScheduledSend, 0, len(sends)),
+	}
+	for _, send := range sends {
+		response.Scheduled = append(response.Scheduled, toScheduledSend(send))
+	}
+
+	return GetScheduled200JSONResponse(response), nil
+}
+
+// DeleteScheduled implements StrictServerInterface
+func (h *StrictApiHandler) DeleteScheduled(ctx context.Context, request DeleteScheduledRequestObject) (DeleteScheduledResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.Service.CancelScheduled(request.Id, username)
+	switch {
+	case errors.Is(err, service.ErrScheduleNotFound):
+		return DeleteScheduled404Response{}, nil
+	case errors.Is(err, service.ErrNotScheduleOwner):
+		return DeleteScheduled403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to cancel scheduled send: %w", err)
+	}
+
+	return DeleteScheduled200JSONResponse{Success: boolPtr(true)}, nil
+}
+
+// toScheduledSend converts a scheduled send to the API model, describing what
+// will be sent with the same fields as the request that scheduled it
+func toScheduledSend(send types.ScheduledSend) ScheduledSend {
+	s := ScheduledSend{
+		Id:        send.ID,
+		Kind:      ScheduledSendKind(send.Kind),
+		SendAt:    send.SendAt,
+		CreatedBy: send.CreatedBy,
+		CreatedAt: send.CreatedAt,
+	}
+
+	if n := send.Notification; n != nil {
+		s.Notification = &NotifyRequest{
+			FromUsername: &n.FromUsername,
+			Message:      n.Message,
+			ReadReceipt:  &n.ReadReceipt,
+		}
+		if n.TargetUsername != "" {
+			s.Notification.TargetUsername = &n.TargetUsername
+		}
+		if len(n.TargetGroups) > 0 {
+			s.Notification.TargetGroups = &n.TargetGroups
+		}
+		if n.Priority != "" {
+			priority := Priority(n.Priority)
+			s.Notification.Priority = &priority
+		}
+	}
+
+	if a := send.Acknowledgment; a != nil {
+		s.Acknowledgment = &AcknowledgeRequestPayload{
+			Message:  a.Message,
+			Deadline: a.Deadline,
+		}
+		if len(a.Options) > 0 {
+			s.Acknowledgment.Options = &a.Options
+		}
+		if len(a.ToUsernames) > 0 {
+			s.Acknowledgment.ToUsernames = &a.ToUsernames
+		}
+		if len(a.ToGroups) > 0 {
+			s.Acknowledgment.ToGroups = &a.ToGroups
+		}
+	}
+
+	return s
+}
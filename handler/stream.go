@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// StreamConfig tunes the long-lived /events connections
+type StreamConfig struct {
+	HeartbeatInterval time.Duration // How often a ": ping" comment is sent; 0 disables heartbeats
+	RetryInterval     time.Duration // Reconnect delay suggested to clients with "retry:"; 0 omits it
+	WriteTimeout      time.Duration // How long a write may stall before the client is dropped; 0 waits forever
+}
+
+// streamWriter writes to an event stream with a deadline on every write, so
+// a peer that stopped reading is detected and dropped instead of blocking the
+// handler (and filling the client's event channel) indefinitely
+type streamWriter struct {
+	io.Writer
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+// newStreamWriter wraps the response writer of an event stream. Writes go
+// through w; flushes and deadlines go to the writer it wraps, as gin's Flush
+// drops the error and a dead peer would only be noticed on a later write.
+func newStreamWriter(w http.ResponseWriter, timeout time.Duration) *streamWriter {
+	conn := w
+	if wrapper, ok := w.(interface{ Unwrap() http.ResponseWriter }); ok {
+		conn = wrapper.Unwrap()
+	}
+	return &streamWriter{
+		Writer:  w,
+		rc:      http.NewResponseController(conn),
+		timeout: timeout,
+	}
+}
+
+// arm sets the deadline for the writes and flush that follow
+func (s *streamWriter) arm() {
+	if s.timeout <= 0 {
+		return
+	}
+	if err := s.rc.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("Error setting write deadline: %v", err)
+	}
+}
+
+// flush sends the buffered output to the client, returning the error if the
+// connection is closed or the write deadline passed
+func (s *streamWriter) flush() error {
+	return s.rc.Flush()
+}
+
+// isStalled reports whether a stream write failed because the deadline passed
+func isStalled(err error) bool {
+	return errors.Is(err, os.ErrDeadlineExceeded)
+}
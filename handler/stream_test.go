@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStreamWriterFlushReportsClosedConnection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	flushErr := make(chan error, 1)
+	r := gin.New()
+	r.GET("/stream", func(c *gin.Context) {
+		stream := newStreamWriter(c.Writer, time.Second)
+		c.Header("Content-Type", "text/event-stream")
+
+		// Small writes sit in the buffer, so only a flush can fail
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			stream.arm()
+			fmt.Fprint(stream, ": ping\n\n")
+			if err := stream.flush(); err != nil {
+				flushErr <- err
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		flushErr <- nil
+	})
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if err := <-flushErr; err == nil {
+		t.Error("flush kept succeeding after the client closed the connection")
+	}
+}
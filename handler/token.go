@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sse-demo/auth"
+	"strings"
+	"time"
+)
+
+// GetTokens implements StrictServerInterface
+func (h *StrictApiHandler) GetTokens(ctx context.Context, request GetTokensRequestObject) (GetTokensResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := h.Tokens.List(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+
+	response := TokensResponse{
+		Tokens: make([]ApiToken, 0, len(tokens)),
+	}
+	for _, token := range tokens {
+		response.Tokens = append(response.Tokens, toApiToken(token))
+	}
+
+	return GetTokens200JSONResponse(response), nil
+}
+
+// PostToken implements StrictServerInterface
+func (h *StrictApiHandler) PostToken(ctx context.Context, request PostTokenRequestObject) (PostTokenResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body := request.Body
+	if body == nil || strings.TrimSpace(body.Name) == "" || len(body.Scopes) == 0 {
+		return PostToken400Response{}, nil
+	}
+
+	scopes := make([]auth.Scope, 0, len(body.Scopes))
+	for _, s := range body.Scopes {
+		scope := auth.Scope(s)
+		if !scope.Valid() {
+			return PostToken400Response{}, nil
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if body.ExpiresAt != nil && !body.ExpiresAt.After(time.Now()) {
+		return PostToken400Response{}, nil
+	}
+
+	// A token acts as its creator unless they may impersonate others, which
+	// is how service principals such as "ci" get their own tokens
+	principal, ok, err := h.actingUser(ctx, body.Principal)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return PostToken403Response{}, nil
+	}
+
+	token, secret, err := h.Tokens.Create(username, principal, strings.TrimSpace(body.Name), scopes, body.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	log.Printf("User %s created API token %s acting as %s", username, token.ID, principal)
+
+	return PostToken200JSONResponse{
+		Token:  toApiToken(token),
+		Secret: secret,
+	}, nil
+}
+
+// DeleteToken implements StrictServerInterface
+func (h *StrictApiHandler) DeleteToken(ctx context.Context, request DeleteTokenRequestObject) (DeleteTokenResponseObject, error) {
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.Tokens.Revoke(request.Id, username)
+	switch {
+	case errors.Is(err, auth.ErrTokenNotFound):
+		return DeleteToken404Response{}, nil
+	case errors.Is(err, auth.ErrNotTokenOwner):
+		return DeleteToken403Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to revoke API token: %w", err)
+	}
+
+	log.Printf("User %s revoked API token %s", username, request.Id)
+
+	return DeleteToken200JSONResponse{Success: boolPtr(true)}, nil
+}
+
+// toApiToken converts an API token to the API model, without its hash
+func toApiToken(token auth.APIToken) ApiToken {
+	t := ApiToken{
+		Id:        token.ID,
+		Name:      token.Name,
+		Principal: token.Principal,
+		Scopes:    make([]TokenScope, 0, len(token.Scopes)),
+		Hint:      token.Hint,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+	}
+	for _, scope := range token.Scopes {
+		t.Scopes = append(t.Scopes, TokenScope(scope))
+	}
+	return t
+}
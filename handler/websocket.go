@@ -0,0 +1,354 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sse-demo/auth"
+	"sse-demo/types"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsMaxMessageSize limits the size of frames read from WebSocket clients
+	wsMaxMessageSize = 64 * 1024
+
+	// wsSessionCheckInterval is how often an idle socket checks that its
+	// session has not been logged out or revoked
+	wsSessionCheckInterval = 30 * time.Second
+)
+
+// upgrader upgrades /ws requests. The default origin check rejects
+// cross-site pages, which would otherwise ride on the session cookie.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+var (
+	errActionInvalid   = errors.New("invalid request")
+	errActionForbidden = errors.New("forbidden")
+	errActionNotFound  = errors.New("not found")
+	errActionConflict  = errors.New("acknowledgment request is closed")
+	errSessionEnded    = errors.New("session ended")
+)
+
+// wsSession is the session a WebSocket was opened with. The socket outlives
+// the request that authenticated it, so it checks the session again before
+// each action and periodically, and ends once the session does. Only actions
+// renew the session.
+type wsSession struct {
+	store auth.SessionStore
+
+	mu sync.Mutex
+	id string
+}
+
+// valid reports whether the session is still valid without renewing it, so
+// an idle socket does not keep its session alive
+func (s *wsSession) valid() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.store.LookupSession(s.id)
+	return ok
+}
+
+// renew reports whether the session is still valid and renews it, as the
+// client is active
+func (s *wsSession) renew() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.store.GetSession(s.id)
+	if !ok {
+		return false
+	}
+	// Renewing a signed session issues a new token; keep checking that one
+	s.id = session.ID
+	return true
+}
+
+// wsAction is a frame sent by a WebSocket client
+type wsAction struct {
+	Action string          `json:"action"`        // notify, acknowledge_request or acknowledge_response
+	Ref    string          `json:"ref,omitempty"` // Echoed in the action_result event
+	Data   json.RawMessage `json:"data"`          // Body of the matching REST endpoint
+}
+
+// GetWs implements StrictServerInterface. It serves the user's event stream
+// over a WebSocket and runs the actions the client sends back through the
+// same handlers as the REST endpoints.
+func (h *StrictApiHandler) GetWs(ctx context.Context, request GetWsRequestObject) (GetWsResponseObject, error) {
+	ginCtx, ok := ctx.(*gin.Context)
+	if !ok {
+		return nil, fmt.Errorf("context is not a gin.Context")
+	}
+
+	// Get the username the auth middleware derived from the session
+	username, err := currentUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !websocket.IsWebSocketUpgrade(ginCtx.Request) {
+		return GetWs400Response{}, nil
+	}
+
+	// The auth middleware has already checked the cookie
+	sessionID, _ := ginCtx.Cookie(auth.SessionCookieName)
+	session := &wsSession{store: h.SessionStore, id: sessionID}
+
+	// The upgrader answers failed handshakes itself
+	conn, err := upgrader.Upgrade(ginCtx.Writer, ginCtx.Request, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade failed for %s: %v", username, err)
+		return nil, nil
+	}
+	defer conn.Close()
+
+	client, _ := h.Service.AddClient(username, nil)
+	defer h.Service.RemoveClient(client)
+
+	// Read actions until the connection closes; the writer below owns all
+	// writes. Gin reuses ginCtx once this handler returns, so the reader runs
+	// actions with a copy and is waited for before returning.
+	results := make(chan types.ActionResultPayload, 16)
+	readerDone := make(chan struct{})
+	writerDone := make(chan struct{})
+	var readErr error
+	go func() {
+		defer close(readerDone)
+		readErr = h.readActions(ginCtx.Copy(), conn, session, results, writerDone)
+	}()
+	defer func() {
+		close(writerDone)
+		conn.Close()
+		<-readerDone
+	}()
+
+	// Ping frames keep proxies from closing an idle connection; the reader
+	// drops the connection when pongs stop coming back
+	var heartbeat <-chan time.Time
+	if h.Stream.HeartbeatInterval > 0 {
+		ticker := time.NewTicker(h.Stream.HeartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	sessionCheck := time.NewTicker(wsSessionCheckInterval)
+	defer sessionCheck.Stop()
+
+	for {
+		select {
+		case _, ok := <-client.Events.Ready():
+			if !ok {
+				// Queue was closed by the service
+				return nil, nil
+			}
+			for event, ok := client.Events.Pop(); ok; event, ok = client.Events.Pop() {
+				if err := h.writeFrame(conn, event.Data); err != nil {
+					return nil, h.dropStream(username, err)
+				}
+			}
+
+		case result := <-results:
+			data, err := json.Marshal(types.SSEEvent{
+				Type:      types.EventTypeActionResult,
+				Payload:   result,
+				Timestamp: time.Now(),
+			})
+			if err != nil {
+				log.Printf("Error marshaling action result: %v", err)
+				continue
+			}
+			if err := h.writeFrame(conn, string(data)); err != nil {
+				return nil, h.dropStream(username, err)
+			}
+
+		case <-client.Closed:
+			// The service dropped this connection; tell the client why
+			log.Printf("Closing WebSocket of %s: %s", username, client.CloseEvent.Payload)
+			if err := h.writeFrame(conn, client.CloseEvent.Data); err == nil {
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "slow consumer"), time.Now().Add(time.Second))
+			}
+			return nil, nil
+
+		case <-heartbeat:
+			if err := conn.WriteControl(websocket.PingMessage, nil, h.writeDeadline()); err != nil {
+				return nil, h.dropStream(username, err)
+			}
+
+		case <-sessionCheck.C:
+			if !session.valid() {
+				h.closeEndedSession(conn, username)
+				return nil, nil
+			}
+
+		case <-readerDone:
+			if errors.Is(readErr, errSessionEnded) {
+				h.closeEndedSession(conn, username)
+				return nil, nil
+			}
+			log.Printf("WebSocket client %s disconnected.", username)
+			return nil, nil
+		}
+	}
+}
+
+// closeEndedSession closes a socket whose session was logged out, revoked or
+// expired
+func (h *StrictApiHandler) closeEndedSession(conn *websocket.Conn, username string) {
+	log.Printf("Closing WebSocket of %s: session ended", username)
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, errSessionEnded.Error()), time.Now().Add(time.Second))
+}
+
+// readActions reads and runs the client's actions, queueing their results
+// for the writer. It returns when the connection fails or closes, when the
+// session has ended (errSessionEnded), or once writerDone is closed.
+func (h *StrictApiHandler) readActions(ctx context.Context, conn *websocket.Conn, session *wsSession, results chan<- types.ActionResultPayload, writerDone <-chan struct{}) error {
+	conn.SetReadLimit(wsMaxMessageSize)
+	if h.Stream.HeartbeatInterval > 0 {
+		// Allow one missed pong before giving up on the peer
+		timeout := 2*h.Stream.HeartbeatInterval + h.Stream.WriteTimeout
+		conn.SetReadDeadline(time.Now().Add(timeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(timeout))
+		})
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("Error reading from WebSocket: %v", err)
+			}
+			return nil
+		}
+
+		// A logout or password change since the last action ends the socket;
+		// otherwise the action is activity and renews the session
+		if !session.renew() {
+			return errSessionEnded
+		}
+
+		// A malformed frame is answered, not fatal
+		var action wsAction
+		result := types.ActionResultPayload{Error: errActionInvalid.Error()}
+		if err := json.Unmarshal(data, &action); err == nil {
+			result = h.runAction(ctx, action)
+		}
+
+		select {
+		case results <- result:
+		case <-writerDone:
+			return nil
+		}
+	}
+}
+
+// runAction runs one client action through the REST handler of the same name
+func (h *StrictApiHandler) runAction(ctx context.Context, action wsAction) types.ActionResultPayload {
+	result := types.ActionResultPayload{
+		Ref:    action.Ref,
+		Action: action.Action,
+	}
+
+	body, err := h.dispatchAction(ctx, action)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.OK = true
+	result.Result = body
+	return result
+}
+
+// dispatchAction decodes the action's data as the request body of the
+// matching endpoint and maps the endpoint's response to a result or an error
+func (h *StrictApiHandler) dispatchAction(ctx context.Context, action wsAction) (interface{}, error) {
+	switch action.Action {
+	case "notify":
+		var body NotifyRequest
+		if err := json.Unmarshal(action.Data, &body); err != nil {
+			return nil, errActionInvalid
+		}
+		resp, err := h.PostNotify(ctx, PostNotifyRequestObject{Body: &body})
+		if err != nil {
+			return nil, err
+		}
+		switch resp := resp.(type) {
+		case PostNotify200JSONResponse:
+			return resp, nil
+		case PostNotify400Response:
+			return nil, errActionInvalid
+		case PostNotify403Response:
+			return nil, errActionForbidden
+		}
+
+	case "acknowledge_request":
+		var body AcknowledgeRequestPayload
+		if err := json.Unmarshal(action.Data, &body); err != nil {
+			return nil, errActionInvalid
+		}
+		resp, err := h.PostAcknowledgeRequest(ctx, PostAcknowledgeRequestRequestObject{Body: &body})
+		if err != nil {
+			return nil, err
+		}
+		switch resp := resp.(type) {
+		case PostAcknowledgeRequest200JSONResponse:
+			return resp, nil
+		case PostAcknowledgeRequest400Response:
+			return nil, errActionInvalid
+		}
+
+	case "acknowledge_response":
+		var body AcknowledgeResponsePayload
+		if err := json.Unmarshal(action.Data, &body); err != nil {
+			return nil, errActionInvalid
+		}
+		resp, err := h.PostAcknowledgeResponse(ctx, PostAcknowledgeResponseRequestObject{Body: &body})
+		if err != nil {
+			return nil, err
+		}
+		switch resp := resp.(type) {
+		case PostAcknowledgeResponse200JSONResponse:
+			return resp, nil
+		case PostAcknowledgeResponse400Response:
+			return nil, errActionInvalid
+		case PostAcknowledgeResponse403Response:
+			return nil, errActionForbidden
+		case PostAcknowledgeResponse404Response:
+			return nil, errActionNotFound
+		case PostAcknowledgeResponse409Response:
+			return nil, errActionConflict
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown action %q", action.Action)
+	}
+
+	return nil, fmt.Errorf("unexpected response to %s", action.Action)
+}
+
+// writeFrame sends an encoded event envelope as a text frame
+func (h *StrictApiHandler) writeFrame(conn *websocket.Conn, data string) error {
+	conn.SetWriteDeadline(h.writeDeadline())
+	return conn.WriteMessage(websocket.TextMessage, []byte(data))
+}
+
+// writeDeadline returns the deadline for a write started now, or the zero
+// time (no deadline) if no write timeout is configured
+func (h *StrictApiHandler) writeDeadline() time.Time {
+	if h.Stream.WriteTimeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(h.Stream.WriteTimeout)
+}
@@ -1,36 +1,430 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
 	"log"
+	"net"
+	"net/http"
+	"os"
 	"sse-demo/auth"
+	"sse-demo/config"
 	"sse-demo/handler"
 	"sse-demo/service"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	bolt "go.etcd.io/bbolt"
 )
 
 func main() {
-	// 1. Create the session store for authentication
-	sessionStore := auth.NewSessionStore()
+	// 1. Load configuration from the environment
+	cfg := config.Load()
 
-	// 2. Create the notification service (our in-memory "state")
-	notificationService := service.NewNotificationService()
+	// "adduser <username>" provisions an account, with the password read
+	// from stdin, instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "adduser" {
+		if err := addUser(cfg, os.Args[2:], os.Stdin); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// 2. Create the message broker that connects server instances
+	broker, err := newBroker(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer broker.Close()
+
+	// 3. Open the database backing the durable stores, if enabled
+	var db *bolt.DB
+	if cfg.Store == "bolt" || cfg.SessionStore == "bolt" {
+		db, err = bolt.Open(cfg.BoltPath, 0600, &bolt.Options{Timeout: time.Second})
+		if err != nil {
+			log.Fatalf("Failed to open %s: %v", cfg.BoltPath, err)
+		}
+		defer db.Close()
+	}
+
+	// 4. Create the session store for authentication, purging expired
+	// sessions in the background
+	sessionStore, err := newSessionStore(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	janitor := auth.NewSessionJanitor(sessionStore, cfg.SessionSweepInterval)
+	defer janitor.Close()
+
+	// 5. Create the user accounts that passwords are checked against
+	users, err := newUserStore(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	accounts, err := auth.NewAccounts(users, cfg.LoginMaxFailures, cfg.LoginLockout)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 6. Create the API tokens accepted in place of a session
+	tokenStore, err := newTokenStore(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tokens := auth.NewTokens(tokenStore)
+
+	// 7. Discover the OIDC provider for single sign-on, if configured
+	var oidcProvider *auth.OIDCProvider
+	if cfg.OIDCIssuer != "" {
+		oidcProvider, err = auth.NewOIDCProvider(context.Background(), auth.OIDCConfig{
+			Issuer:        cfg.OIDCIssuer,
+			ClientID:      cfg.OIDCClientID,
+			ClientSecret:  cfg.OIDCClientSecret,
+			RedirectURL:   cfg.OIDCRedirectURL,
+			UsernameClaim: cfg.OIDCUsernameClaim,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// 8. Create the notification inbox
+	inbox, err := newInboxStore(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 9. Create the channel membership store
+	channels, err := newChannelStore(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 10. Create the recipient group store
+	groups, err := newGroupStore(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 11. Create the store for scheduled sends
+	schedules, err := newScheduleStore(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 12. Create the store for recurring schedules and their history
+	recurring, err := newRecurringStore(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 13. Create the do-not-disturb settings store
+	dnd, err := newDoNotDisturbStore(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 14. Create the acknowledgment store, which instances on the redis
+	// broker share so a response may reach any of them
+	acknowledgments, err := newAcknowledgmentStore(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 15. Create the notification service
+	notificationService, err := service.NewNotificationService(service.Options{
+		Broker:    broker,
+		Inbox:     inbox,
+		Channels:  channels,
+		Groups:    groups,
+		Schedules: schedules,
+		Recurring: recurring,
 
-	// 3. Create the handler which implements the StrictServerInterface
-	apiHandler := handler.NewStrictApiHandler(notificationService, sessionStore)
+		Acknowledgments: acknowledgments,
 
-	// 4. Create a strict handler wrapper for type safety
+		DoNotDisturb: dnd,
+
+		BufferSize:         cfg.BufferSize,
+		SlowConsumerPolicy: service.SlowConsumerPolicy(cfg.SlowConsumerPolicy),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer notificationService.Close()
+
+	// 16. Grant the configured permissions. Their holders cannot register
+	// themselves, or anyone could claim a granted name that has no account
+	// yet; their accounts are created with adduser.
+	permissions := auth.Permissions{}
+	for _, username := range cfg.Impersonators {
+		permissions.Grant(username, auth.PermissionImpersonate)
+		accounts.Reserve(username)
+	}
+
+	// 17. Create the handler which implements the StrictServerInterface
+	apiHandler := handler.NewStrictApiHandler(notificationService, sessionStore, accounts, tokens, permissions, handler.StreamConfig{
+		HeartbeatInterval: cfg.HeartbeatInterval,
+		RetryInterval:     cfg.RetryInterval,
+		WriteTimeout:      cfg.WriteTimeout,
+	}, handler.SSOConfig{
+		Provider:     oidcProvider,
+		PostLoginURL: cfg.OIDCPostLoginURL,
+	})
+
+	// 18. Create a strict handler wrapper for type safety
 	strictHandler := handler.NewStrictHandler(apiHandler, nil)
 
-	// 5. Set up Gin
+	// 19. Set up Gin
 	r := gin.Default()
 
-	// 6. Register the generated routes
-	handler.RegisterHandlers(r, strictHandler)
+	// 20. Register the generated routes, requiring a session or API token where the spec declares cookieAuth
+	handler.RegisterHandlersWithOptions(r, strictHandler, handler.GinServerOptions{
+		Middlewares: []handler.MiddlewareFunc{handler.AuthMiddleware(sessionStore, tokens)},
+	})
+
+	// 21. Expose expvar metrics such as sse_dropped_events on the internal
+	// debug address only, since they include the command line and memory stats
+	if cfg.DebugAddr != "off" {
+		if err := serveDebug(cfg.DebugAddr); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	// 7. Start the server
-	log.Println("Starting server on :8080")
-	if err := r.Run(":8080"); err != nil {
+	// 22. Start the server
+	log.Printf("Starting server on %s", cfg.Addr)
+	if err := r.Run(cfg.Addr); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// serveDebug starts serving /debug/vars on addr in the background
+func serveDebug(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on debug address %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	log.Printf("Serving debug metrics on %s", listener.Addr())
+	go func() {
+		if err := http.Serve(listener, mux); err != nil {
+			log.Printf("Debug server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// newBroker creates the broker selected by the configuration
+func newBroker(cfg config.Config) (service.Broker, error) {
+	switch cfg.Broker {
+	case "memory":
+		return service.NewMemoryBroker(), nil
+	case "redis":
+		return service.NewRedisBroker(cfg.RedisURL, cfg.RedisPrefix)
+	default:
+		return nil, fmt.Errorf("unknown broker %q", cfg.Broker)
+	}
+}
+
+// newAcknowledgmentStore creates the acknowledgment store. Requests live in
+// Redis when instances share the redis broker and otherwise in memory, as
+// they are only kept until shortly after they close.
+func newAcknowledgmentStore(cfg config.Config) (service.AcknowledgmentStore, error) {
+	switch cfg.Broker {
+	case "memory":
+		return service.NewMemoryAcknowledgmentStore(), nil
+	case "redis":
+		return service.NewRedisAcknowledgmentStore(cfg.RedisURL, cfg.RedisPrefix)
+	default:
+		return nil, fmt.Errorf("unknown broker %q", cfg.Broker)
+	}
+}
+
+// newUserStore creates the user store selected by the configuration.
+// Accounts survive a restart only with the bolt store.
+func newUserStore(cfg config.Config, db *bolt.DB) (auth.UserStore, error) {
+	switch cfg.Store {
+	case "memory":
+		return auth.NewMemoryUserStore(), nil
+	case "bolt":
+		return auth.NewBoltUserStore(db)
+	default:
+		return nil, fmt.Errorf("unknown store %q", cfg.Store)
+	}
+}
+
+// addUser provisions the account named in args with the password on the
+// first line of in. Reserved usernames can only be created this way. It needs
+// the bolt store, and the server must not be holding the database.
+func addUser(cfg config.Config, args []string, in io.Reader) error {
+	if len(args) != 1 {
+		return errors.New("usage: adduser <username> < password")
+	}
+	if cfg.Store != "bolt" {
+		return errors.New("adduser needs SSE_STORE=bolt, as the memory store lives in the server process")
+	}
+
+	db, err := bolt.Open(cfg.BoltPath, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", cfg.BoltPath, err)
+	}
+	defer db.Close()
+
+	users, err := auth.NewBoltUserStore(db)
+	if err != nil {
+		return err
+	}
+	accounts, err := auth.NewAccounts(users, cfg.LoginMaxFailures, cfg.LoginLockout)
+	if err != nil {
+		return err
+	}
+
+	password, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	if err := accounts.Provision(args[0], strings.TrimRight(password, "\r\n")); err != nil {
+		return fmt.Errorf("failed to add %s: %w", args[0], err)
+	}
+
+	log.Printf("User added: %s", args[0])
+	return nil
+}
+
+// newSessionStore creates the session store selected by the configuration.
+// Signed tokens keep their revocations in Redis when instances share the
+// redis broker, so a logout reaches every instance, and otherwise in the
+// store selected by SSE_STORE.
+func newSessionStore(cfg config.Config, db *bolt.DB) (auth.SessionStore, error) {
+	lifetime := auth.SessionLifetime{
+		Idle: cfg.SessionIdleTimeout,
+		Max:  cfg.SessionMaxLifetime,
+	}
+	if err := lifetime.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.SessionStore {
+	case "memory":
+		return auth.NewMemorySessionStore(lifetime), nil
+	case "bolt":
+		return auth.NewBoltSessionStore(db, lifetime)
+	case "jwt":
+		keys, err := auth.ParseSigningKeys(cfg.SessionKeys)
+		if err != nil {
+			return nil, err
+		}
+		var revoked auth.RevocationList
+		switch {
+		case cfg.Broker == "redis":
+			if revoked, err = auth.NewRedisRevocationList(cfg.RedisURL, cfg.RedisPrefix); err != nil {
+				return nil, err
+			}
+		case cfg.Store == "memory":
+			revoked = auth.NewMemoryRevocationList()
+		case cfg.Store == "bolt":
+			if revoked, err = auth.NewBoltRevocationList(db); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown store %q", cfg.Store)
+		}
+		return auth.NewJWTSessionStore(keys, revoked, lifetime)
+	default:
+		return nil, fmt.Errorf("unknown session store %q", cfg.SessionStore)
+	}
+}
+
+// newTokenStore creates the API token store selected by the configuration
+func newTokenStore(cfg config.Config, db *bolt.DB) (auth.TokenStore, error) {
+	switch cfg.Store {
+	case "memory":
+		return auth.NewMemoryTokenStore(), nil
+	case "bolt":
+		return auth.NewBoltTokenStore(db)
+	default:
+		return nil, fmt.Errorf("unknown store %q", cfg.Store)
+	}
+}
+
+// newInboxStore creates the inbox store selected by the configuration
+func newInboxStore(cfg config.Config, db *bolt.DB) (service.InboxStore, error) {
+	switch cfg.Store {
+	case "memory":
+		return service.NewMemoryInboxStore(), nil
+	case "bolt":
+		return service.NewBoltInboxStore(db)
+	default:
+		return nil, fmt.Errorf("unknown store %q", cfg.Store)
+	}
+}
+
+// newChannelStore creates the channel store selected by the configuration
+func newChannelStore(cfg config.Config, db *bolt.DB) (service.ChannelStore, error) {
+	switch cfg.Store {
+	case "memory":
+		return service.NewMemoryChannelStore(), nil
+	case "bolt":
+		return service.NewBoltChannelStore(db)
+	default:
+		return nil, fmt.Errorf("unknown store %q", cfg.Store)
+	}
+}
+
+// newGroupStore creates the group store selected by the configuration
+func newGroupStore(cfg config.Config, db *bolt.DB) (service.GroupStore, error) {
+	switch cfg.Store {
+	case "memory":
+		return service.NewMemoryGroupStore(), nil
+	case "bolt":
+		return service.NewBoltGroupStore(db)
+	default:
+		return nil, fmt.Errorf("unknown store %q", cfg.Store)
+	}
+}
+
+// newScheduleStore creates the schedule store selected by the configuration.
+// Scheduled sends survive a restart only with the bolt store.
+func newScheduleStore(cfg config.Config, db *bolt.DB) (service.ScheduleStore, error) {
+	switch cfg.Store {
+	case "memory":
+		return service.NewMemoryScheduleStore(), nil
+	case "bolt":
+		return service.NewBoltScheduleStore(db)
+	default:
+		return nil, fmt.Errorf("unknown store %q", cfg.Store)
+	}
+}
+
+// newRecurringStore creates the recurring schedule store selected by the
+// configuration
+func newRecurringStore(cfg config.Config, db *bolt.DB) (service.RecurringStore, error) {
+	switch cfg.Store {
+	case "memory":
+		return service.NewMemoryRecurringStore(), nil
+	case "bolt":
+		return service.NewBoltRecurringStore(db)
+	default:
+		return nil, fmt.Errorf("unknown store %q", cfg.Store)
+	}
+}
+
+// newDoNotDisturbStore creates the do-not-disturb store selected by the
+// configuration
+func newDoNotDisturbStore(cfg config.Config, db *bolt.DB) (service.DoNotDisturbStore, error) {
+	switch cfg.Store {
+	case "memory":
+		return service.NewMemoryDoNotDisturbStore(), nil
+	case "bolt":
+		return service.NewBoltDoNotDisturbStore(db)
+	default:
+		return nil, fmt.Errorf("unknown store %q", cfg.Store)
+	}
+}
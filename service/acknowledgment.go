@@ -0,0 +1,471 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"slices"
+	"sse-demo/types"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrAcknowledgmentNotFound is returned for an unknown (or collected) request ID
+	ErrAcknowledgmentNotFound = errors.New("acknowledgment request not found")
+
+	// ErrNotRecipient is returned when a user responds to a request not addressed to them
+	ErrNotRecipient = errors.New("user is not a recipient of the acknowledgment request")
+
+	// ErrAcknowledgmentClosed is returned when responding to an expired request
+	ErrAcknowledgmentClosed = errors.New("acknowledgment request is closed")
+
+	// ErrInvalidOption is returned when a response's option is not one the request offers
+	ErrInvalidOption = errors.New("option is not offered by the acknowledgment request")
+)
+
+const (
+	// acknowledgmentRetention is how long completed and expired requests stay
+	// queryable before they are garbage collected
+	acknowledgmentRetention = time.Hour
+
+	// acknowledgmentSweepInterval is how often closed requests are collected
+	acknowledgmentSweepInterval = time.Minute
+
+	// acknowledgmentMaxPending is how long a request without a deadline stays
+	// open before it expires, so abandoned requests are eventually collected
+	acknowledgmentMaxPending = 7 * 24 * time.Hour
+)
+
+// AcknowledgmentRecord is an acknowledgment request together with its
+// responses in arrival order
+type AcknowledgmentRecord struct {
+	Request   types.AcknowledgmentRequest    `json:"request"`
+	Responses []types.AcknowledgmentResponse `json:"responses"`
+}
+
+// AcknowledgmentStore holds acknowledgment requests and their responses.
+// Every server instance must see the same store, as a recipient's response
+// may reach a different instance than the one that created the request.
+type AcknowledgmentStore interface {
+	// Create stores a new request
+	Create(record AcknowledgmentRecord) error
+
+	// Get returns a request with its responses, or ErrAcknowledgmentNotFound
+	Get(requestID string) (AcknowledgmentRecord, error)
+
+	// Update applies fn to a request and stores the result, atomically with
+	// respect to other updates of the request. fn may run more than once;
+	// if it returns an error nothing is stored.
+	Update(requestID string, fn func(*AcknowledgmentRecord) error) error
+
+	// Due returns the IDs of pending requests whose expiry is not after now
+	Due(now time.Time) ([]string, error)
+
+	// Collect deletes the requests closed before the cutoff and returns how
+	// many were deleted
+	Collect(before time.Time) (int, error)
+}
+
+// MemoryAcknowledgmentStore is an AcknowledgmentStore kept in process
+// memory, so it serves a single instance only
+type MemoryAcknowledgmentStore struct {
+	mu      sync.Mutex
+	records map[string]AcknowledgmentRecord // Map of request ID -> request with its responses
+}
+
+// NewMemoryAcknowledgmentStore creates a new in-memory acknowledgment store
+func NewMemoryAcknowledgmentStore() *MemoryAcknowledgmentStore {
+	return &MemoryAcknowledgmentStore{
+		records: make(map[string]AcknowledgmentRecord),
+	}
+}
+
+// Create stores the request
+func (m *MemoryAcknowledgmentStore) Create(record AcknowledgmentRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records[record.Request.ID] = record.clone()
+	return nil
+}
+
+// Get returns a copy of the request
+func (m *MemoryAcknowledgmentStore) Get(requestID string) (AcknowledgmentRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.records[requestID]
+	if !ok {
+		return AcknowledgmentRecord{}, ErrAcknowledgmentNotFound
+	}
+	return record.clone(), nil
+}
+
+// Update runs fn on a copy of the request under the store's lock
+func (m *MemoryAcknowledgmentStore) Update(requestID string, fn func(*AcknowledgmentRecord) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.records[requestID]
+	if !ok {
+		return ErrAcknowledgmentNotFound
+	}
+
+	updated := record.clone()
+	if err := fn(&updated); err != nil {
+		return err
+	}
+	m.records[requestID] = updated
+	return nil
+}
+
+// Due scans the pending requests
+func (m *MemoryAcknowledgmentStore) Due(now time.Time) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []string
+	for id, record := range m.records {
+		if record.Request.Status == types.AcknowledgmentStatusPending && !record.expiresAt().After(now) {
+			due = append(due, id)
+		}
+	}
+	return due, nil
+}
+
+// Collect scans the closed requests
+func (m *MemoryAcknowledgmentStore) Collect(before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := 0
+	for id, record := range m.records {
+		if closedAt := record.Request.ClosedAt; closedAt != nil && closedAt.Before(before) {
+			delete(m.records, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// CreateAcknowledgmentRequest creates an acknowledgment request and broadcasts it.
+// If a deadline is set, the request expires at that time and the requester is
+// told which recipients never responded; without one it expires after
+// acknowledgmentMaxPending. If options are set, every response
+// must choose one of them. Groups are expanded to their current members, and
+// the request records the resolved recipients.
+func (s *NotificationService) CreateAcknowledgmentRequest(input types.AcknowledgeRequest) (string, error) {
+	recipients, err := s.ResolveRecipients(input.ToUsernames, input.ToGroups)
+	if err != nil {
+		return "", err
+	}
+
+	requestID := uuid.New().String()
+	req := types.AcknowledgmentRequest{
+		ID:           requestID,
+		FromUsername: input.FromUsername,
+		ToUsernames:  recipients,
+		ToGroups:     input.ToGroups,
+		Message:      input.Message,
+		Options:      input.Options,
+		CreatedAt:    time.Now(),
+		Deadline:     input.Deadline,
+		Status:       types.AcknowledgmentStatusPending,
+	}
+
+	record := AcknowledgmentRecord{Request: req, Responses: []types.AcknowledgmentResponse{}}
+	if err := s.acknowledgments.Create(record); err != nil {
+		return "", err
+	}
+
+	// The sweep of any instance expires the request should this one stop
+	// before the deadline; the timer just expires it on time
+	if req.Deadline != nil {
+		s.mu.Lock()
+		s.acknowledgmentTimers[requestID] = time.AfterFunc(time.Until(*req.Deadline), func() {
+			s.expireAcknowledgmentRequest(requestID)
+		})
+		s.mu.Unlock()
+	}
+
+	payload := types.AcknowledgmentRequestPayload{
+		ID:           req.ID,
+		FromUsername: req.FromUsername,
+		ToUsernames:  req.ToUsernames,
+		ToGroups:     req.ToGroups,
+		Message:      req.Message,
+		Options:      req.Options,
+		Deadline:     req.Deadline,
+	}
+
+	s.publish(types.EventTypeAcknowledgmentRequest, payload, req.ToUsernames)
+
+	return requestID, nil
+}
+
+// RecordAcknowledgment records a recipient's response to a request. Repeated
+// responses are accepted but have no further effect; the first one stands.
+// Once every recipient has responded the requester gets an
+// acknowledgment_completed event.
+func (s *NotificationService) RecordAcknowledgment(resp types.AcknowledgmentResponse) error {
+	var (
+		req          types.AcknowledgmentRequest
+		recorded     bool
+		completed    bool
+		acknowledged []string
+	)
+	err := s.acknowledgments.Update(resp.RequestID, func(record *AcknowledgmentRecord) error {
+		req, recorded, completed, acknowledged = record.Request, false, false, nil
+
+		if !slices.Contains(req.ToUsernames, resp.FromUsername) {
+			return ErrNotRecipient
+		}
+		if record.hasResponded(resp.FromUsername) {
+			return nil
+		}
+		if req.Status != types.AcknowledgmentStatusPending {
+			return ErrAcknowledgmentClosed
+		}
+		if len(req.Options) > 0 && !slices.Contains(req.Options, resp.Option) {
+			return ErrInvalidOption
+		}
+		if len(req.Options) == 0 && resp.Option != "" {
+			return ErrInvalidOption
+		}
+
+		// Add to the responses
+		resp.RespondedAt = time.Now()
+		record.Responses = append(record.Responses, resp)
+		recorded = true
+
+		if len(record.pending()) == 0 {
+			record.close(types.AcknowledgmentStatusCompleted, resp.RespondedAt)
+			completed = true
+			acknowledged = record.respondents()
+		}
+		return nil
+	})
+	if err != nil || !recorded {
+		return err
+	}
+
+	payload := types.AcknowledgmentResponsePayload{
+		RequestID:    resp.RequestID,
+		FromUsername: resp.FromUsername,
+		Option:       resp.Option,
+		Comment:      resp.Comment,
+	}
+
+	// Send response to the requester only
+	s.publish(types.EventTypeAcknowledgmentResponse, payload, []string{req.FromUsername})
+
+	if completed {
+		log.Printf("Acknowledgment request %s completed", req.ID)
+		s.stopAcknowledgmentTimer(req.ID)
+
+		s.publish(types.EventTypeAcknowledgmentCompleted, types.AcknowledgmentCompletedPayload{
+			RequestID:    req.ID,
+			Acknowledged: acknowledged,
+		}, []string{req.FromUsername})
+	}
+
+	return nil
+}
+
+// GetAcknowledgmentStatus returns a snapshot of a request with its responses
+// and pending recipients, or ErrAcknowledgmentNotFound
+func (s *NotificationService) GetAcknowledgmentStatus(requestID string) (types.AcknowledgmentStatusInfo, error) {
+	record, err := s.acknowledgments.Get(requestID)
+	if err != nil {
+		return types.AcknowledgmentStatusInfo{}, err
+	}
+
+	return types.AcknowledgmentStatusInfo{
+		AcknowledgmentRequest: record.Request,
+		Acknowledged:          record.respondents(),
+		Pending:               record.pending(),
+		Responses:             record.Responses,
+	}, nil
+}
+
+// GetAcknowledgmentTally summarises the responses to a request per option,
+// or returns ErrAcknowledgmentNotFound
+func (s *NotificationService) GetAcknowledgmentTally(requestID string) (types.AcknowledgmentTally, error) {
+	record, err := s.acknowledgments.Get(requestID)
+	if err != nil {
+		return types.AcknowledgmentTally{}, err
+	}
+
+	tally := types.AcknowledgmentTally{
+		RequestID: requestID,
+		Options:   make([]types.AcknowledgmentOptionTally, 0, len(record.Request.Options)),
+		Responded: len(record.Responses),
+		Pending:   len(record.pending()),
+	}
+	for _, option := range record.Request.Options {
+		entry := types.AcknowledgmentOptionTally{Option: option, Usernames: []string{}}
+		for _, resp := range record.Responses {
+			if resp.Option == option {
+				entry.Count++
+				entry.Usernames = append(entry.Usernames, resp.FromUsername)
+			}
+		}
+		tally.Options = append(tally.Options, entry)
+	}
+
+	return tally, nil
+}
+
+// expireAcknowledgmentRequest closes a request whose deadline or maximum age
+// has passed and tells the requester who never responded. Only the instance
+// whose update closes the request sends the event.
+func (s *NotificationService) expireAcknowledgmentRequest(requestID string) {
+	s.stopAcknowledgmentTimer(requestID)
+
+	var (
+		req          types.AcknowledgmentRequest
+		expired      bool
+		deadline     time.Time
+		pending      []string
+		acknowledged []string
+	)
+	err := s.acknowledgments.Update(requestID, func(record *AcknowledgmentRecord) error {
+		req, expired = record.Request, record.Request.Status == types.AcknowledgmentStatusPending
+		if !expired {
+			return nil
+		}
+
+		deadline = record.expiresAt()
+		pending = record.pending()
+		acknowledged = record.respondents()
+		record.close(types.AcknowledgmentStatusExpired, time.Now())
+		return nil
+	})
+	if errors.Is(err, ErrAcknowledgmentNotFound) {
+		return
+	}
+	if err != nil {
+		log.Printf("Error expiring acknowledgment request %s: %v", requestID, err)
+		return
+	}
+	if !expired {
+		return
+	}
+
+	log.Printf("Acknowledgment request %s expired with %d pending recipient(s)", requestID, len(pending))
+
+	payload := types.AcknowledgmentExpiredPayload{
+		RequestID:    requestID,
+		Deadline:     deadline,
+		Acknowledged: acknowledged,
+		Pending:      pending,
+	}
+	s.publish(types.EventTypeAcknowledgmentExpired, payload, []string{req.FromUsername})
+}
+
+// collectAcknowledgments periodically removes requests that were closed
+// longer than acknowledgmentRetention ago and expires the ones left pending
+// past their deadline or acknowledgmentMaxPending
+func (s *NotificationService) collectAcknowledgments() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(acknowledgmentSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.sweepAcknowledgments(time.Now())
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// sweepAcknowledgments deletes the requests closed before now minus the
+// retention and expires the pending ones whose expiry is not after now. This
+// also expires requests with a deadline whose creating instance went away
+// before its timer fired.
+func (s *NotificationService) sweepAcknowledgments(now time.Time) {
+	removed, err := s.acknowledgments.Collect(now.Add(-acknowledgmentRetention))
+	if err != nil {
+		log.Printf("Error collecting closed acknowledgment requests: %v", err)
+	}
+	if removed > 0 {
+		log.Printf("Collected %d closed acknowledgment request(s)", removed)
+	}
+
+	due, err := s.acknowledgments.Due(now)
+	if err != nil {
+		log.Printf("Error listing overdue acknowledgment requests: %v", err)
+		return
+	}
+	for _, id := range due {
+		s.expireAcknowledgmentRequest(id)
+	}
+}
+
+// stopAcknowledgmentTimer stops the request's deadline timer if this
+// instance started one
+func (s *NotificationService) stopAcknowledgmentTimer(requestID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, ok := s.acknowledgmentTimers[requestID]; ok {
+		timer.Stop()
+		delete(s.acknowledgmentTimers, requestID)
+	}
+}
+
+// clone copies the record so changes to it do not reach the stored one
+func (r AcknowledgmentRecord) clone() AcknowledgmentRecord {
+	r.Responses = slices.Clone(r.Responses)
+	return r
+}
+
+// close moves a pending request to a final status
+func (r *AcknowledgmentRecord) close(status types.AcknowledgmentStatus, at time.Time) {
+	r.Request.Status = status
+	r.Request.ClosedAt = &at
+}
+
+// expiresAt returns when the request expires if it is still pending: its
+// deadline, or acknowledgmentMaxPending after it was created
+func (r AcknowledgmentRecord) expiresAt() time.Time {
+	if r.Request.Deadline != nil {
+		return *r.Request.Deadline
+	}
+	return r.Request.CreatedAt.Add(acknowledgmentMaxPending)
+}
+
+// pending returns the recipients who have not responded yet
+func (r AcknowledgmentRecord) pending() []string {
+	pending := []string{}
+	for _, username := range r.Request.ToUsernames {
+		if !r.hasResponded(username) {
+			pending = append(pending, username)
+		}
+	}
+	return pending
+}
+
+// respondents returns the recipients who have responded, in response order
+func (r AcknowledgmentRecord) respondents() []string {
+	respondents := []string{}
+	for _, resp := range r.Responses {
+		respondents = append(respondents, resp.FromUsername)
+	}
+	return respondents
+}
+
+// hasResponded reports whether username has responded
+func (r AcknowledgmentRecord) hasResponded(username string) bool {
+	for _, resp := range r.Responses {
+		if resp.FromUsername == username {
+			return true
+		}
+	}
+	return false
+}
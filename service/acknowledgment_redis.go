@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sse-demo/types"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisUpdateAttempts is how often an acknowledgment update is retried when
+// another instance changed the request in between
+const redisUpdateAttempts = 10
+
+// RedisAcknowledgmentStore is an AcknowledgmentStore shared through Redis, so
+// a recipient can respond through any server instance. Each request is one
+// key holding the record; pending requests are also indexed by expiry in a
+// sorted set. Keys carry a TTL, and Redis drops closed requests once the
+// retention has passed.
+type RedisAcknowledgmentStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisAcknowledgmentStore connects to the Redis server at url
+// (redis://host:port/db). Keys are namespaced with prefix.
+func NewRedisAcknowledgmentStore(url string, prefix string) (*RedisAcknowledgmentStore, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	return &RedisAcknowledgmentStore{client: client, prefix: prefix}, nil
+}
+
+// Create stores the record and indexes it as pending
+func (r *RedisAcknowledgmentStore) Create(record AcknowledgmentRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal acknowledgment request: %w", err)
+	}
+
+	ctx := context.Background()
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, r.requestKey(record.Request.ID), data, r.ttl(record, time.Now()))
+		pipe.ZAdd(ctx, r.pendingKey(), redis.Z{Score: float64(record.expiresAt().UnixMilli()), Member: record.Request.ID})
+		return nil
+	})
+	return err
+}
+
+// Get decodes the stored record
+func (r *RedisAcknowledgmentStore) Get(requestID string) (AcknowledgmentRecord, error) {
+	data, err := r.client.Get(context.Background(), r.requestKey(requestID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return AcknowledgmentRecord{}, ErrAcknowledgmentNotFound
+	}
+	if err != nil {
+		return AcknowledgmentRecord{}, err
+	}
+	return decodeAcknowledgmentRecord(data)
+}
+
+// Update watches the request's key, so fn is run again on the latest record
+// if another instance wrote it first
+func (r *RedisAcknowledgmentStore) Update(requestID string, fn func(*AcknowledgmentRecord) error) error {
+	ctx := context.Background()
+	key := r.requestKey(requestID)
+
+	update := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			return ErrAcknowledgmentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		record, err := decodeAcknowledgmentRecord(data)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(&record); err != nil {
+			return err
+		}
+
+		if data, err = json.Marshal(record); err != nil {
+			return fmt.Errorf("failed to marshal acknowledgment request: %w", err)
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, r.ttl(record, time.Now()))
+			if record.Request.Status != types.AcknowledgmentStatusPending {
+				pipe.ZRem(ctx, r.pendingKey(), requestID)
+			}
+			return nil
+		})
+		return err
+	}
+
+	for range redisUpdateAttempts {
+		err := r.client.Watch(ctx, update, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return fmt.Errorf("acknowledgment request %s changed concurrently %d times", requestID, redisUpdateAttempts)
+}
+
+// Due reads the pending index up to now
+func (r *RedisAcknowledgmentStore) Due(now time.Time) ([]string, error) {
+	return r.client.ZRangeByScore(context.Background(), r.pendingKey(), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.UnixMilli(), 10),
+	}).Result()
+}
+
+// Collect only drops index entries left behind by requests Redis expired
+// before any instance could close them; the records themselves expire
+// through their TTL, so it reports none deleted
+func (r *RedisAcknowledgmentStore) Collect(before time.Time) (int, error) {
+	err := r.client.ZRemRangeByScore(context.Background(), r.pendingKey(), "-inf", strconv.FormatInt(before.UnixMilli(), 10)).Err()
+	return 0, err
+}
+
+// Close closes the Redis connection
+func (r *RedisAcknowledgmentStore) Close() error {
+	return r.client.Close()
+}
+
+// ttl returns how long Redis keeps the record: the retention after it
+// closed or, for a pending request, after it is due to expire
+func (r *RedisAcknowledgmentStore) ttl(record AcknowledgmentRecord, now time.Time) time.Duration {
+	end := record.expiresAt()
+	if record.Request.ClosedAt != nil {
+		end = *record.Request.ClosedAt
+	}
+	return max(end.Add(acknowledgmentRetention).Sub(now), time.Second)
+}
+
+func (r *RedisAcknowledgmentStore) requestKey(requestID string) string {
+	return r.prefix + ":ack:" + requestID
+}
+
+func (r *RedisAcknowledgmentStore) pendingKey() string {
+	return r.prefix + ":ack-pending"
+}
+
+// decodeAcknowledgmentRecord decodes a record stored as JSON
+func decodeAcknowledgmentRecord(data []byte) (AcknowledgmentRecord, error) {
+	var record AcknowledgmentRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return AcknowledgmentRecord{}, fmt.Errorf("failed to decode acknowledgment request: %w", err)
+	}
+	return record, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"sse-demo/types"
+	"testing"
+	"time"
+)
+
+func TestSweepExpiresAndCollectsRequestsWithoutDeadline(t *testing.T) {
+	s, err := NewNotificationService(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	requester, _ := s.AddClient("alice", nil)
+	defer s.RemoveClient(requester)
+
+	id, err := s.CreateAcknowledgmentRequest(types.AcknowledgeRequest{
+		FromUsername: "alice",
+		ToUsernames:  []string{"bob"},
+		Message:      "Anyone there?",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	s.sweepAcknowledgments(now.Add(time.Hour))
+	if status, _ := s.GetAcknowledgmentStatus(id); status.Status != types.AcknowledgmentStatusPending {
+		t.Fatalf("status after an hour = %s, want pending", status.Status)
+	}
+
+	s.sweepAcknowledgments(now.Add(acknowledgmentMaxPending + time.Minute))
+	status, err := s.GetAcknowledgmentStatus(id)
+	if err != nil || status.Status != types.AcknowledgmentStatusExpired {
+		t.Fatalf("status after the maximum age = %+v, want expired", status)
+	}
+
+	expired := false
+	for event, ok := requester.Events.Pop(); ok; event, ok = requester.Events.Pop() {
+		expired = expired || event.Type == types.EventTypeAcknowledgmentExpired
+	}
+	if !expired {
+		t.Error("requester was not sent acknowledgment_expired")
+	}
+
+	s.sweepAcknowledgments(now.Add(acknowledgmentMaxPending + acknowledgmentRetention + 2*time.Minute))
+	if _, err := s.GetAcknowledgmentStatus(id); !errors.Is(err, ErrAcknowledgmentNotFound) {
+		t.Error("expired request was not collected after the retention")
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sse-demo/types"
+	"sync"
+)
+
+// Message is a typed SSE event addressed to a set of recipients as it travels
+// through a Broker. A message carrying a do-not-disturb change has no event;
+// every instance applies the change instead.
+type Message struct {
+	Event        types.SSEEvent      `json:"event"`
+	Recipients   []string            `json:"recipients"`    // Empty list means broadcast to all
+	DoNotDisturb *DoNotDisturbChange `json:"dnd,omitempty"` // Setting changed on some instance
+}
+
+// DoNotDisturbChange is a user's new do-not-disturb setting
+type DoNotDisturbChange struct {
+	Username string             `json:"username"`
+	Setting  types.DoNotDisturb `json:"setting"`
+}
+
+// Broker distributes events and presence between NotificationService
+// instances. With the in-memory broker a single process is the whole cluster;
+// a shared broker lets several server processes run behind a load balancer.
+//
+// Event IDs and replay buffers stay local to each instance, so reconnecting
+// clients should be routed back to the same instance (sticky sessions) to
+// resume with Last-Event-ID.
+type Broker interface {
+	// Publish sends a message to every subscribed instance, including this one
+	Publish(ctx context.Context, msg Message) error
+
+	// Subscribe registers the handler called for every published message
+	Subscribe(handler func(Message)) error
+
+	// Join records a new connection for username and reports whether it is
+	// the user's first connection in the cluster
+	Join(ctx context.Context, username string) (bool, error)
+
+	// Leave records a closed connection for username and reports whether it
+	// was the user's last connection in the cluster
+	Leave(ctx context.Context, username string) (bool, error)
+
+	// Members returns the usernames with at least one open connection
+	Members(ctx context.Context) ([]string, error)
+
+	// Close releases the broker's resources
+	Close() error
+}
+
+// MemoryBroker is the default single-process Broker.
+type MemoryBroker struct {
+	mu       sync.Mutex
+	handlers []func(Message)
+	presence map[string]int // Map of username -> open connection count
+}
+
+// NewMemoryBroker creates a new in-memory broker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		presence: make(map[string]int),
+	}
+}
+
+// Publish delivers the message synchronously to every subscribed handler
+func (b *MemoryBroker) Publish(ctx context.Context, msg Message) error {
+	b.mu.Lock()
+	handlers := make([]func(Message), len(b.handlers))
+	copy(handlers, b.handlers)
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(msg)
+	}
+	return nil
+}
+
+// Subscribe registers a handler for published messages
+func (b *MemoryBroker) Subscribe(handler func(Message)) error {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, handler)
+	b.mu.Unlock()
+	return nil
+}
+
+// Join increments the user's connection count
+func (b *MemoryBroker) Join(ctx context.Context, username string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.presence[username]++
+	return b.presence[username] == 1, nil
+}
+
+// Leave decrements the user's connection count
+func (b *MemoryBroker) Leave(ctx context.Context, username string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.presence[username] == 0 {
+		return false, nil
+	}
+
+	b.presence[username]--
+	if b.presence[username] == 0 {
+		delete(b.presence, username)
+		return true, nil
+	}
+	return false, nil
+}
+
+// Members returns the connected usernames
+func (b *MemoryBroker) Members(ctx context.Context) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	users := make([]string, 0, len(b.presence))
+	for username := range b.presence {
+		users = append(users, username)
+	}
+	sort.Strings(users)
+	return users, nil
+}
+
+// Close is a no-op for the in-memory broker
+func (b *MemoryBroker) Close() error {
+	return nil
+}
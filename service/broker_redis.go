@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisPresenceTTL is how long a silent instance's presence survives in Redis
+	redisPresenceTTL = 30 * time.Second
+
+	// redisHeartbeatInterval is how often an instance refreshes its presence
+	redisHeartbeatInterval = 10 * time.Second
+)
+
+// presenceElsewhereLua is the Lua body shared by the presence scripts: it
+// reports whether ARGV[1] is present on a live instance other than ARGV[3].
+// The keys of other instances are derived from the prefix in ARGV[2], which
+// ties the scripts to a single Redis server rather than a cluster.
+const presenceElsewhereLua = `
+local function elsewhere()
+	for _, node in ipairs(redis.call('SMEMBERS', KEYS[2])) do
+		if node ~= ARGV[3]
+			and redis.call('EXISTS', ARGV[2] .. ':node:' .. node) == 1
+			and redis.call('HEXISTS', ARGV[2] .. ':presence:' .. node, ARGV[1]) == 1 then
+			return true
+		end
+	end
+	return false
+end
+`
+
+// joinScript increments the user's count in this instance's presence hash
+// (KEYS[1]) and returns 1 if that made them present in the cluster. Running
+// as one script keeps two instances from both seeing the other's connection.
+var joinScript = redis.NewScript(presenceElsewhereLua + `
+local count = redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+if count ~= 1 or elsewhere() then
+	return 0
+end
+return 1
+`)
+
+// leaveScript decrements the user's count and returns 1 if that was their
+// last connection in the cluster
+var leaveScript = redis.NewScript(presenceElsewhereLua + `
+local count = redis.call('HINCRBY', KEYS[1], ARGV[1], -1)
+if count > 0 then
+	return 0
+end
+redis.call('HDEL', KEYS[1], ARGV[1])
+if elsewhere() then
+	return 0
+end
+return 1
+`)
+
+// RedisBroker shares events and presence between server processes through
+// Redis pub/sub. Each instance keeps its own presence hash with a TTL, so the
+// users of a crashed instance drop out of Members once the TTL lapses.
+type RedisBroker struct {
+	client *redis.Client
+	prefix string
+	nodeID string
+
+	mu      sync.Mutex
+	pubsubs []*redis.PubSub
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewRedisBroker connects to the Redis server at url (redis://host:port/db)
+// and registers this instance. All keys and the pub/sub channel are
+// namespaced with prefix.
+func NewRedisBroker(url string, prefix string) (*RedisBroker, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	b := &RedisBroker{
+		client: redis.NewClient(opts),
+		prefix: prefix,
+		nodeID: uuid.New().String(),
+		stop:   make(chan struct{}),
+	}
+
+	ctx := context.Background()
+	if err := b.client.Ping(ctx).Err(); err != nil {
+		b.client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	if err := b.heartbeat(ctx); err != nil {
+		b.client.Close()
+		return nil, fmt.Errorf("failed to register instance: %w", err)
+	}
+
+	b.wg.Add(1)
+	go b.heartbeatLoop()
+
+	log.Printf("Redis broker connected (instance %s)", b.nodeID)
+	return b, nil
+}
+
+// Publish sends the message on the shared events channel
+func (b *RedisBroker) Publish(ctx context.Context, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	return b.client.Publish(ctx, b.eventsChannel(), data).Err()
+}
+
+// Subscribe listens on the shared events channel and calls handler for every message
+func (b *RedisBroker) Subscribe(handler func(Message)) error {
+	ctx := context.Background()
+
+	pubsub := b.client.Subscribe(ctx, b.eventsChannel())
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+
+	b.mu.Lock()
+	b.pubsubs = append(b.pubsubs, pubsub)
+	b.mu.Unlock()
+
+	go func() {
+		for m := range pubsub.Channel() {
+			var msg Message
+			if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+				log.Printf("Error decoding broker message: %v", err)
+				continue
+			}
+			handler(msg)
+		}
+	}()
+
+	return nil
+}
+
+// Join increments the user's connection count on this instance
+func (b *RedisBroker) Join(ctx context.Context, username string) (bool, error) {
+	return b.runPresenceScript(ctx, joinScript, username)
+}
+
+// Leave decrements the user's connection count on this instance
+func (b *RedisBroker) Leave(ctx context.Context, username string) (bool, error) {
+	return b.runPresenceScript(ctx, leaveScript, username)
+}
+
+// runPresenceScript runs a presence script for username, returning whether
+// the user's cluster-wide presence changed
+func (b *RedisBroker) runPresenceScript(ctx context.Context, script *redis.Script, username string) (bool, error) {
+	keys := []string{b.presenceKey(b.nodeID), b.nodesKey()}
+	changed, err := script.Run(ctx, b.client, keys, username, b.prefix, b.nodeID, int(redisPresenceTTL.Seconds())).Int()
+	if err != nil {
+		return false, err
+	}
+	return changed == 1, nil
+}
+
+// Members returns the usernames connected to any live instance
+func (b *RedisBroker) Members(ctx context.Context) ([]string, error) {
+	nodes, err := b.liveNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		usernames, err := b.client.HKeys(ctx, b.presenceKey(node)).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, username := range usernames {
+			seen[username] = true
+		}
+	}
+
+	users := make([]string, 0, len(seen))
+	for username := range seen {
+		users = append(users, username)
+	}
+	sort.Strings(users)
+	return users, nil
+}
+
+// Close unregisters this instance and closes the Redis connection
+func (b *RedisBroker) Close() error {
+	close(b.stop)
+	b.wg.Wait()
+
+	b.mu.Lock()
+	for _, pubsub := range b.pubsubs {
+		pubsub.Close()
+	}
+	b.pubsubs = nil
+	b.mu.Unlock()
+
+	ctx := context.Background()
+	b.client.SRem(ctx, b.nodesKey(), b.nodeID)
+	b.client.Del(ctx, b.nodeKey(b.nodeID), b.presenceKey(b.nodeID))
+
+	return b.client.Close()
+}
+
+// liveNodes returns the registered instances whose heartbeat has not expired,
+// pruning the ones that have
+func (b *RedisBroker) liveNodes(ctx context.Context) ([]string, error) {
+	nodes, err := b.client.SMembers(ctx, b.nodesKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	live := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		alive, err := b.client.Exists(ctx, b.nodeKey(node)).Result()
+		if err != nil {
+			return nil, err
+		}
+		if alive == 0 {
+			b.client.SRem(ctx, b.nodesKey(), node)
+			b.client.Del(ctx, b.presenceKey(node))
+			continue
+		}
+		live = append(live, node)
+	}
+	return live, nil
+}
+
+// heartbeat refreshes this instance's registration and presence TTLs
+func (b *RedisBroker) heartbeat(ctx context.Context) error {
+	_, err := b.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, b.nodesKey(), b.nodeID)
+		pipe.Set(ctx, b.nodeKey(b.nodeID), time.Now().Unix(), redisPresenceTTL)
+		pipe.Expire(ctx, b.presenceKey(b.nodeID), redisPresenceTTL)
+		return nil
+	})
+	return err
+}
+
+// heartbeatLoop keeps this instance registered until Close is called
+func (b *RedisBroker) heartbeatLoop() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(redisHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := b.heartbeat(context.Background()); err != nil {
+				log.Printf("Error refreshing redis presence: %v", err)
+			}
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+func (b *RedisBroker) eventsChannel() string {
+	return b.prefix + ":events"
+}
+
+func (b *RedisBroker) nodesKey() string {
+	return b.prefix + ":nodes"
+}
+
+func (b *RedisBroker) nodeKey(node string) string {
+	return b.prefix + ":node:" + node
+}
+
+func (b *RedisBroker) presenceKey(node string) string {
+	return b.prefix + ":presence:" + node
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sse-demo/types"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// newTestRedisBrokers starts an in-process Redis server and connects n
+// brokers to it, as n server instances would
+func newTestRedisBrokers(t *testing.T, n int) (*miniredis.Miniredis, []*RedisBroker) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	brokers := make([]*RedisBroker, n)
+	for i := range brokers {
+		b, err := NewRedisBroker("redis://"+server.Addr(), "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { b.Close() })
+		brokers[i] = b
+	}
+	return server, brokers
+}
+
+func TestRedisBrokerPublishReachesEveryInstance(t *testing.T) {
+	_, brokers := newTestRedisBrokers(t, 2)
+
+	received := make([]chan Message, len(brokers))
+	for i, b := range brokers {
+		ch := make(chan Message, 1)
+		received[i] = ch
+		if err := b.Subscribe(func(msg Message) { ch <- msg }); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sent := Message{
+		Event:      types.SSEEvent{Type: types.EventTypeNotification},
+		Recipients: []string{"bob"},
+	}
+	if err := brokers[0].Publish(context.Background(), sent); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, ch := range received {
+		select {
+		case msg := <-ch:
+			if msg.Event.Type != sent.Event.Type || !slices.Equal(msg.Recipients, sent.Recipients) {
+				t.Errorf("instance %d received %+v, want %+v", i, msg, sent)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("instance %d did not receive the message", i)
+		}
+	}
+}
+
+func TestRedisBrokerJoinLeaveAcrossInstances(t *testing.T) {
+	_, brokers := newTestRedisBrokers(t, 2)
+	a, b := brokers[0], brokers[1]
+	ctx := context.Background()
+
+	steps := []struct {
+		name   string
+		action func(context.Context, string) (bool, error)
+		want   bool
+	}{
+		{"first connection on a", a.Join, true},
+		{"second connection on a", a.Join, false},
+		{"connection on b", b.Join, false},
+		{"close one on a", a.Leave, false},
+		{"close last on a", a.Leave, false},
+		{"close last on b", b.Leave, true},
+		{"reconnect on b", b.Join, true},
+	}
+	for _, step := range steps {
+		got, err := step.action(ctx, "alice")
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if got != step.want {
+			t.Errorf("%s: got %v, want %v", step.name, got, step.want)
+		}
+	}
+
+	members, err := a.Members(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(members, []string{"alice"}) {
+		t.Errorf("Members = %v, want [alice]", members)
+	}
+}
+
+func TestRedisBrokerConcurrentFirstJoinsReportOnce(t *testing.T) {
+	_, brokers := newTestRedisBrokers(t, 2)
+	ctx := context.Background()
+
+	const users = 50
+	for _, action := range []string{"join", "leave"} {
+		var mu sync.Mutex
+		changes := make(map[string]int)
+
+		var wg sync.WaitGroup
+		for i := range users {
+			for _, b := range brokers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					username := benchUsername(i)
+					run := b.Join
+					if action == "leave" {
+						run = b.Leave
+					}
+					changed, err := run(ctx, username)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					if changed {
+						mu.Lock()
+						changes[username]++
+						mu.Unlock()
+					}
+				}()
+			}
+		}
+		wg.Wait()
+
+		for i := range users {
+			if got := changes[benchUsername(i)]; got != 1 {
+				t.Errorf("%s: %s reported %d times, want once", action, benchUsername(i), got)
+			}
+		}
+	}
+}
+
+func TestRedisBrokerPresenceExpiresWithInstance(t *testing.T) {
+	server, brokers := newTestRedisBrokers(t, 2)
+	a, b := brokers[0], brokers[1]
+	ctx := context.Background()
+
+	if _, err := a.Join(ctx, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	members, err := b.Members(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(members, []string{"alice"}) {
+		t.Fatalf("Members = %v, want [alice]", members)
+	}
+
+	// Both instances stop heartbeating as far as Redis can tell; b's own
+	// registration is refreshed so only a has gone silent
+	server.FastForward(redisPresenceTTL + time.Second)
+	if err := b.heartbeat(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err = b.Members(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 0 {
+		t.Errorf("Members after a's TTL lapsed = %v, want none", members)
+	}
+
+	first, err := b.Join(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first {
+		t.Error("joining after a's TTL lapsed should be the user's first connection")
+	}
+}
+
+// newTestRedisInstances starts n notification services sharing a broker and
+// an acknowledgment store through one Redis server, as n server instances
+func newTestRedisInstances(t *testing.T, n int) []*NotificationService {
+	t.Helper()
+
+	server, brokers := newTestRedisBrokers(t, n)
+	instances := make([]*NotificationService, n)
+	for i, b := range brokers {
+		acknowledgments, err := NewRedisAcknowledgmentStore("redis://"+server.Addr(), "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { acknowledgments.Close() })
+
+		s, err := NewNotificationService(Options{Broker: b, Acknowledgments: acknowledgments})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(s.Close)
+		instances[i] = s
+	}
+	return instances
+}
+
+// waitForEvent pops events from the client until one of the given type
+// arrives, failing the test if none does within two seconds
+func waitForEvent(t *testing.T, client *Client, eventType types.EventType) Event {
+	t.Helper()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		for event, ok := client.Events.Pop(); ok; event, ok = client.Events.Pop() {
+			if event.Type == eventType {
+				return event
+			}
+		}
+		select {
+		case <-client.Events.Ready():
+		case <-timeout:
+			t.Fatalf("%s was not sent %s", client.Username, eventType)
+		}
+	}
+}
+
+func TestRedisAcknowledgmentAnsweredOnAnotherInstance(t *testing.T) {
+	instances := newTestRedisInstances(t, 2)
+	creator, other := instances[0], instances[1]
+
+	requester, _ := creator.AddClient("alice", nil)
+	defer creator.RemoveClient(requester)
+
+	id, err := creator.CreateAcknowledgmentRequest(types.AcknowledgeRequest{
+		FromUsername: "alice",
+		ToUsernames:  []string{"bob", "carol"},
+		Message:      "Deploy?",
+		Options:      []string{"yes", "no"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		name    string
+		resp    types.AcknowledgmentResponse
+		wantErr error
+	}{
+		{"not a recipient", types.AcknowledgmentResponse{RequestID: id, FromUsername: "mallory", Option: "yes"}, ErrNotRecipient},
+		{"option not offered", types.AcknowledgmentResponse{RequestID: id, FromUsername: "bob", Option: "maybe"}, ErrInvalidOption},
+		{"bob answers", types.AcknowledgmentResponse{RequestID: id, FromUsername: "bob", Option: "yes"}, nil},
+		{"bob answers again", types.AcknowledgmentResponse{RequestID: id, FromUsername: "bob", Option: "no"}, nil},
+		{"carol answers", types.AcknowledgmentResponse{RequestID: id, FromUsername: "carol", Option: "no"}, nil},
+		{"unknown request", types.AcknowledgmentResponse{RequestID: "missing", FromUsername: "bob"}, ErrAcknowledgmentNotFound},
+	}
+	for _, step := range steps {
+		if err := other.RecordAcknowledgment(step.resp); !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: error = %v, want %v", step.name, err, step.wantErr)
+		}
+	}
+
+	for i, s := range instances {
+		status, err := s.GetAcknowledgmentStatus(id)
+		if err != nil {
+			t.Fatalf("instance %d: %v", i, err)
+		}
+		if status.Status != types.AcknowledgmentStatusCompleted || !slices.Equal(status.Acknowledged, []string{"bob", "carol"}) {
+			t.Errorf("instance %d: status %s acknowledged by %v, want completed by [bob carol]", i, status.Status, status.Acknowledged)
+		}
+
+		tally, err := s.GetAcknowledgmentTally(id)
+		if err != nil {
+			t.Fatalf("instance %d: %v", i, err)
+		}
+		if tally.Responded != 2 || tally.Options[0].Count != 1 || tally.Options[1].Count != 1 {
+			t.Errorf("instance %d: tally %+v, want one yes and one no", i, tally)
+		}
+	}
+
+	waitForEvent(t, requester, types.EventTypeAcknowledgmentCompleted)
+}
+
+func TestRedisAcknowledgmentExpiredByAnotherInstance(t *testing.T) {
+	instances := newTestRedisInstances(t, 2)
+	creator, other := instances[0], instances[1]
+
+	requester, _ := other.AddClient("alice", nil)
+	defer other.RemoveClient(requester)
+
+	deadline := time.Now().Add(time.Hour)
+	id, err := creator.CreateAcknowledgmentRequest(types.AcknowledgeRequest{
+		FromUsername: "alice",
+		ToUsernames:  []string{"bob"},
+		Message:      "Anyone there?",
+		Deadline:     &deadline,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// As if the creating instance stopped before its deadline timer fired
+	creator.stopAcknowledgmentTimer(id)
+
+	other.sweepAcknowledgments(deadline.Add(-time.Minute))
+	if status, _ := other.GetAcknowledgmentStatus(id); status.Status != types.AcknowledgmentStatusPending {
+		t.Fatalf("status before the deadline = %s, want pending", status.Status)
+	}
+
+	other.sweepAcknowledgments(deadline.Add(time.Minute))
+	if status, _ := other.GetAcknowledgmentStatus(id); status.Status != types.AcknowledgmentStatusExpired {
+		t.Fatalf("status after the deadline = %s, want expired", status.Status)
+	}
+	waitForEvent(t, requester, types.EventTypeAcknowledgmentExpired)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"regexp"
+	"slices"
+	"sort"
+	"sse-demo/types"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChannelStore records which users have joined each channel. A channel exists
+// while it has at least one member.
+type ChannelStore interface {
+	// Join adds username to the channel, creating it if needed, and reports
+	// whether the user was not a member yet
+	Join(channel string, username string) (bool, error)
+
+	// Leave removes username from the channel and reports whether the user
+	// was a member. The channel is removed with its last member.
+	Leave(channel string, username string) (bool, error)
+
+	// Members returns the channel's members sorted by name, or an empty list
+	// if the channel does not exist
+	Members(channel string) ([]string, error)
+
+	// List returns every channel with its members, sorted by name
+	List() ([]types.Channel, error)
+}
+
+var (
+	// ErrInvalidChannel is returned for a channel name that is not allowed
+	ErrInvalidChannel = errors.New("invalid channel name")
+
+	// ErrNotChannelMember is returned when a user acts on a channel they have not joined
+	ErrNotChannelMember = errors.New("not a member of the channel")
+
+	// ErrChannelNotFound is returned for a channel without members
+	ErrChannelNotFound = errors.New("channel not found")
+)
+
+// namePattern matches a normalized channel or group name
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+
+// channelName normalizes a channel name such as "#Ops" to "ops"
+func channelName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+	if !namePattern.MatchString(name) {
+		return "", ErrInvalidChannel
+	}
+	return name, nil
+}
+
+// MemoryChannelStore is a ChannelStore kept in process memory
+type MemoryChannelStore struct {
+	mu       sync.Mutex
+	channels map[string]map[string]bool // Map of channel -> set of members
+}
+
+// NewMemoryChannelStore creates a new in-memory channel store
+func NewMemoryChannelStore() *MemoryChannelStore {
+	return &MemoryChannelStore{
+		channels: make(map[string]map[string]bool),
+	}
+}
+
+// Join adds username to the channel's member set
+func (m *MemoryChannelStore) Join(channel string, username string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	members, ok := m.channels[channel]
+	if !ok {
+		members = make(map[string]bool)
+		m.channels[channel] = members
+	}
+	if members[username] {
+		return false, nil
+	}
+	members[username] = true
+	return true, nil
+}
+
+// Leave removes username from the channel's member set
+func (m *MemoryChannelStore) Leave(channel string, username string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	members := m.channels[channel]
+	if !members[username] {
+		return false, nil
+	}
+	delete(members, username)
+	if len(members) == 0 {
+		delete(m.channels, channel)
+	}
+	return true, nil
+}
+
+// Members returns the channel's members
+func (m *MemoryChannelStore) Members(channel string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return sortedMembers(m.channels[channel]), nil
+}
+
+// List returns every channel with its members
+func (m *MemoryChannelStore) List() ([]types.Channel, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	channels := make([]types.Channel, 0, len(m.channels))
+	for name, members := range m.channels {
+		channels = append(channels, types.Channel{
+			Name:    name,
+			Members: sortedMembers(members),
+		})
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].Name < channels[j].Name
+	})
+	return channels, nil
+}
+
+// sortedMembers returns the usernames in a member set, sorted
+func sortedMembers(members map[string]bool) []string {
+	usernames := make([]string, 0, len(members))
+	for username := range members {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+// JoinChannel adds username to a channel, creating it if needed. The
+// channel's members get a channel_joined event.
+func (s *NotificationService) JoinChannel(channel string, username string) (string, error) {
+	channel, err := channelName(channel)
+	if err != nil {
+		return "", err
+	}
+
+	joined, err := s.channels.Join(channel, username)
+	if err != nil {
+		return "", err
+	}
+	if !joined {
+		return channel, nil
+	}
+
+	members, err := s.channels.Members(channel)
+	if err != nil {
+		return "", err
+	}
+	s.publish(types.EventTypeChannelJoined, types.ChannelMembershipPayload{
+		Channel:  channel,
+		Username: username,
+	}, members)
+
+	return channel, nil
+}
+
+// LeaveChannel removes username from a channel, or returns ErrNotChannelMember.
+// The remaining members and the user get a channel_left event.
+func (s *NotificationService) LeaveChannel(channel string, username string) (string, error) {
+	channel, err := channelName(channel)
+	if err != nil {
+		return "", err
+	}
+
+	left, err := s.channels.Leave(channel, username)
+	if err != nil {
+		return "", err
+	}
+	if !left {
+		return "", ErrNotChannelMember
+	}
+
+	members, err := s.channels.Members(channel)
+	if err != nil {
+		return "", err
+	}
+	s.publish(types.EventTypeChannelLeft, types.ChannelMembershipPayload{
+		Channel:  channel,
+		Username: username,
+	}, append(members, username))
+
+	return channel, nil
+}
+
+// ChannelMembers returns the members of a channel, or ErrChannelNotFound
+func (s *NotificationService) ChannelMembers(channel string) (string, []string, error) {
+	channel, err := channelName(channel)
+	if err != nil {
+		return "", nil, err
+	}
+
+	members, err := s.channels.Members(channel)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(members) == 0 {
+		return "", nil, ErrChannelNotFound
+	}
+	return channel, members, nil
+}
+
+// ListChannels returns every channel with its members
+func (s *NotificationService) ListChannels() ([]types.Channel, error) {
+	return s.channels.List()
+}
+
+// PublishToChannel sends a notification to every member of a channel. Only
+// members may publish; others get ErrNotChannelMember.
+func (s *NotificationService) PublishToChannel(channel string, req types.NotifyRequest) (types.Notification, []string, error) {
+	channel, err := channelName(channel)
+	if err != nil {
+		return types.Notification{}, nil, err
+	}
+
+	members, err := s.channels.Members(channel)
+	if err != nil {
+		return types.Notification{}, nil, err
+	}
+	if !slices.Contains(members, req.FromUsername) {
+		return types.Notification{}, nil, ErrNotChannelMember
+	}
+
+	notification := types.Notification{
+		Id:          uuid.New().String(),
+		From:        req.FromUsername,
+		Message:     req.Message,
+		Timestamp:   time.Now(),
+		ReadReceipt: req.ReadReceipt,
+		Channel:     channel,
+		Priority:    req.Priority,
+	}
+
+	// Record in the inbox first so offline members can catch up from history
+	if err := s.inbox.Add(members, notification); err != nil {
+		log.Printf("Error storing notification %s: %v", notification.Id, err)
+	}
+
+	s.publishPriority(types.EventTypeNotification, notification, members, req.Priority)
+	return notification, members, nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"sse-demo/types"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// channelsBucket holds one nested bucket per channel with username -> empty value
+var channelsBucket = []byte("channels")
+
+// BoltChannelStore is a ChannelStore persisted in a bbolt database
+type BoltChannelStore struct {
+	db *bolt.DB
+}
+
+// NewBoltChannelStore creates the channel bucket in db if needed
+func NewBoltChannelStore(db *bolt.DB) (*BoltChannelStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(channelsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create channel bucket: %w", err)
+	}
+	return &BoltChannelStore{db: db}, nil
+}
+
+// Join adds username to the channel's bucket
+func (b *BoltChannelStore) Join(channel string, username string) (bool, error) {
+	joined := false
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		members, err := tx.Bucket(channelsBucket).CreateBucketIfNotExists([]byte(channel))
+		if err != nil {
+			return err
+		}
+		if members.Get([]byte(username)) != nil {
+			return nil
+		}
+		joined = true
+		return members.Put([]byte(username), []byte{})
+	})
+	return joined, err
+}
+
+// Leave removes username from the channel's bucket, dropping the bucket once
+// it is empty
+func (b *BoltChannelStore) Leave(channel string, username string) (bool, error) {
+	left := false
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(channelsBucket)
+		members := root.Bucket([]byte(channel))
+		if members == nil || members.Get([]byte(username)) == nil {
+			return nil
+		}
+		left = true
+		if err := members.Delete([]byte(username)); err != nil {
+			return err
+		}
+		if k, _ := members.Cursor().First(); k == nil {
+			return root.DeleteBucket([]byte(channel))
+		}
+		return nil
+	})
+	return left, err
+}
+
+// Members reads the channel's bucket; keys are already sorted
+func (b *BoltChannelStore) Members(channel string) ([]string, error) {
+	usernames := []string{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		members := tx.Bucket(channelsBucket).Bucket([]byte(channel))
+		if members == nil {
+			return nil
+		}
+		return members.ForEach(func(k, _ []byte) error {
+			usernames = append(usernames, string(k))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usernames, nil
+}
+
+// List walks the channel buckets in name order
+func (b *BoltChannelStore) List() ([]types.Channel, error) {
+	channels := []types.Channel{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(channelsBucket)
+		return root.ForEachBucket(func(name []byte) error {
+			channel := types.Channel{Name: string(name), Members: []string{}}
+			err := root.Bucket(name).ForEach(func(k, _ []byte) error {
+				channel.Members = append(channel.Members, string(k))
+				return nil
+			})
+			channels = append(channels, channel)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
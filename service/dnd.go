@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sse-demo/types"
+	"sync"
+	"time"
+)
+
+// DoNotDisturbStore persists users' do-not-disturb settings. The service
+// loads them all at startup and checks them on every delivery from memory.
+type DoNotDisturbStore interface {
+	// Set stores a user's setting; a disabled one is removed
+	Set(username string, dnd types.DoNotDisturb) error
+
+	// List returns every enabled setting by username
+	List() (map[string]types.DoNotDisturb, error)
+}
+
+// MemoryDoNotDisturbStore is a DoNotDisturbStore kept in process memory
+type MemoryDoNotDisturbStore struct {
+	mu       sync.Mutex
+	settings map[string]types.DoNotDisturb // Map of username -> enabled setting
+}
+
+// NewMemoryDoNotDisturbStore creates a new in-memory do-not-disturb store
+func NewMemoryDoNotDisturbStore() *MemoryDoNotDisturbStore {
+	return &MemoryDoNotDisturbStore{
+		settings: make(map[string]types.DoNotDisturb),
+	}
+}
+
+// Set stores or removes the setting
+func (m *MemoryDoNotDisturbStore) Set(username string, dnd types.DoNotDisturb) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !dnd.Enabled {
+		delete(m.settings, username)
+		return nil
+	}
+	m.settings[username] = dnd
+	return nil
+}
+
+// List returns a copy of the settings
+func (m *MemoryDoNotDisturbStore) List() (map[string]types.DoNotDisturb, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	settings := make(map[string]types.DoNotDisturb, len(m.settings))
+	for username, dnd := range m.settings {
+		settings[username] = dnd
+	}
+	return settings, nil
+}
+
+// GetDoNotDisturb returns the user's do-not-disturb setting. An expired
+// setting reads as disabled.
+func (s *NotificationService) GetDoNotDisturb(username string) types.DoNotDisturb {
+	s.dndMu.RLock()
+	defer s.dndMu.RUnlock()
+
+	dnd := s.dnd[username]
+	if !dnd.Active(time.Now()) {
+		return types.DoNotDisturb{}
+	}
+	return dnd
+}
+
+// SetDoNotDisturb turns do-not-disturb on or off for username. While it is
+// on, only critical notifications are delivered live. The change goes out
+// through the broker so every instance stops delivering, not just this one.
+func (s *NotificationService) SetDoNotDisturb(username string, dnd types.DoNotDisturb) error {
+	if !dnd.Enabled {
+		dnd.Until = nil
+	}
+	if err := s.dndStore.Set(username, dnd); err != nil {
+		return err
+	}
+
+	change := DoNotDisturbChange{Username: username, Setting: dnd}
+	if err := s.broker.Publish(context.Background(), Message{DoNotDisturb: &change}); err != nil {
+		// Other instances miss the change, but this one still honours it
+		log.Printf("Error publishing do-not-disturb change for %s: %v", username, err)
+		s.applyDoNotDisturb(change)
+	}
+	return nil
+}
+
+// applyDoNotDisturb takes a setting changed on any instance into memory and
+// the local store, so it survives this instance restarting
+func (s *NotificationService) applyDoNotDisturb(change DoNotDisturbChange) {
+	if err := s.dndStore.Set(change.Username, change.Setting); err != nil {
+		log.Printf("Error storing do-not-disturb for %s: %v", change.Username, err)
+	}
+
+	s.dndMu.Lock()
+	defer s.dndMu.Unlock()
+
+	if change.Setting.Enabled {
+		s.dnd[change.Username] = change.Setting
+	} else {
+		delete(s.dnd, change.Username)
+	}
+}
+
+// restoreDoNotDisturb loads the persisted settings into memory
+func (s *NotificationService) restoreDoNotDisturb() error {
+	settings, err := s.dndStore.List()
+	if err != nil {
+		return err
+	}
+
+	s.dndMu.Lock()
+	s.dnd = settings
+	s.dndMu.Unlock()
+	return nil
+}
+
+// doNotDisturb reports whether username has do-not-disturb on right now
+func (s *NotificationService) doNotDisturb(username string) bool {
+	s.dndMu.RLock()
+	defer s.dndMu.RUnlock()
+
+	dnd, ok := s.dnd[username]
+	return ok && dnd.Active(time.Now())
+}
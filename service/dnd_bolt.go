@@ -0,0 +1,66 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sse-demo/types"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// dndBucket maps username -> JSON encoded types.DoNotDisturb
+var dndBucket = []byte("dnd")
+
+// BoltDoNotDisturbStore is a DoNotDisturbStore persisted in a bbolt database
+type BoltDoNotDisturbStore struct {
+	db *bolt.DB
+}
+
+// NewBoltDoNotDisturbStore creates the do-not-disturb bucket in db if needed
+func NewBoltDoNotDisturbStore(db *bolt.DB) (*BoltDoNotDisturbStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(dndBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create do-not-disturb bucket: %w", err)
+	}
+	return &BoltDoNotDisturbStore{db: db}, nil
+}
+
+// Set stores the setting under the username, or deletes the key if disabled
+func (b *BoltDoNotDisturbStore) Set(username string, dnd types.DoNotDisturb) error {
+	if !dnd.Enabled {
+		return b.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(dndBucket).Delete([]byte(username))
+		})
+	}
+
+	data, err := json.Marshal(dnd)
+	if err != nil {
+		return fmt.Errorf("failed to marshal do-not-disturb setting: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(dndBucket).Put([]byte(username), data)
+	})
+}
+
+// List decodes every stored setting
+func (b *BoltDoNotDisturbStore) List() (map[string]types.DoNotDisturb, error) {
+	settings := make(map[string]types.DoNotDisturb)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(dndBucket).ForEach(func(k, v []byte) error {
+			var dnd types.DoNotDisturb
+			if err := json.Unmarshal(v, &dnd); err != nil {
+				return fmt.Errorf("failed to decode do-not-disturb setting: %w", err)
+			}
+			settings[string(k)] = dnd
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
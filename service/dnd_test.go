@@ -0,0 +1,38 @@
+package service
+
+import (
+	"sse-demo/types"
+	"testing"
+)
+
+func TestDoNotDisturbReachesEveryInstance(t *testing.T) {
+	// Two services on one broker stand in for two server instances
+	broker := NewMemoryBroker()
+	instances := make([]*NotificationService, 2)
+	for i := range instances {
+		s, err := NewNotificationService(Options{Broker: broker})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		instances[i] = s
+	}
+
+	if err := instances[0].SetDoNotDisturb("bob", types.DoNotDisturb{Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range instances {
+		if !s.doNotDisturb("bob") {
+			t.Errorf("instance %d does not see do-not-disturb turned on", i)
+		}
+	}
+
+	if err := instances[1].SetDoNotDisturb("bob", types.DoNotDisturb{}); err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range instances {
+		if s.doNotDisturb("bob") {
+			t.Errorf("instance %d does not see do-not-disturb turned off", i)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"sse-demo/types"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GroupStore persists named groups of users that notifications and
+// acknowledgment requests can be addressed to
+type GroupStore interface {
+	// Create stores a new group, or returns ErrGroupExists
+	Create(group types.Group) error
+
+	// Get returns a group, or ErrGroupNotFound
+	Get(name string) (types.Group, error)
+
+	// List returns every group sorted by name
+	List() ([]types.Group, error)
+
+	// Update applies fn to a group and stores the result atomically. If fn
+	// returns an error the group is left unchanged.
+	Update(name string, fn func(group *types.Group) error) (types.Group, error)
+
+	// Delete removes a group, or returns ErrGroupNotFound
+	Delete(name string) error
+}
+
+var (
+	// ErrInvalidGroup is returned for a group name that is not allowed
+	ErrInvalidGroup = errors.New("invalid group name")
+
+	// ErrGroupExists is returned when creating a group whose name is taken
+	ErrGroupExists = errors.New("group already exists")
+
+	// ErrGroupNotFound is returned for an unknown group
+	ErrGroupNotFound = errors.New("group not found")
+
+	// ErrNotGroupOwner is returned when someone other than the creator changes a group
+	ErrNotGroupOwner = errors.New("only the group's creator may change it")
+
+	// ErrNotGroupMember is returned when removing a user who is not in the group
+	ErrNotGroupMember = errors.New("user is not a member of the group")
+
+	// ErrNoRecipients is returned when the targets of a message resolve to nobody
+	ErrNoRecipients = errors.New("no recipients")
+)
+
+// groupName normalizes a group name such as "OnCall" to "oncall"
+func groupName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !namePattern.MatchString(name) {
+		return "", ErrInvalidGroup
+	}
+	return name, nil
+}
+
+// MemoryGroupStore is a GroupStore kept in process memory
+type MemoryGroupStore struct {
+	mu     sync.Mutex
+	groups map[string]types.Group // Map of name -> group
+}
+
+// NewMemoryGroupStore creates a new in-memory group store
+func NewMemoryGroupStore() *MemoryGroupStore {
+	return &MemoryGroupStore{
+		groups: make(map[string]types.Group),
+	}
+}
+
+// Create stores the group unless its name is taken
+func (m *MemoryGroupStore) Create(group types.Group) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.groups[group.Name]; ok {
+		return ErrGroupExists
+	}
+	m.groups[group.Name] = cloneGroup(group)
+	return nil
+}
+
+// Get returns a copy of the group
+func (m *MemoryGroupStore) Get(name string) (types.Group, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	group, ok := m.groups[name]
+	if !ok {
+		return types.Group{}, ErrGroupNotFound
+	}
+	return cloneGroup(group), nil
+}
+
+// List returns copies of every group
+func (m *MemoryGroupStore) List() ([]types.Group, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	groups := make([]types.Group, 0, len(m.groups))
+	for _, group := range m.groups {
+		groups = append(groups, cloneGroup(group))
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups, nil
+}
+
+// Update applies fn to a copy of the group and stores it if fn succeeds
+func (m *MemoryGroupStore) Update(name string, fn func(group *types.Group) error) (types.Group, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	group, ok := m.groups[name]
+	if !ok {
+		return types.Group{}, ErrGroupNotFound
+	}
+
+	group = cloneGroup(group)
+	if err := fn(&group); err != nil {
+		return types.Group{}, err
+	}
+	m.groups[name] = group
+	return cloneGroup(group), nil
+}
+
+// Delete removes the group
+func (m *MemoryGroupStore) Delete(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.groups[name]; !ok {
+		return ErrGroupNotFound
+	}
+	delete(m.groups, name)
+	return nil
+}
+
+// cloneGroup copies a group so callers cannot modify the stored member list
+func cloneGroup(group types.Group) types.Group {
+	group.Members = slices.Clone(group.Members)
+	return group
+}
+
+// normalizeMembers trims, sorts and deduplicates usernames, dropping blanks
+func normalizeMembers(usernames []string) []string {
+	members := []string{}
+	for _, username := range usernames {
+		if username = strings.TrimSpace(username); username != "" {
+			members = append(members, username)
+		}
+	}
+	sort.Strings(members)
+	return slices.Compact(members)
+}
+
+// CreateGroup creates a group owned by its creator
+func (s *NotificationService) CreateGroup(group types.Group) (types.Group, error) {
+	name, err := groupName(group.Name)
+	if err != nil {
+		return types.Group{}, err
+	}
+
+	now := time.Now()
+	group.Name = name
+	group.Members = normalizeMembers(group.Members)
+	group.CreatedAt = now
+	group.UpdatedAt = now
+
+	if err := s.groups.Create(group); err != nil {
+		return types.Group{}, err
+	}
+	return group, nil
+}
+
+// GetGroup returns a group, or ErrGroupNotFound
+func (s *NotificationService) GetGroup(name string) (types.Group, error) {
+	name, err := groupName(name)
+	if err != nil {
+		return types.Group{}, ErrGroupNotFound
+	}
+	return s.groups.Get(name)
+}
+
+// ListGroups returns every group
+func (s *NotificationService) ListGroups() ([]types.Group, error) {
+	return s.groups.List()
+}
+
+// UpdateGroup replaces a group's description and/or members. Only the
+// group's creator may change it.
+func (s *NotificationService) UpdateGroup(name string, username string, description *string, members *[]string) (types.Group, error) {
+	return s.updateGroup(name, username, func(group *types.Group) error {
+		if description != nil {
+			group.Description = *description
+		}
+		if members != nil {
+			group.Members = normalizeMembers(*members)
+		}
+		return nil
+	})
+}
+
+// AddGroupMembers adds users to a group. Only the group's creator may change it.
+func (s *NotificationService) AddGroupMembers(name string, username string, members []string) (types.Group, error) {
+	return s.updateGroup(name, username, func(group *types.Group) error {
+		group.Members = normalizeMembers(append(group.Members, members...))
+		return nil
+	})
+}
+
+// RemoveGroupMember removes a user from a group, or returns ErrNotGroupMember.
+// Only the group's creator may change it.
+func (s *NotificationService) RemoveGroupMember(name string, username string, member string) (types.Group, error) {
+	return s.updateGroup(name, username, func(group *types.Group) error {
+		i := slices.Index(group.Members, member)
+		if i < 0 {
+			return ErrNotGroupMember
+		}
+		group.Members = slices.Delete(group.Members, i, i+1)
+		return nil
+	})
+}
+
+// DeleteGroup removes a group. Only the group's creator may delete it.
+func (s *NotificationService) DeleteGroup(name string, username string) error {
+	group, err := s.GetGroup(name)
+	if err != nil {
+		return err
+	}
+	if group.CreatedBy != username {
+		return ErrNotGroupOwner
+	}
+	return s.groups.Delete(group.Name)
+}
+
+// updateGroup applies fn to a group after checking that username created it
+func (s *NotificationService) updateGroup(name string, username string, fn func(group *types.Group) error) (types.Group, error) {
+	name, err := groupName(name)
+	if err != nil {
+		return types.Group{}, ErrGroupNotFound
+	}
+
+	return s.groups.Update(name, func(group *types.Group) error {
+		if group.CreatedBy != username {
+			return ErrNotGroupOwner
+		}
+		if err := fn(group); err != nil {
+			return err
+		}
+		group.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+// ResolveRecipients expands groups into their current members and merges them
+// with usernames, without duplicates. It returns ErrGroupNotFound for an
+// unknown group and ErrNoRecipients if nobody is left.
+func (s *NotificationService) ResolveRecipients(usernames []string, groups []string) ([]string, error) {
+	recipients := []string{}
+	seen := make(map[string]bool)
+	add := func(username string) {
+		if username != "" && !seen[username] {
+			seen[username] = true
+			recipients = append(recipients, username)
+		}
+	}
+
+	for _, username := range usernames {
+		add(username)
+	}
+	for _, name := range groups {
+		group, err := s.GetGroup(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range group.Members {
+			add(member)
+		}
+	}
+
+	if len(recipients) == 0 {
+		return nil, ErrNoRecipients
+	}
+	return recipients, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sse-demo/types"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// groupsBucket maps group name -> JSON encoded types.Group
+var groupsBucket = []byte("groups")
+
+// BoltGroupStore is a GroupStore persisted in a bbolt database
+type BoltGroupStore struct {
+	db *bolt.DB
+}
+
+// NewBoltGroupStore creates the group bucket in db if needed
+func NewBoltGroupStore(db *bolt.DB) (*BoltGroupStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(groupsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group bucket: %w", err)
+	}
+	return &BoltGroupStore{db: db}, nil
+}
+
+// Create stores the group unless its name is taken
+func (b *BoltGroupStore) Create(group types.Group) error {
+	data, err := json.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("failed to marshal group: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(groupsBucket)
+		if bucket.Get([]byte(group.Name)) != nil {
+			return ErrGroupExists
+		}
+		return bucket.Put([]byte(group.Name), data)
+	})
+}
+
+// Get decodes the stored group
+func (b *BoltGroupStore) Get(name string) (types.Group, error) {
+	var group types.Group
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(groupsBucket).Get([]byte(name))
+		if data == nil {
+			return ErrGroupNotFound
+		}
+		return decodeGroup(data, &group)
+	})
+	return group, err
+}
+
+// List decodes every group in key (name) order
+func (b *BoltGroupStore) List() ([]types.Group, error) {
+	groups := []types.Group{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(groupsBucket).ForEach(func(_, v []byte) error {
+			var group types.Group
+			if err := decodeGroup(v, &group); err != nil {
+				return err
+			}
+			groups = append(groups, group)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// Update applies fn to the stored group within a single transaction
+func (b *BoltGroupStore) Update(name string, fn func(group *types.Group) error) (types.Group, error) {
+	var group types.Group
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(groupsBucket)
+		data := bucket.Get([]byte(name))
+		if data == nil {
+			return ErrGroupNotFound
+		}
+		if err := decodeGroup(data, &group); err != nil {
+			return err
+		}
+		if err := fn(&group); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(group)
+		if err != nil {
+			return fmt.Errorf("failed to marshal group: %w", err)
+		}
+		return bucket.Put([]byte(name), data)
+	})
+	if err != nil {
+		return types.Group{}, err
+	}
+	return group, nil
+}
+
+// Delete removes the group's key
+func (b *BoltGroupStore) Delete(name string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(groupsBucket)
+		if bucket.Get([]byte(name)) == nil {
+			return ErrGroupNotFound
+		}
+		return bucket.Delete([]byte(name))
+	})
+}
+
+// decodeGroup unmarshals a stored group
+func decodeGroup(data []byte, group *types.Group) error {
+	if err := json.Unmarshal(data, group); err != nil {
+		return fmt.Errorf("failed to decode group: %w", err)
+	}
+	return nil
+}
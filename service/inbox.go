@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"sse-demo/types"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// InboxStore persists the notifications sent to each user so they survive
+// disconnects and can be listed as history, along with their read state.
+//
+// Pages are addressed by opaque cursors: List returns the notifications older
+// than the cursor, newest first, and the cursor for the following page.
+type InboxStore interface {
+	// Register creates an inbox for username if it does not exist yet, so
+	// broadcasts reach the user while offline
+	Register(username string) error
+
+	// Add records an unread notification in the given users' inboxes. An
+	// empty list means every registered user.
+	Add(usernames []string, notification types.Notification) error
+
+	// List returns up to limit notifications older than cursor (or the newest
+	// ones if cursor is empty) and the cursor for the next page, which is
+	// empty on the last page
+	List(username string, cursor string, limit int) ([]types.InboxItem, string, error)
+
+	// MarkRead marks one notification as read. It returns the item and whether
+	// it was unread before, or ErrNotificationNotFound.
+	MarkRead(username string, id string, at time.Time) (types.InboxItem, bool, error)
+
+	// MarkAllRead marks every unread notification as read and returns the
+	// items that changed
+	MarkAllRead(username string, at time.Time) ([]types.InboxItem, error)
+
+	// UnreadCount returns the number of unread notifications
+	UnreadCount(username string) (int, error)
+}
+
+var (
+	// ErrInvalidCursor is returned by InboxStore.List for a malformed cursor
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrNotificationNotFound is returned when a notification is not in the user's inbox
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// MemoryInboxStore is an InboxStore kept in process memory
+type MemoryInboxStore struct {
+	mu      sync.Mutex
+	inboxes map[string][]types.InboxItem // Map of username -> notifications, oldest first
+}
+
+// NewMemoryInboxStore creates a new in-memory inbox store
+func NewMemoryInboxStore() *MemoryInboxStore {
+	return &MemoryInboxStore{
+		inboxes: make(map[string][]types.InboxItem),
+	}
+}
+
+// Register creates an empty inbox for username
+func (m *MemoryInboxStore) Register(username string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.inboxes[username]; !ok {
+		m.inboxes[username] = []types.InboxItem{}
+	}
+	return nil
+}
+
+// Add appends the notification to each user's inbox
+func (m *MemoryInboxStore) Add(usernames []string, notification types.Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(usernames) == 0 {
+		for username := range m.inboxes {
+			usernames = append(usernames, username)
+		}
+		sort.Strings(usernames)
+	}
+
+	for _, username := range usernames {
+		m.inboxes[username] = append(m.inboxes[username], types.InboxItem{Notification: notification})
+	}
+	return nil
+}
+
+// List pages backwards through the user's inbox. The cursor is the position
+// of the oldest notification returned so far.
+func (m *MemoryInboxStore) List(username string, cursor string, limit int) ([]types.InboxItem, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inbox := m.inboxes[username]
+
+	end := len(inbox)
+	if cursor != "" {
+		pos, err := strconv.Atoi(cursor)
+		if err != nil || pos < 0 || pos > len(inbox) {
+			return nil, "", ErrInvalidCursor
+		}
+		end = pos
+	}
+
+	start := end - limit
+	if start < 0 {
+		start = 0
+	}
+
+	page := make([]types.InboxItem, 0, end-start)
+	for i := end - 1; i >= start; i-- {
+		page = append(page, inbox[i])
+	}
+
+	next := ""
+	if start > 0 {
+		next = strconv.Itoa(start)
+	}
+	return page, next, nil
+}
+
+// MarkRead marks the notification with the given ID as read
+func (m *MemoryInboxStore) MarkRead(username string, id string, at time.Time) (types.InboxItem, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inbox := m.inboxes[username]
+	for i := range inbox {
+		if inbox[i].Id != id {
+			continue
+		}
+		if inbox[i].Read {
+			return inbox[i], false, nil
+		}
+		markRead(&inbox[i], at)
+		return inbox[i], true, nil
+	}
+	return types.InboxItem{}, false, ErrNotificationNotFound
+}
+
+// MarkAllRead marks every unread notification in the inbox as read
+func (m *MemoryInboxStore) MarkAllRead(username string, at time.Time) ([]types.InboxItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	changed := []types.InboxItem{}
+	inbox := m.inboxes[username]
+	for i := range inbox {
+		if !inbox[i].Read {
+			markRead(&inbox[i], at)
+			changed = append(changed, inbox[i])
+		}
+	}
+	return changed, nil
+}
+
+// UnreadCount counts the unread notifications in the inbox
+func (m *MemoryInboxStore) UnreadCount(username string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, item := range m.inboxes[username] {
+		if !item.Read {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// markRead sets the read state of an inbox item
+func markRead(item *types.InboxItem, at time.Time) {
+	item.Read = true
+	item.ReadAt = &at
+}
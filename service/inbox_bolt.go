@@ -0,0 +1,286 @@
+package service
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sse-demo/types"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// inboxBucket holds one nested bucket per user with sequence -> item
+	inboxBucket = []byte("inbox")
+
+	// inboxIndexBucket holds one nested bucket per user with notification ID -> sequence
+	inboxIndexBucket = []byte("inbox_index")
+
+	// inboxUnreadBucket maps username -> unread count
+	inboxUnreadBucket = []byte("inbox_unread")
+)
+
+// BoltInboxStore is an InboxStore persisted in a bbolt database. Each user's
+// inbox is a nested bucket keyed by a big-endian sequence number, so keys sort
+// in delivery order.
+type BoltInboxStore struct {
+	db *bolt.DB
+}
+
+// NewBoltInboxStore creates the inbox buckets in db if needed
+func NewBoltInboxStore(db *bolt.DB) (*BoltInboxStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{inboxBucket, inboxIndexBucket, inboxUnreadBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inbox buckets: %w", err)
+	}
+	return &BoltInboxStore{db: db}, nil
+}
+
+// Register creates an empty inbox bucket for username
+func (b *BoltInboxStore) Register(username string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.Bucket(inboxBucket).CreateBucketIfNotExists([]byte(username))
+		return err
+	})
+}
+
+// Add appends the notification to each user's inbox bucket
+func (b *BoltInboxStore) Add(usernames []string, notification types.Notification) error {
+	data, err := json.Marshal(types.InboxItem{Notification: notification})
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(inboxBucket)
+		indexRoot := tx.Bucket(inboxIndexBucket)
+
+		if len(usernames) == 0 {
+			err := root.ForEachBucket(func(name []byte) error {
+				usernames = append(usernames, string(name))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, username := range usernames {
+			inbox, err := root.CreateBucketIfNotExists([]byte(username))
+			if err != nil {
+				return err
+			}
+			seq, err := inbox.NextSequence()
+			if err != nil {
+				return err
+			}
+			if err := inbox.Put(seqKey(seq), data); err != nil {
+				return err
+			}
+
+			index, err := indexRoot.CreateBucketIfNotExists([]byte(username))
+			if err != nil {
+				return err
+			}
+			if err := index.Put([]byte(notification.Id), seqKey(seq)); err != nil {
+				return err
+			}
+
+			if err := addUnread(tx, username, 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// List pages backwards through the user's inbox bucket. The cursor is the
+// sequence number of the oldest notification returned so far.
+func (b *BoltInboxStore) List(username string, cursor string, limit int) ([]types.InboxItem, string, error) {
+	var before uint64
+	if cursor != "" {
+		seq, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+		before = seq
+	}
+
+	page := []types.InboxItem{}
+	next := ""
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		inbox := tx.Bucket(inboxBucket).Bucket([]byte(username))
+		if inbox == nil {
+			return nil
+		}
+
+		c := inbox.Cursor()
+		var k, v []byte
+		if before == 0 {
+			k, v = c.Last()
+		} else {
+			// Seek lands on the cursor's own key (or the next one); step back past it
+			if sk, _ := c.Seek(seqKey(before)); sk == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		}
+
+		for ; k != nil && len(page) < limit; k, v = c.Prev() {
+			var item types.InboxItem
+			if err := json.Unmarshal(v, &item); err != nil {
+				return fmt.Errorf("failed to decode notification: %w", err)
+			}
+			page = append(page, item)
+			next = strconv.FormatUint(binary.BigEndian.Uint64(k), 10)
+		}
+
+		// No more pages once the oldest entry has been returned
+		if k == nil {
+			next = ""
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return page, next, nil
+}
+
+// MarkRead looks the notification up through the ID index and marks it read
+func (b *BoltInboxStore) MarkRead(username string, id string, at time.Time) (types.InboxItem, bool, error) {
+	var item types.InboxItem
+	changed := false
+
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		index := tx.Bucket(inboxIndexBucket).Bucket([]byte(username))
+		inbox := tx.Bucket(inboxBucket).Bucket([]byte(username))
+		if index == nil || inbox == nil {
+			return ErrNotificationNotFound
+		}
+
+		key := index.Get([]byte(id))
+		if key == nil {
+			return ErrNotificationNotFound
+		}
+
+		if err := json.Unmarshal(inbox.Get(key), &item); err != nil {
+			return fmt.Errorf("failed to decode notification: %w", err)
+		}
+		if item.Read {
+			return nil
+		}
+
+		markRead(&item, at)
+		changed = true
+
+		data, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("failed to marshal notification: %w", err)
+		}
+		if err := inbox.Put(key, data); err != nil {
+			return err
+		}
+		return addUnread(tx, username, -1)
+	})
+	if err != nil {
+		return types.InboxItem{}, false, err
+	}
+
+	return item, changed, nil
+}
+
+// MarkAllRead scans the user's inbox and marks every unread item read
+func (b *BoltInboxStore) MarkAllRead(username string, at time.Time) ([]types.InboxItem, error) {
+	changed := []types.InboxItem{}
+
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		inbox := tx.Bucket(inboxBucket).Bucket([]byte(username))
+		if inbox == nil {
+			return nil
+		}
+
+		updates := make(map[string][]byte)
+		err := inbox.ForEach(func(k, v []byte) error {
+			var item types.InboxItem
+			if err := json.Unmarshal(v, &item); err != nil {
+				return fmt.Errorf("failed to decode notification: %w", err)
+			}
+			if item.Read {
+				return nil
+			}
+
+			markRead(&item, at)
+			data, err := json.Marshal(item)
+			if err != nil {
+				return fmt.Errorf("failed to marshal notification: %w", err)
+			}
+			updates[string(k)] = data
+			changed = append(changed, item)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Buckets must not be modified while iterating with ForEach
+		for k, data := range updates {
+			if err := inbox.Put([]byte(k), data); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(inboxUnreadBucket).Delete([]byte(username))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+// UnreadCount reads the user's stored unread counter
+func (b *BoltInboxStore) UnreadCount(username string) (int, error) {
+	count := 0
+	err := b.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(inboxUnreadBucket).Get([]byte(username)); v != nil {
+			count = int(binary.BigEndian.Uint64(v))
+		}
+		return nil
+	})
+	return count, err
+}
+
+// addUnread adjusts the user's unread counter by delta within tx
+func addUnread(tx *bolt.Tx, username string, delta int) error {
+	bucket := tx.Bucket(inboxUnreadBucket)
+
+	count := 0
+	if v := bucket.Get([]byte(username)); v != nil {
+		count = int(binary.BigEndian.Uint64(v))
+	}
+
+	count += delta
+	if count <= 0 {
+		return bucket.Delete([]byte(username))
+	}
+	return bucket.Put([]byte(username), binary.BigEndian.AppendUint64(nil, uint64(count)))
+}
+
+// seqKey encodes a sequence number as a sortable bucket key
+func seqKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sse-demo/types"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// newTestBolt opens a bbolt database in a temporary directory
+func newTestBolt(t *testing.T) *bolt.DB {
+	t.Helper()
+
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newTestInboxStores creates each kind of inbox store
+func newTestInboxStores(t *testing.T) map[string]InboxStore {
+	t.Helper()
+
+	bolted, err := NewBoltInboxStore(newTestBolt(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]InboxStore{"memory": NewMemoryInboxStore(), "bolt": bolted}
+}
+
+// addNotifications sends n notifications with IDs n1..nN to username
+func addNotifications(t *testing.T, store InboxStore, username string, n int) {
+	t.Helper()
+
+	for i := 1; i <= n; i++ {
+		notification := types.Notification{Id: fmt.Sprintf("n%d", i), Message: "hello", Timestamp: time.Now()}
+		if err := store.Add([]string{username}, notification); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestInboxListPages(t *testing.T) {
+	// Each case pages through an inbox of n notifications until the cursor
+	// comes back empty
+	tests := []struct {
+		name  string
+		n     int
+		limit int
+		pages [][]string
+	}{
+		{"empty inbox", 0, 2, [][]string{{}}},
+		{"short last page", 5, 2, [][]string{{"n5", "n4"}, {"n3", "n2"}, {"n1"}}},
+		{"full last page", 4, 2, [][]string{{"n4", "n3"}, {"n2", "n1"}}},
+		{"single page", 3, 10, [][]string{{"n3", "n2", "n1"}}},
+	}
+	for _, tt := range tests {
+		for kind, store := range newTestInboxStores(t) {
+			t.Run(tt.name+"/"+kind, func(t *testing.T) {
+				addNotifications(t, store, "alice", tt.n)
+
+				var pages [][]string
+				cursor := ""
+				for {
+					items, next, err := store.List("alice", cursor, tt.limit)
+					if err != nil {
+						t.Fatal(err)
+					}
+					ids := []string{}
+					for _, item := range items {
+						ids = append(ids, item.Id)
+					}
+					pages = append(pages, ids)
+
+					if next == "" {
+						break
+					}
+					if len(pages) > len(tt.pages) {
+						t.Fatalf("got more than %d pages: %v", len(tt.pages), pages)
+					}
+					cursor = next
+				}
+
+				if !reflect.DeepEqual(pages, tt.pages) {
+					t.Errorf("pages = %v, want %v", pages, tt.pages)
+				}
+			})
+		}
+	}
+}
+
+func TestInboxListRejectsInvalidCursor(t *testing.T) {
+	for kind, store := range newTestInboxStores(t) {
+		addNotifications(t, store, "alice", 1)
+		if _, _, err := store.List("alice", "not-a-cursor", 10); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: List with a malformed cursor returned %v, want ErrInvalidCursor", kind, err)
+		}
+	}
+}
+
+func TestInboxUnreadCount(t *testing.T) {
+	for kind, store := range newTestInboxStores(t) {
+		t.Run(kind, func(t *testing.T) {
+			for _, username := range []string{"alice", "bob"} {
+				if err := store.Register(username); err != nil {
+					t.Fatal(err)
+				}
+			}
+			addNotifications(t, store, "alice", 3)
+
+			// Each step changes the inbox and checks both users' counters
+			steps := []struct {
+				name    string
+				run     func() (int, error) // Returns how many items changed
+				changed int
+				alice   int
+				bob     int
+			}{
+				{"after direct sends", nil, 0, 3, 0},
+				{"mark one read", func() (int, error) {
+					_, changed, err := store.MarkRead("alice", "n2", time.Now())
+					return boolCount(changed), err
+				}, 1, 2, 0},
+				{"mark the same one read again", func() (int, error) {
+					_, changed, err := store.MarkRead("alice", "n2", time.Now())
+					return boolCount(changed), err
+				}, 0, 2, 0},
+				{"broadcast", func() (int, error) {
+					return 0, store.Add(nil, types.Notification{Id: "b1", Message: "everyone"})
+				}, 0, 3, 1},
+				{"mark all read", func() (int, error) {
+					items, err := store.MarkAllRead("alice", time.Now())
+					return len(items), err
+				}, 3, 0, 1},
+				{"mark all read again", func() (int, error) {
+					items, err := store.MarkAllRead("alice", time.Now())
+					return len(items), err
+				}, 0, 0, 1},
+			}
+			for _, step := range steps {
+				if step.run != nil {
+					changed, err := step.run()
+					if err != nil {
+						t.Fatalf("%s: %v", step.name, err)
+					}
+					if changed != step.changed {
+						t.Errorf("%s: %d items changed, want %d", step.name, changed, step.changed)
+					}
+				}
+				for username, want := range map[string]int{"alice": step.alice, "bob": step.bob} {
+					got, err := store.UnreadCount(username)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if got != want {
+						t.Errorf("%s: %s has %d unread, want %d", step.name, username, got, want)
+					}
+				}
+			}
+
+			if _, _, err := store.MarkRead("alice", "missing", time.Now()); !errors.Is(err, ErrNotificationNotFound) {
+				t.Errorf("MarkRead of an unknown notification returned %v, want ErrNotificationNotFound", err)
+			}
+		})
+	}
+}
+
+// boolCount is 1 for true and 0 for false
+func boolCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
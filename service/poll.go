@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// defaultPollGrace is how long a long-polling user stays present after a
+// poll returns. Clients poll again right away, so a lapse means they are gone.
+const defaultPollGrace = 30 * time.Second
+
+// pollLease is the presence a long-polling user holds between polls
+type pollLease struct {
+	active int         // Polls in progress
+	timer  *time.Timer // Releases the lease once no poll has run for the grace period; nil until the first poll ends
+}
+
+// PollEvents waits up to timeout for events for a long-polling client. With
+// after set, events buffered since that ID are returned immediately; without
+// it only new events are waited for. It also returns the ID to pass as after
+// on the next poll.
+func (s *NotificationService) PollEvents(ctx context.Context, username string, after *uint64, timeout time.Duration) ([]Event, uint64) {
+	s.acquirePollLease(username)
+	defer s.releasePollLease(username)
+
+	// The poll reads from the same per-user stream as an SSE connection
+	client, missed, lastID := s.attach(username, after)
+	defer s.detach(client)
+
+	if len(missed) > 0 {
+		return missed, missed[len(missed)-1].ID
+	}
+	if after != nil && *after > lastID {
+		// The stream restarted (e.g. the server did); start over from its head
+		after = nil
+	}
+	if after == nil {
+		after = &lastID
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-client.Events.Ready():
+		// Collect everything queued into one batch, most urgent first. The
+		// next poll continues after the highest ID, wherever it sits.
+		events := []Event{}
+		next := *after
+		for event, ok := client.Events.Pop(); ok; event, ok = client.Events.Pop() {
+			events = append(events, event)
+			next = max(next, event.ID)
+		}
+		return events, next
+	case <-client.Closed:
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return []Event{}, *after
+}
+
+// acquirePollLease marks a poll as running, joining presence on the user's
+// first poll
+func (s *NotificationService) acquirePollLease(username string) {
+	s.pollMu.Lock()
+	lease, ok := s.pollLeases[username]
+	if ok {
+		lease.active++
+		if lease.timer != nil {
+			lease.timer.Stop()
+		}
+		s.pollMu.Unlock()
+		return
+	}
+	s.pollLeases[username] = &pollLease{active: 1}
+	s.pollMu.Unlock()
+
+	s.join(username)
+}
+
+// releasePollLease marks a poll as finished and keeps the user present for
+// the grace period in case no further poll follows
+func (s *NotificationService) releasePollLease(username string) {
+	s.pollMu.Lock()
+	defer s.pollMu.Unlock()
+
+	lease, ok := s.pollLeases[username]
+	if !ok {
+		return
+	}
+	lease.active--
+	if lease.active > 0 {
+		return
+	}
+	if lease.timer == nil {
+		lease.timer = time.AfterFunc(s.pollGrace, func() {
+			s.expirePollLease(username)
+		})
+	} else {
+		lease.timer.Reset(s.pollGrace)
+	}
+}
+
+// expirePollLease drops the presence of a user who stopped polling
+func (s *NotificationService) expirePollLease(username string) {
+	s.pollMu.Lock()
+	lease, ok := s.pollLeases[username]
+	if !ok || lease.active > 0 {
+		s.pollMu.Unlock()
+		return
+	}
+	delete(s.pollLeases, username)
+	s.pollMu.Unlock()
+
+	s.leave(username)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPollLeaseExpires(t *testing.T) {
+	const grace = 20 * time.Millisecond
+
+	tests := []struct {
+		name    string
+		repoll  bool // Whether a second poll is still waiting once the grace period has passed
+		present bool
+	}{
+		{"no further poll", false, false},
+		{"next poll waiting", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewNotificationService(Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			s.pollGrace = grace
+
+			present := func() bool {
+				users, err := s.GetConnectedUsers()
+				if err != nil {
+					t.Fatal(err)
+				}
+				return slices.Contains(users, "alice")
+			}
+
+			s.PollEvents(context.Background(), "alice", nil, time.Millisecond)
+			if !present() {
+				t.Fatal("user is not present right after a poll")
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			if tt.repoll {
+				go func() {
+					defer close(done)
+					s.PollEvents(ctx, "alice", nil, time.Minute)
+				}()
+			} else {
+				close(done)
+			}
+
+			time.Sleep(5 * grace)
+			if got := present(); got != tt.present {
+				t.Errorf("present after the grace period = %v, want %v", got, tt.present)
+			}
+
+			// Once the last poll ends the lease lapses after the grace period
+			cancel()
+			<-done
+			deadline := time.Now().Add(2 * time.Second)
+			for present() {
+				if time.Now().After(deadline) {
+					t.Fatal("lease did not expire after the last poll")
+				}
+				time.Sleep(time.Millisecond)
+			}
+		})
+	}
+}
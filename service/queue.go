@@ -0,0 +1,205 @@
+package service
+
+import "sync"
+
+// EventQueue is a connection's bounded buffer of events waiting to be
+// written. Higher-priority events come out first, and events of equal
+// priority in arrival order, so an urgent event skips a backlog.
+//
+// Readers wait on Ready and then call Pop until it reports the queue empty.
+type EventQueue struct {
+	mu     sync.Mutex
+	events queuedEvents
+	seq    uint64        // Arrival counter that keeps equal priorities in order
+	taken  uint64        // Highest event ID that has left the queue
+	size   int           // Capacity; Push fails beyond it
+	ready  chan struct{} // Holds a token after a Push; closed by Close
+	closed bool
+}
+
+// queuedEvent is an event with its position in arrival order
+type queuedEvent struct {
+	Event
+	seq uint64
+}
+
+// newEventQueue creates a queue holding at most size events
+func newEventQueue(size int) *EventQueue {
+	return &EventQueue{
+		size:  size,
+		ready: make(chan struct{}, 1),
+	}
+}
+
+// Ready receives a value when events may be waiting, and is closed once the
+// service has closed the queue
+func (q *EventQueue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// Pop removes and returns the most urgent event, or false if the queue is empty
+func (q *EventQueue) Pop() (Event, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.events) == 0 {
+		return Event{}, false
+	}
+	event := q.events.remove(0).Event
+	q.taken = max(q.taken, event.ID)
+	return event, true
+}
+
+// ResumeID returns the highest event ID below which no event is still
+// queued: the Last-Event-ID a client can reconnect with after receiving the
+// events popped so far without missing any. An urgent event leaves ahead of
+// earlier ones, so this trails the IDs popped while they wait.
+func (q *EventQueue) ResumeID() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	resume := q.taken
+	for _, e := range q.events {
+		if e.ID != 0 && e.ID <= resume {
+			resume = e.ID - 1
+		}
+	}
+	return resume
+}
+
+// Len returns the number of queued events
+func (q *EventQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.events)
+}
+
+// push adds event unless the queue is full or closed
+func (q *EventQueue) push(event Event) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || len(q.events) >= q.size {
+		return false
+	}
+
+	q.seq++
+	q.events.push(queuedEvent{Event: event, seq: q.seq})
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// evict removes the least urgent event whose priority ranks at most maxRank,
+// taking the oldest or the newest among equals. It returns false if every
+// queued event is more urgent.
+func (q *EventQueue) evict(maxRank int, oldest bool) (Event, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	victim := -1
+	for i, e := range q.events {
+		rank := e.Priority.Rank()
+		if rank > maxRank {
+			continue
+		}
+		if victim < 0 {
+			victim = i
+			continue
+		}
+		v := q.events[victim]
+		switch vRank := v.Priority.Rank(); {
+		case rank < vRank:
+			victim = i
+		case rank == vRank && oldest == (e.seq < v.seq):
+			victim = i
+		}
+	}
+	if victim < 0 {
+		return Event{}, false
+	}
+	event := q.events.remove(victim).Event
+	q.taken = max(q.taken, event.ID)
+	return event, true
+}
+
+// close discards the queued events and closes Ready
+func (q *EventQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.events = nil
+	close(q.ready)
+}
+
+// queuedEvents is a binary heap with the most urgent, earliest event on top.
+// It is maintained by hand rather than through container/heap so pushes do
+// not allocate.
+type queuedEvents []queuedEvent
+
+// before reports whether the event at i goes out before the one at j
+func (h queuedEvents) before(i, j int) bool {
+	ri, rj := h[i].Priority.Rank(), h[j].Priority.Rank()
+	if ri != rj {
+		return ri > rj
+	}
+	return h[i].seq < h[j].seq
+}
+
+// push adds e and restores the heap order
+func (h *queuedEvents) push(e queuedEvent) {
+	*h = append(*h, e)
+	h.up(len(*h) - 1)
+}
+
+// remove takes out the event at i and restores the heap order
+func (h *queuedEvents) remove(i int) queuedEvent {
+	old := *h
+	n := len(old) - 1
+	e := old[i]
+	old[i] = old[n]
+	old[n] = queuedEvent{} // Let the event's strings be collected
+	*h = old[:n]
+	if i < n {
+		h.down(i)
+		h.up(i)
+	}
+	return e
+}
+
+// up moves the event at i towards the top until its parent goes out first
+func (h queuedEvents) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.before(i, parent) {
+			return
+		}
+		h[i], h[parent] = h[parent], h[i]
+		i = parent
+	}
+}
+
+// down moves the event at i towards the bottom until it goes out before its children
+func (h queuedEvents) down(i int) {
+	for {
+		first := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(h) && h.before(child, first) {
+				first = child
+			}
+		}
+		if first == i {
+			return
+		}
+		h[i], h[first] = h[first], h[i]
+		i = first
+	}
+}
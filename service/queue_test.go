@@ -0,0 +1,53 @@
+package service
+
+import (
+	"sse-demo/types"
+	"testing"
+)
+
+func TestResumeAfterReorderedEventsReplaysOvertakenOnes(t *testing.T) {
+	s, err := NewNotificationService(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	client, _ := s.AddClient("bob", nil)
+	defer s.RemoveClient(client)
+	for _, ok := client.Events.Pop(); ok; _, ok = client.Events.Pop() {
+	}
+	base := client.Events.ResumeID()
+
+	s.publish(types.EventTypeNotification, "first", []string{"bob"})
+	s.publish(types.EventTypeNotification, "second", []string{"bob"})
+	s.publishPriority(types.EventTypeNotification, "urgent", []string{"bob"}, types.PriorityCritical)
+
+	urgent, ok := client.Events.Pop()
+	if !ok || urgent.Priority != types.PriorityCritical {
+		t.Fatalf("first popped event = %+v, want the critical one", urgent)
+	}
+	resume := client.Events.ResumeID()
+	if resume != base {
+		t.Fatalf("ResumeID after the overtaking event = %d, want %d", resume, base)
+	}
+
+	// The connection drops here; reconnecting from the resume point must
+	// replay the two events the critical one overtook
+	reconnected, missed := s.AddClient("bob", &resume)
+	defer s.RemoveClient(reconnected)
+	var replayed []string
+	for _, event := range missed {
+		if event.Type == types.EventTypeNotification && event.Priority != types.PriorityCritical {
+			replayed = append(replayed, event.Payload)
+		}
+	}
+	if len(replayed) != 2 || replayed[0] != `"first"` || replayed[1] != `"second"` {
+		t.Fatalf("replayed %v after resuming from %d, want both overtaken events", replayed, resume)
+	}
+
+	for _, ok := client.Events.Pop(); ok; _, ok = client.Events.Pop() {
+	}
+	if got := client.Events.ResumeID(); got != urgent.ID {
+		t.Errorf("ResumeID after draining = %d, want %d", got, urgent.ID)
+	}
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"sse-demo/types"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+)
+
+// maxRecurringRuns is the number of executions kept in a recurring schedule's history
+const maxRecurringRuns = 50
+
+// RecurringStore persists recurring schedules and their execution history
+type RecurringStore interface {
+	// Create stores a new schedule
+	Create(schedule types.RecurringSchedule) error
+
+	// Get returns a schedule, or ErrRecurringNotFound
+	Get(id string) (types.RecurringSchedule, error)
+
+	// List returns every schedule, oldest first
+	List() ([]types.RecurringSchedule, error)
+
+	// Update applies fn to a schedule and stores the result unless fn fails
+	Update(id string, fn func(schedule *types.RecurringSchedule) error) (types.RecurringSchedule, error)
+
+	// Delete removes a schedule and its history
+	Delete(id string) error
+
+	// AddRun appends to a schedule's history, keeping the latest maxRecurringRuns
+	AddRun(id string, run types.RecurringRun) error
+
+	// Runs returns a schedule's history, newest first
+	Runs(id string) ([]types.RecurringRun, error)
+}
+
+var (
+	// ErrRecurringNotFound is returned for an unknown or deleted recurring schedule
+	ErrRecurringNotFound = errors.New("recurring schedule not found")
+
+	// ErrNotRecurringOwner is returned when accessing another user's recurring schedule
+	ErrNotRecurringOwner = errors.New("only the creator may manage a recurring schedule")
+
+	// ErrInvalidCron is returned for a cron expression or time zone that does not parse
+	ErrInvalidCron = errors.New("invalid cron expression or time zone")
+)
+
+// MemoryRecurringStore is a RecurringStore kept in process memory
+type MemoryRecurringStore struct {
+	mu        sync.Mutex
+	schedules map[string]types.RecurringSchedule // Map of ID -> schedule
+	runs      map[string][]types.RecurringRun    // Map of ID -> history, oldest first
+}
+
+// NewMemoryRecurringStore creates a new in-memory recurring schedule store
+func NewMemoryRecurringStore() *MemoryRecurringStore {
+	return &MemoryRecurringStore{
+		schedules: make(map[string]types.RecurringSchedule),
+		runs:      make(map[string][]types.RecurringRun),
+	}
+}
+
+// Create stores the schedule
+func (m *MemoryRecurringStore) Create(schedule types.RecurringSchedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.schedules[schedule.ID] = schedule
+	return nil
+}
+
+// Get returns the schedule
+func (m *MemoryRecurringStore) Get(id string) (types.RecurringSchedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	schedule, ok := m.schedules[id]
+	if !ok {
+		return types.RecurringSchedule{}, ErrRecurringNotFound
+	}
+	return schedule, nil
+}
+
+// List returns every schedule, oldest first
+func (m *MemoryRecurringStore) List() ([]types.RecurringSchedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	schedules := make([]types.RecurringSchedule, 0, len(m.schedules))
+	for _, schedule := range m.schedules {
+		schedules = append(schedules, schedule)
+	}
+	sortRecurring(schedules)
+	return schedules, nil
+}
+
+// Update applies fn to a copy of the schedule and stores it if fn succeeds
+func (m *MemoryRecurringStore) Update(id string, fn func(schedule *types.RecurringSchedule) error) (types.RecurringSchedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	schedule, ok := m.schedules[id]
+	if !ok {
+		return types.RecurringSchedule{}, ErrRecurringNotFound
+	}
+	if err := fn(&schedule); err != nil {
+		return types.RecurringSchedule{}, err
+	}
+	m.schedules[id] = schedule
+	return schedule, nil
+}
+
+// Delete removes the schedule and its history
+func (m *MemoryRecurringStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.schedules[id]; !ok {
+		return ErrRecurringNotFound
+	}
+	delete(m.schedules, id)
+	delete(m.runs, id)
+	return nil
+}
+
+// AddRun appends the run, dropping the oldest beyond maxRecurringRuns
+func (m *MemoryRecurringStore) AddRun(id string, run types.RecurringRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.schedules[id]; !ok {
+		return ErrRecurringNotFound
+	}
+	runs := append(m.runs[id], run)
+	if len(runs) > maxRecurringRuns {
+		runs = slices.Clone(runs[len(runs)-maxRecurringRuns:])
+	}
+	m.runs[id] = runs
+	return nil
+}
+
+// Runs returns the history, newest first
+func (m *MemoryRecurringStore) Runs(id string) ([]types.RecurringRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.schedules[id]; !ok {
+		return nil, ErrRecurringNotFound
+	}
+	runs := slices.Clone(m.runs[id])
+	slices.Reverse(runs)
+	if runs == nil {
+		runs = []types.RecurringRun{}
+	}
+	return runs, nil
+}
+
+// sortRecurring orders schedules by creation time, then ID
+func sortRecurring(schedules []types.RecurringSchedule) {
+	sort.Slice(schedules, func(i, j int) bool {
+		if !schedules[i].CreatedAt.Equal(schedules[j].CreatedAt) {
+			return schedules[i].CreatedAt.Before(schedules[j].CreatedAt)
+		}
+		return schedules[i].ID < schedules[j].ID
+	})
+}
+
+// parseCron parses a standard cron expression in the named time zone. The
+// zone must be given separately rather than as a CRON_TZ= prefix.
+func parseCron(expr string, timeZone string) (cron.Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" || strings.HasPrefix(expr, "TZ=") || strings.HasPrefix(expr, "CRON_TZ=") {
+		return nil, ErrInvalidCron
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCron, err)
+	}
+
+	schedule, err := cron.ParseStandard("CRON_TZ=" + timeZone + " " + expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCron, err)
+	}
+	return schedule, nil
+}
+
+// CreateRecurring validates and stores a recurring schedule and starts running
+// it. The time zone defaults to UTC.
+func (s *NotificationService) CreateRecurring(schedule types.RecurringSchedule) (types.RecurringSchedule, error) {
+	if schedule.TimeZone == "" {
+		schedule.TimeZone = "UTC"
+	}
+	schedule.Cron = strings.TrimSpace(schedule.Cron)
+	if _, err := parseCron(schedule.Cron, schedule.TimeZone); err != nil {
+		return types.RecurringSchedule{}, err
+	}
+
+	now := time.Now()
+	schedule.ID = uuid.New().String()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+
+	if err := s.recurring.Create(schedule); err != nil {
+		return types.RecurringSchedule{}, err
+	}
+	s.armRecurring(schedule)
+
+	log.Printf("Created recurring %s %s from %s: %q in %s", schedule.Kind, schedule.ID, schedule.CreatedBy, schedule.Cron, schedule.TimeZone)
+	return schedule, nil
+}
+
+// GetRecurring returns a recurring schedule created by username
+func (s *NotificationService) GetRecurring(id string, username string) (types.RecurringSchedule, error) {
+	schedule, err := s.recurring.Get(id)
+	if err != nil {
+		return types.RecurringSchedule{}, err
+	}
+	if schedule.CreatedBy != username {
+		return types.RecurringSchedule{}, ErrNotRecurringOwner
+	}
+	return schedule, nil
+}
+
+// ListRecurring returns the recurring schedules created by username, oldest first
+func (s *NotificationService) ListRecurring(username string) ([]types.RecurringSchedule, error) {
+	schedules, err := s.recurring.List()
+	if err != nil {
+		return nil, err
+	}
+
+	own := []types.RecurringSchedule{}
+	for _, schedule := range schedules {
+		if schedule.CreatedBy == username {
+			own = append(own, schedule)
+		}
+	}
+	return own, nil
+}
+
+// UpdateRecurring changes a schedule's cron expression, time zone or paused
+// state. Nil arguments are left unchanged. Only the creator may update it.
+func (s *NotificationService) UpdateRecurring(id string, username string, expr *string, timeZone *string, paused *bool) (types.RecurringSchedule, error) {
+	schedule, err := s.recurring.Update(id, func(schedule *types.RecurringSchedule) error {
+		if schedule.CreatedBy != username {
+			return ErrNotRecurringOwner
+		}
+		if expr != nil {
+			schedule.Cron = strings.TrimSpace(*expr)
+		}
+		if timeZone != nil {
+			schedule.TimeZone = *timeZone
+		}
+		if paused != nil {
+			schedule.Paused = *paused
+		}
+		if _, err := parseCron(schedule.Cron, schedule.TimeZone); err != nil {
+			return err
+		}
+		schedule.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return types.RecurringSchedule{}, err
+	}
+
+	s.armRecurring(schedule)
+	return schedule, nil
+}
+
+// DeleteRecurring stops and removes a recurring schedule and its history.
+// Only the creator may delete it.
+func (s *NotificationService) DeleteRecurring(id string, username string) error {
+	if _, err := s.GetRecurring(id, username); err != nil {
+		return err
+	}
+	if err := s.recurring.Delete(id); err != nil {
+		return err
+	}
+	s.disarmRecurring(id)
+
+	log.Printf("Deleted recurring schedule %s", id)
+	return nil
+}
+
+// RecurringRuns returns the execution history of a schedule created by
+// username, newest first
+func (s *NotificationService) RecurringRuns(id string, username string) ([]types.RecurringRun, error) {
+	if _, err := s.GetRecurring(id, username); err != nil {
+		return nil, err
+	}
+	return s.recurring.Runs(id)
+}
+
+// NextRecurringRun returns when a schedule runs next, or false if it is paused
+func (s *NotificationService) NextRecurringRun(id string) (time.Time, bool) {
+	s.recurringMu.Lock()
+	entryID, ok := s.recurringEntries[id]
+	s.recurringMu.Unlock()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	next := s.cron.Entry(entryID).Next
+	if next.IsZero() {
+		// The cron has not computed the next run yet
+		schedule, err := s.recurring.Get(id)
+		if err != nil {
+			return time.Time{}, false
+		}
+		parsed, err := parseCron(schedule.Cron, schedule.TimeZone)
+		if err != nil {
+			return time.Time{}, false
+		}
+		next = parsed.Next(time.Now())
+	}
+	return next, true
+}
+
+// restoreRecurring registers the persisted schedules with the cron. Runs
+// missed while the server was down are skipped.
+func (s *NotificationService) restoreRecurring() error {
+	schedules, err := s.recurring.List()
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		s.armRecurring(schedule)
+	}
+	if len(schedules) > 0 {
+		log.Printf("Restored %d recurring schedule(s)", len(schedules))
+	}
+	return nil
+}
+
+// armRecurring (re)registers a schedule with the cron, or just removes it if
+// the schedule is paused
+func (s *NotificationService) armRecurring(schedule types.RecurringSchedule) {
+	s.disarmRecurring(schedule.ID)
+	if schedule.Paused {
+		return
+	}
+
+	parsed, err := parseCron(schedule.Cron, schedule.TimeZone)
+	if err != nil {
+		log.Printf("Not running recurring schedule %s: %v", schedule.ID, err)
+		return
+	}
+
+	id := schedule.ID
+	s.recurringMu.Lock()
+	s.recurringEntries[id] = s.cron.Schedule(parsed, cron.FuncJob(func() {
+		s.runRecurring(id)
+	}))
+	s.recurringMu.Unlock()
+}
+
+// disarmRecurring removes a schedule from the cron
+func (s *NotificationService) disarmRecurring(id string) {
+	s.recurringMu.Lock()
+	defer s.recurringMu.Unlock()
+
+	if entryID, ok := s.recurringEntries[id]; ok {
+		s.cron.Remove(entryID)
+		delete(s.recurringEntries, id)
+	}
+}
+
+// runRecurring sends the current version of a schedule's notification or
+// acknowledgment request and records the outcome in its history
+func (s *NotificationService) runRecurring(id string) {
+	schedule, err := s.recurring.Get(id)
+	if err != nil {
+		if !errors.Is(err, ErrRecurringNotFound) {
+			log.Printf("Error loading recurring schedule %s: %v", id, err)
+		}
+		return
+	}
+	if schedule.Paused {
+		return
+	}
+
+	run := types.RecurringRun{At: time.Now()}
+
+	switch schedule.Kind {
+	case types.ScheduledKindNotification:
+		req := *schedule.Notification
+		// BroadcastMessage only logs failures, so check the recipients here
+		if req.TargetUsername != "all" {
+			if _, err = s.ResolveRecipients([]string{req.TargetUsername}, req.TargetGroups); err != nil {
+				break
+			}
+		}
+		s.BroadcastMessage(req)
+
+	case types.ScheduledKindAcknowledgment:
+		req := *schedule.Acknowledgment
+		if schedule.DeadlineAfter > 0 {
+			deadline := run.At.Add(schedule.DeadlineAfter)
+			req.Deadline = &deadline
+		}
+		run.RequestID, err = s.CreateAcknowledgmentRequest(req)
+	}
+
+	if err != nil {
+		run.Error = err.Error()
+		log.Printf("Recurring %s %s sent nothing: %v", schedule.Kind, id, err)
+	} else {
+		log.Printf("Ran recurring %s %s", schedule.Kind, id)
+	}
+
+	if err := s.recurring.AddRun(id, run); err != nil && !errors.Is(err, ErrRecurringNotFound) {
+		log.Printf("Error recording run of recurring schedule %s: %v", id, err)
+	}
+}
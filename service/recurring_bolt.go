@@ -0,0 +1,201 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sse-demo/types"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// recurringBucket maps recurring schedule ID -> JSON encoded types.RecurringSchedule
+	recurringBucket = []byte("recurring")
+
+	// recurringRunsBucket holds one nested bucket per schedule with sequence -> run
+	recurringRunsBucket = []byte("recurring_runs")
+)
+
+// BoltRecurringStore is a RecurringStore persisted in a bbolt database, so
+// recurring schedules and their history survive a restart
+type BoltRecurringStore struct {
+	db *bolt.DB
+}
+
+// NewBoltRecurringStore creates the recurring schedule buckets in db if needed
+func NewBoltRecurringStore(db *bolt.DB) (*BoltRecurringStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{recurringBucket, recurringRunsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recurring schedule buckets: %w", err)
+	}
+	return &BoltRecurringStore{db: db}, nil
+}
+
+// Create stores the schedule under its ID
+func (b *BoltRecurringStore) Create(schedule types.RecurringSchedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal recurring schedule: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(recurringBucket).Put([]byte(schedule.ID), data)
+	})
+}
+
+// Get decodes the stored schedule
+func (b *BoltRecurringStore) Get(id string) (types.RecurringSchedule, error) {
+	var schedule types.RecurringSchedule
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(recurringBucket).Get([]byte(id))
+		if data == nil {
+			return ErrRecurringNotFound
+		}
+		return decodeRecurring(data, &schedule)
+	})
+	return schedule, err
+}
+
+// List decodes every schedule and orders them by creation time
+func (b *BoltRecurringStore) List() ([]types.RecurringSchedule, error) {
+	schedules := []types.RecurringSchedule{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(recurringBucket).ForEach(func(_, v []byte) error {
+			var schedule types.RecurringSchedule
+			if err := decodeRecurring(v, &schedule); err != nil {
+				return err
+			}
+			schedules = append(schedules, schedule)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortRecurring(schedules)
+	return schedules, nil
+}
+
+// Update applies fn to the stored schedule within a single transaction
+func (b *BoltRecurringStore) Update(id string, fn func(schedule *types.RecurringSchedule) error) (types.RecurringSchedule, error) {
+	var schedule types.RecurringSchedule
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(recurringBucket)
+		data := bucket.Get([]byte(id))
+		if data == nil {
+			return ErrRecurringNotFound
+		}
+		if err := decodeRecurring(data, &schedule); err != nil {
+			return err
+		}
+		if err := fn(&schedule); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(schedule)
+		if err != nil {
+			return fmt.Errorf("failed to marshal recurring schedule: %w", err)
+		}
+		return bucket.Put([]byte(id), data)
+	})
+	if err != nil {
+		return types.RecurringSchedule{}, err
+	}
+	return schedule, nil
+}
+
+// Delete removes the schedule's key and its history bucket
+func (b *BoltRecurringStore) Delete(id string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(recurringBucket)
+		if bucket.Get([]byte(id)) == nil {
+			return ErrRecurringNotFound
+		}
+		if err := bucket.Delete([]byte(id)); err != nil {
+			return err
+		}
+
+		runs := tx.Bucket(recurringRunsBucket)
+		if runs.Bucket([]byte(id)) == nil {
+			return nil
+		}
+		return runs.DeleteBucket([]byte(id))
+	})
+}
+
+// AddRun appends the run to the schedule's history bucket and deletes the
+// run that falls beyond maxRecurringRuns
+func (b *BoltRecurringStore) AddRun(id string, run types.RecurringRun) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal recurring run: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(recurringBucket).Get([]byte(id)) == nil {
+			return ErrRecurringNotFound
+		}
+
+		runs, err := tx.Bucket(recurringRunsBucket).CreateBucketIfNotExists([]byte(id))
+		if err != nil {
+			return err
+		}
+		seq, err := runs.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err := runs.Put(seqKey(seq), data); err != nil {
+			return err
+		}
+
+		// Runs are trimmed on every append, so only one key can fall out
+		if seq > maxRecurringRuns {
+			return runs.Delete(seqKey(seq - maxRecurringRuns))
+		}
+		return nil
+	})
+}
+
+// Runs walks the schedule's history bucket backwards
+func (b *BoltRecurringStore) Runs(id string) ([]types.RecurringRun, error) {
+	runs := []types.RecurringRun{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(recurringBucket).Get([]byte(id)) == nil {
+			return ErrRecurringNotFound
+		}
+
+		bucket := tx.Bucket(recurringRunsBucket).Bucket([]byte(id))
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var run types.RecurringRun
+			if err := json.Unmarshal(v, &run); err != nil {
+				return fmt.Errorf("failed to decode recurring run: %w", err)
+			}
+			runs = append(runs, run)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// decodeRecurring unmarshals a stored recurring schedule
+func decodeRecurring(data []byte, schedule *types.RecurringSchedule) error {
+	if err := json.Unmarshal(data, schedule); err != nil {
+		return fmt.Errorf("failed to decode recurring schedule: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"sse-demo/types"
+	"testing"
+	"time"
+)
+
+func TestParseCronTimeZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		timeZone string
+		from     time.Time
+		want     time.Time // Next run after from
+		err      error
+	}{
+		{
+			name:     "winter time in Berlin",
+			expr:     "0 9 * * *",
+			timeZone: "Europe/Berlin",
+			from:     time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "summer time in Berlin",
+			expr:     "0 9 * * *",
+			timeZone: "Europe/Berlin",
+			from:     time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 7, 15, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekday falls on the previous UTC day",
+			expr:     "30 8 * * MON",
+			timeZone: "Pacific/Auckland",
+			from:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), // A Sunday
+			want:     time.Date(2026, 3, 1, 19, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "descriptor",
+			expr:     "@daily",
+			timeZone: "America/New_York",
+			from:     time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 1, 16, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "UTC",
+			expr:     "0 9 * * *",
+			timeZone: "UTC",
+			from:     time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "zone in the expression",
+			expr:     "CRON_TZ=Europe/Berlin 0 9 * * *",
+			timeZone: "UTC",
+			err:      ErrInvalidCron,
+		},
+		{
+			name:     "unknown zone",
+			expr:     "0 9 * * *",
+			timeZone: "Mars/Olympus_Mons",
+			err:      ErrInvalidCron,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseCron(tt.expr, tt.timeZone)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("parseCron returned %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("next run = %v, want %v", got.UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNextRecurringRunUsesTimeZone(t *testing.T) {
+	s, err := NewNotificationService(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	schedule, err := s.CreateRecurring(types.RecurringSchedule{
+		Kind:      types.ScheduledKindNotification,
+		Cron:      "0 9 * * *",
+		TimeZone:  "Asia/Tokyo",
+		CreatedBy: "bob",
+		Notification: &types.NotifyRequest{
+			FromUsername:   "bob",
+			Message:        "good morning",
+			TargetUsername: "alice",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, ok := s.NextRecurringRun(schedule.ID)
+	if !ok {
+		t.Fatal("schedule has no next run")
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := next.In(tokyo)
+	if local.Hour() != 9 || local.Minute() != 0 {
+		t.Errorf("next run is at %s in Tokyo, want 09:00", local.Format("15:04"))
+	}
+	if hour := next.UTC().Hour(); hour != 0 {
+		t.Errorf("next run is at %02d:00 UTC, want 00:00", hour)
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+// registryShards is the number of independently locked parts of the registry
+const registryShards = 64
+
+// registry holds the local connections and event streams of every user. Users
+// are spread over shards by username, each with its own lock, so a broadcast
+// only ever holds one shard at a time and connects, disconnects and targeted
+// deliveries elsewhere proceed in parallel.
+type registry struct {
+	shards [registryShards]registryShard
+	online atomic.Int64 // Number of users with at least one open connection
+}
+
+// registryShard is one part of the registry
+type registryShard struct {
+	mu      sync.Mutex
+	clients map[string]map[string]*Client // Map of username -> connection ID -> client
+	streams map[string]*userStream        // Map of username -> event sequence and replay buffer
+}
+
+// newRegistry creates an empty registry
+func newRegistry() *registry {
+	r := &registry{}
+	for i := range r.shards {
+		r.shards[i].clients = make(map[string]map[string]*Client)
+		r.shards[i].streams = make(map[string]*userStream)
+	}
+	return r
+}
+
+// shard returns the shard that holds username
+func (r *registry) shard(username string) *registryShard {
+	h := fnv.New32a()
+	h.Write([]byte(username))
+	return &r.shards[h.Sum32()%registryShards]
+}
+
+// group splits usernames by the shard that holds them
+func (r *registry) group(usernames []string) map[*registryShard][]string {
+	groups := make(map[*registryShard][]string)
+	for _, username := range usernames {
+		shard := r.shard(username)
+		groups[shard] = append(groups[shard], username)
+	}
+	return groups
+}
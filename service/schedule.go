@@ -0,0 +1,238 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"sse-demo/types"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleStore persists notifications and acknowledgment requests waiting
+// for their send time. Sends are removed once they go out or are cancelled.
+type ScheduleStore interface {
+	// Add stores a pending send
+	Add(send types.ScheduledSend) error
+
+	// Get returns a pending send, or ErrScheduleNotFound
+	Get(id string) (types.ScheduledSend, error)
+
+	// Remove deletes a pending send, or returns ErrScheduleNotFound if it was
+	// already sent or cancelled
+	Remove(id string) error
+
+	// List returns every pending send, soonest first
+	List() ([]types.ScheduledSend, error)
+}
+
+var (
+	// ErrScheduleNotFound is returned for an unknown, sent or cancelled scheduled send
+	ErrScheduleNotFound = errors.New("scheduled send not found")
+
+	// ErrNotScheduleOwner is returned when cancelling another user's scheduled send
+	ErrNotScheduleOwner = errors.New("only the creator may cancel a scheduled send")
+)
+
+// MemoryScheduleStore is a ScheduleStore kept in process memory
+type MemoryScheduleStore struct {
+	mu    sync.Mutex
+	sends map[string]types.ScheduledSend // Map of ID -> pending send
+}
+
+// NewMemoryScheduleStore creates a new in-memory schedule store
+func NewMemoryScheduleStore() *MemoryScheduleStore {
+	return &MemoryScheduleStore{
+		sends: make(map[string]types.ScheduledSend),
+	}
+}
+
+// Add stores the send
+func (m *MemoryScheduleStore) Add(send types.ScheduledSend) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sends[send.ID] = send
+	return nil
+}
+
+// Get returns the send
+func (m *MemoryScheduleStore) Get(id string) (types.ScheduledSend, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	send, ok := m.sends[id]
+	if !ok {
+		return types.ScheduledSend{}, ErrScheduleNotFound
+	}
+	return send, nil
+}
+
+// Remove deletes the send
+func (m *MemoryScheduleStore) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sends[id]; !ok {
+		return ErrScheduleNotFound
+	}
+	delete(m.sends, id)
+	return nil
+}
+
+// List returns every send, soonest first
+func (m *MemoryScheduleStore) List() ([]types.ScheduledSend, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sends := make([]types.ScheduledSend, 0, len(m.sends))
+	for _, send := range m.sends {
+		sends = append(sends, send)
+	}
+	sortScheduled(sends)
+	return sends, nil
+}
+
+// sortScheduled orders sends by send time, then ID
+func sortScheduled(sends []types.ScheduledSend) {
+	sort.Slice(sends, func(i, j int) bool {
+		if !sends[i].SendAt.Equal(sends[j].SendAt) {
+			return sends[i].SendAt.Before(sends[j].SendAt)
+		}
+		return sends[i].ID < sends[j].ID
+	})
+}
+
+// ScheduleSend stores a notification or acknowledgment request to be sent at
+// send.SendAt and arms its timer. Groups are expanded when it is sent.
+func (s *NotificationService) ScheduleSend(send types.ScheduledSend) (types.ScheduledSend, error) {
+	send.ID = uuid.New().String()
+	send.CreatedAt = time.Now()
+
+	if err := s.schedules.Add(send); err != nil {
+		return types.ScheduledSend{}, err
+	}
+	s.armScheduled(send)
+
+	log.Printf("Scheduled %s %s from %s for %s", send.Kind, send.ID, send.CreatedBy, send.SendAt.Format(time.RFC3339))
+	return send, nil
+}
+
+// ListScheduled returns the pending sends created by username, soonest first
+func (s *NotificationService) ListScheduled(username string) ([]types.ScheduledSend, error) {
+	sends, err := s.schedules.List()
+	if err != nil {
+		return nil, err
+	}
+
+	own := []types.ScheduledSend{}
+	for _, send := range sends {
+		if send.CreatedBy == username {
+			own = append(own, send)
+		}
+	}
+	return own, nil
+}
+
+// CancelScheduled cancels a pending send. Only its creator may cancel it.
+func (s *NotificationService) CancelScheduled(id string, username string) error {
+	send, err := s.schedules.Get(id)
+	if err != nil {
+		return err
+	}
+	if send.CreatedBy != username {
+		return ErrNotScheduleOwner
+	}
+
+	// Whoever removes the send first wins, so it is never both sent and cancelled
+	if err := s.schedules.Remove(id); err != nil {
+		return err
+	}
+
+	s.scheduleMu.Lock()
+	if timer, ok := s.scheduleTimers[id]; ok {
+		timer.Stop()
+		delete(s.scheduleTimers, id)
+	}
+	s.scheduleMu.Unlock()
+
+	log.Printf("Cancelled scheduled %s %s", send.Kind, id)
+	return nil
+}
+
+// restoreScheduled arms the timers of the sends persisted before a restart.
+// Sends whose time passed while the server was down go out right away, in
+// the order they were due.
+func (s *NotificationService) restoreScheduled() error {
+	sends, err := s.schedules.List()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var overdue []types.ScheduledSend
+	for _, send := range sends {
+		if send.SendAt.After(now) {
+			s.armScheduled(send)
+		} else {
+			overdue = append(overdue, send)
+		}
+	}
+	if len(sends) > 0 {
+		log.Printf("Restored %d scheduled send(s), %d overdue", len(sends), len(overdue))
+	}
+
+	if len(overdue) > 0 {
+		go func() {
+			for _, send := range overdue {
+				s.fireScheduled(send)
+			}
+		}()
+	}
+	return nil
+}
+
+// armScheduled starts the timer that sends send at its send time
+func (s *NotificationService) armScheduled(send types.ScheduledSend) {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+
+	s.scheduleTimers[send.ID] = time.AfterFunc(time.Until(send.SendAt), func() {
+		s.fireScheduled(send)
+	})
+}
+
+// fireScheduled sends a scheduled notification or acknowledgment request
+// unless it was cancelled in the meantime
+func (s *NotificationService) fireScheduled(send types.ScheduledSend) {
+	s.scheduleMu.Lock()
+	delete(s.scheduleTimers, send.ID)
+	s.scheduleMu.Unlock()
+
+	if err := s.schedules.Remove(send.ID); err != nil {
+		if !errors.Is(err, ErrScheduleNotFound) {
+			log.Printf("Error removing scheduled send %s: %v", send.ID, err)
+		}
+		return
+	}
+
+	if late := time.Since(send.SendAt); late > time.Minute {
+		log.Printf("Scheduled %s %s is %s late", send.Kind, send.ID, late.Round(time.Second))
+	}
+
+	switch send.Kind {
+	case types.ScheduledKindNotification:
+		s.BroadcastMessage(*send.Notification)
+		log.Printf("Sent scheduled notification %s", send.ID)
+
+	case types.ScheduledKindAcknowledgment:
+		requestID, err := s.CreateAcknowledgmentRequest(*send.Acknowledgment)
+		if err != nil {
+			log.Printf("Error sending scheduled acknowledgment request %s: %v", send.ID, err)
+			return
+		}
+		log.Printf("Sent scheduled acknowledgment request %s as %s", send.ID, requestID)
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sse-demo/types"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// scheduledBucket maps scheduled send ID -> JSON encoded types.ScheduledSend
+var scheduledBucket = []byte("scheduled")
+
+// BoltScheduleStore is a ScheduleStore persisted in a bbolt database, so
+// pending sends survive a restart
+type BoltScheduleStore struct {
+	db *bolt.DB
+}
+
+// NewBoltScheduleStore creates the schedule bucket in db if needed
+func NewBoltScheduleStore(db *bolt.DB) (*BoltScheduleStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(scheduledBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schedule bucket: %w", err)
+	}
+	return &BoltScheduleStore{db: db}, nil
+}
+
+// Add stores the send under its ID
+func (b *BoltScheduleStore) Add(send types.ScheduledSend) error {
+	data, err := json.Marshal(send)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scheduled send: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(scheduledBucket).Put([]byte(send.ID), data)
+	})
+}
+
+// Get decodes the stored send
+func (b *BoltScheduleStore) Get(id string) (types.ScheduledSend, error) {
+	var send types.ScheduledSend
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(scheduledBucket).Get([]byte(id))
+		if data == nil {
+			return ErrScheduleNotFound
+		}
+		return decodeScheduled(data, &send)
+	})
+	return send, err
+}
+
+// Remove deletes the send's key
+func (b *BoltScheduleStore) Remove(id string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(scheduledBucket)
+		if bucket.Get([]byte(id)) == nil {
+			return ErrScheduleNotFound
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+// List decodes every send and orders them by send time
+func (b *BoltScheduleStore) List() ([]types.ScheduledSend, error) {
+	sends := []types.ScheduledSend{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(scheduledBucket).ForEach(func(_, v []byte) error {
+			var send types.ScheduledSend
+			if err := decodeScheduled(v, &send); err != nil {
+				return err
+			}
+			sends = append(sends, send)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortScheduled(sends)
+	return sends, nil
+}
+
+// decodeScheduled unmarshals a stored send
+func decodeScheduled(data []byte, send *types.ScheduledSend) error {
+	if err := json.Unmarshal(data, send); err != nil {
+		return fmt.Errorf("failed to decode scheduled send: %w", err)
+	}
+	return nil
+}
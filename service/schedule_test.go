@@ -0,0 +1,132 @@
+package service
+
+import (
+	"path/filepath"
+	"sse-demo/types"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// newBoltScheduledService starts a service whose scheduled sends are kept in
+// the bbolt file at path. The returned function stops the service and
+// closes the file, like shutting the server down.
+func newBoltScheduledService(t *testing.T, path string) (*NotificationService, func()) {
+	t.Helper()
+
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewBoltScheduleStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewNotificationService(Options{Schedules: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, func() {
+		s.Close()
+		db.Close()
+	}
+}
+
+func TestScheduledSendsSurviveRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	const downtime = 50 * time.Millisecond
+
+	// Each send is scheduled before the restart, which happens after downtime
+	tests := []struct {
+		name      string
+		in        time.Duration // Send time relative to scheduling
+		cancelled bool
+		sent      bool // Sent right after the restart
+		pending   bool // Still waiting after the restart
+	}{
+		{"overdue after the restart", downtime / 2, false, true, false},
+		{"also overdue", downtime / 4, false, true, false},
+		{"due later", time.Hour, false, false, true},
+		{"cancelled before the restart", downtime / 2, true, false, false},
+	}
+
+	before, shutdown := newBoltScheduledService(t, path)
+	ids := make(map[string]string) // Map of message -> scheduled send ID
+	for _, tt := range tests {
+		send, err := before.ScheduleSend(types.ScheduledSend{
+			Kind:      types.ScheduledKindNotification,
+			SendAt:    time.Now().Add(tt.in),
+			CreatedBy: "bob",
+			Notification: &types.NotifyRequest{
+				FromUsername:   "bob",
+				Message:        tt.name,
+				TargetUsername: "alice",
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[tt.name] = send.ID
+		if tt.cancelled {
+			if err := before.CancelScheduled(send.ID, "bob"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	shutdown()
+
+	// Every send but the last comes due while the server is down
+	time.Sleep(downtime)
+	after, shutdown := newBoltScheduledService(t, path)
+	defer shutdown()
+
+	// Overdue sends go out in the background right after the restore
+	received := func() map[string]bool {
+		items, _, err := after.ListNotifications("alice", "", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages := make(map[string]bool)
+		for _, item := range items {
+			messages[item.Message] = true
+		}
+		return messages
+	}
+	want := 0
+	for _, tt := range tests {
+		if tt.sent {
+			want++
+		}
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for len(received()) < want && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	messages := received()
+	pending, err := after.ListScheduled("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		if messages[tt.name] != tt.sent {
+			t.Errorf("%s: sent = %v, want %v", tt.name, messages[tt.name], tt.sent)
+		}
+
+		found := false
+		for _, send := range pending {
+			found = found || send.ID == ids[tt.name]
+		}
+		if found != tt.pending {
+			t.Errorf("%s: pending = %v, want %v", tt.name, found, tt.pending)
+		}
+
+		after.scheduleMu.Lock()
+		_, armed := after.scheduleTimers[ids[tt.name]]
+		after.scheduleMu.Unlock()
+		if armed != tt.pending {
+			t.Errorf("%s: timer armed = %v, want %v", tt.name, armed, tt.pending)
+		}
+	}
+}
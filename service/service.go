@@ -1,189 +1,670 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"slices"
 	"sse-demo/types"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
 )
 
+// replayBufferSize is the number of recent events kept per user for Last-Event-ID replay.
+const replayBufferSize = 100
+
+const (
+	// streamRetention is how long the stream of a user with no open
+	// connection is kept after its newest event, for a client to resume from
+	streamRetention = time.Hour
+
+	// streamSweepInterval is how often idle streams are removed
+	streamSweepInterval = time.Minute
+)
+
+// Event is an encoded SSE event together with its per-user event ID. Data is
+// the full JSON envelope (types.SSEEvent) and Payload just its payload, so
+// clients can be served either form without re-encoding.
+type Event struct {
+	ID        uint64
+	Type      types.EventType
+	Data      string
+	Payload   string
+	Timestamp time.Time
+	Priority  types.Priority
+}
+
+// userStream holds the event ID sequence and replay buffer for a single user.
+type userStream struct {
+	lastID    uint64
+	buffer    []Event   // Oldest first, at most replayBufferSize entries
+	idleSince time.Time // When the user's last connection closed
+}
+
+// Client is a single SSE connection. A user may have several at once, e.g.
+// one per browser tab or device.
+type Client struct {
+	ID       string
+	Username string
+	Events   *EventQueue
+
+	// Closed is closed when the service drops the connection under the
+	// disconnect policy; CloseEvent then tells the client why
+	Closed     chan struct{}
+	CloseEvent Event
+
+	dropped bool // Set once Closed is closed; guarded by the user's registry shard
+}
+
 // NotificationService manages all client connections and message broadcasting.
+// Connections live in a sharded registry; mu only guards the acknowledgment
+// deadline timers, so fan-out never waits on it.
 type NotificationService struct {
-	mu                  sync.Mutex
-	clients             map[string]chan string // Map of username -> SSE channel
-	acknowledgmentReqs   map[string]*types.AcknowledgmentRequest // Map of request ID -> request
-	acknowledgmentAckd   map[string][]string // Map of request ID -> list of users who acknowledged
+	mu                   sync.Mutex
+	broker               Broker
+	inbox                InboxStore
+	channels             ChannelStore
+	groups               GroupStore
+	schedules            ScheduleStore
+	recurring            RecurringStore
+	policy               SlowConsumerPolicy
+	bufferSize           int
+	registry             *registry
+	acknowledgments      AcknowledgmentStore
+	acknowledgmentTimers map[string]*time.Timer // Map of request ID -> deadline timer of a request created here
+
+	scheduleMu     sync.Mutex
+	scheduleTimers map[string]*time.Timer // Map of scheduled send ID -> send timer
+
+	cron             *cron.Cron
+	recurringMu      sync.Mutex
+	recurringEntries map[string]cron.EntryID // Map of recurring schedule ID -> cron entry; paused schedules have none
+
+	dndStore DoNotDisturbStore
+	dndMu    sync.RWMutex
+	dnd      map[string]types.DoNotDisturb // Map of username -> enabled setting, read on every delivery
+
+	pollMu     sync.Mutex
+	pollLeases map[string]*pollLease // Map of username -> presence held for long-polling
+	pollGrace  time.Duration         // How long a lease outlives its last poll; replaced in tests
+
+	stop chan struct{}  // Closed by Close to stop background work
+	wg   sync.WaitGroup // Tracks background goroutines
+}
+
+// Options configures a NotificationService. Nil fields fall back to
+// in-memory implementations.
+type Options struct {
+	Broker    Broker         // Distributes events between server instances
+	Inbox     InboxStore     // Persists notification history
+	Channels  ChannelStore   // Persists channel membership
+	Groups    GroupStore     // Persists recipient groups
+	Schedules ScheduleStore  // Persists scheduled sends
+	Recurring RecurringStore // Persists recurring schedules and their history
+
+	Acknowledgments AcknowledgmentStore // Holds acknowledgment requests; shared between instances
+
+	DoNotDisturb DoNotDisturbStore // Persists do-not-disturb settings
+
+	BufferSize         int                // Events buffered per connection; defaults to 10
+	SlowConsumerPolicy SlowConsumerPolicy // What to do when a buffer is full; defaults to drop-newest
 }
 
-func NewNotificationService() *NotificationService {
-	return &NotificationService{
-		clients:            make(map[string]chan string),
-		acknowledgmentReqs:  make(map[string]*types.AcknowledgmentRequest),
-		acknowledgmentAckd:  make(map[string][]string),
+// NewNotificationService creates the service and subscribes it to the broker.
+// Every event, including those published by this instance, reaches local
+// clients through the broker subscription.
+func NewNotificationService(opts Options) (*NotificationService, error) {
+	if opts.Broker == nil {
+		opts.Broker = NewMemoryBroker()
+	}
+	if opts.Inbox == nil {
+		opts.Inbox = NewMemoryInboxStore()
+	}
+	if opts.Channels == nil {
+		opts.Channels = NewMemoryChannelStore()
+	}
+	if opts.Groups == nil {
+		opts.Groups = NewMemoryGroupStore()
+	}
+	if opts.Schedules == nil {
+		opts.Schedules = NewMemoryScheduleStore()
+	}
+	if opts.Recurring == nil {
+		opts.Recurring = NewMemoryRecurringStore()
+	}
+	if opts.Acknowledgments == nil {
+		opts.Acknowledgments = NewMemoryAcknowledgmentStore()
+	}
+	if opts.DoNotDisturb == nil {
+		opts.DoNotDisturb = NewMemoryDoNotDisturbStore()
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultBufferSize
+	}
+	if opts.SlowConsumerPolicy == "" {
+		opts.SlowConsumerPolicy = PolicyDropNewest
+	}
+	if !opts.SlowConsumerPolicy.valid() {
+		return nil, fmt.Errorf("unknown slow consumer policy %q", opts.SlowConsumerPolicy)
+	}
+
+	s := &NotificationService{
+		broker:               opts.Broker,
+		inbox:                opts.Inbox,
+		channels:             opts.Channels,
+		groups:               opts.Groups,
+		schedules:            opts.Schedules,
+		scheduleTimers:       make(map[string]*time.Timer),
+		recurring:            opts.Recurring,
+		cron:                 cron.New(),
+		recurringEntries:     make(map[string]cron.EntryID),
+		dndStore:             opts.DoNotDisturb,
+		policy:               opts.SlowConsumerPolicy,
+		bufferSize:           opts.BufferSize,
+		registry:             newRegistry(),
+		pollLeases:           make(map[string]*pollLease),
+		pollGrace:            defaultPollGrace,
+		acknowledgments:      opts.Acknowledgments,
+		acknowledgmentTimers: make(map[string]*time.Timer),
+		stop:                 make(chan struct{}),
+	}
+
+	// Deliveries check do-not-disturb, so load it before subscribing
+	if err := s.restoreDoNotDisturb(); err != nil {
+		return nil, fmt.Errorf("failed to load do-not-disturb settings: %w", err)
+	}
+
+	if err := s.broker.Subscribe(s.deliver); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to broker: %w", err)
+	}
+
+	if err := s.restoreScheduled(); err != nil {
+		return nil, fmt.Errorf("failed to restore scheduled sends: %w", err)
 	}
+	if err := s.restoreRecurring(); err != nil {
+		return nil, fmt.Errorf("failed to restore recurring schedules: %w", err)
+	}
+	s.cron.Start()
+
+	s.wg.Add(2)
+	go s.collectAcknowledgments()
+	go s.collectStreams()
+
+	return s, nil
 }
 
-// AddClient registers a new client for SSE and broadcasts user_connected event.
-func (s *NotificationService) AddClient(username string) chan string {
+// Close stops the service's background work
+func (s *NotificationService) Close() {
+	// Let running recurring jobs finish before the rest shuts down
+	<-s.cron.Stop().Done()
+
+	close(s.stop)
+	s.wg.Wait()
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	for id, timer := range s.acknowledgmentTimers {
+		timer.Stop()
+		delete(s.acknowledgmentTimers, id)
+	}
+	s.mu.Unlock()
 
-	// If client already exists, close their old channel
-	if ch, ok := s.clients[username]; ok {
-		close(ch)
+	s.scheduleMu.Lock()
+	for id, timer := range s.scheduleTimers {
+		timer.Stop()
+		delete(s.scheduleTimers, id)
 	}
+	s.scheduleMu.Unlock()
+
+	s.pollMu.Lock()
+	for _, lease := range s.pollLeases {
+		if lease.timer != nil {
+			lease.timer.Stop()
+		}
+	}
+	s.pollMu.Unlock()
+}
 
-	ch := make(chan string, 10)
-	s.clients[username] = ch
-	log.Printf("Client added: %s. Total clients: %d", username, len(s.clients))
+// AddClient registers a new SSE connection for a user. The user_connected event
+// is broadcast only when this is the user's first open connection in the cluster.
+// If lastEventID is set, the buffered events the user missed after that ID are
+// returned so the caller can replay them before reading from the channel.
+func (s *NotificationService) AddClient(username string, lastEventID *uint64) (*Client, []Event) {
+	client, missed, _ := s.attach(username, lastEventID)
+	s.join(username)
+	return client, missed
+}
 
-	// Broadcast user_connected event to all other users
-	s.broadcastEventLocked(types.EventTypeUserConnected, types.UserConnectedPayload{
+// RemoveClient removes a connection and closes its SSE channel. The
+// user_disconnected event is broadcast once the user's last connection in the
+// cluster is gone.
+func (s *NotificationService) RemoveClient(client *Client) {
+	if s.detach(client) {
+		s.leave(client.Username)
+	}
+}
+
+// attach registers a connection in the registry without touching presence.
+// It returns the events after lastEventID (if set) and the user's latest event ID.
+func (s *NotificationService) attach(username string, lastEventID *uint64) (*Client, []Event, uint64) {
+	client := &Client{
+		ID:       uuid.New().String(),
 		Username: username,
-	}, []string{}) // Empty list means broadcast to all
+		Events:   newEventQueue(s.bufferSize),
+		Closed:   make(chan struct{}),
+	}
 
-	return ch
+	shard := s.registry.shard(username)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	conns, ok := shard.clients[username]
+	if !ok {
+		conns = make(map[string]*Client)
+		shard.clients[username] = conns
+		s.registry.online.Add(1)
+	}
+	conns[client.ID] = client
+	log.Printf("Client added: %s (connection %s, %d open). Total users: %d", username, client.ID, len(conns), s.registry.online.Load())
+
+	stream, ok := shard.streams[username]
+	if !ok {
+		stream = &userStream{}
+		shard.streams[username] = stream
+	}
+
+	var missed []Event
+	if lastEventID != nil {
+		missed = stream.since(*lastEventID)
+		log.Printf("Replaying %d missed event(s) to %s after ID %d", len(missed), username, *lastEventID)
+	}
+	return client, missed, stream.lastID
 }
 
-// RemoveClient removes a client and closes their SSE channel, broadcasts user_disconnected event.
-func (s *NotificationService) RemoveClient(username string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// detach removes a connection from the registry and closes its channel. It
+// reports whether the connection was still registered.
+func (s *NotificationService) detach(client *Client) bool {
+	shard := s.registry.shard(client.Username)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
 
-	if ch, ok := s.clients[username]; ok {
-		close(ch)
-		delete(s.clients, username)
-		log.Printf("Client removed: %s. Total clients: %d", username, len(s.clients))
+	conns, ok := shard.clients[client.Username]
+	if !ok {
+		return false
+	}
+	if _, ok := conns[client.ID]; !ok {
+		return false
+	}
 
-		// Broadcast user_disconnected event to all remaining users
-		s.broadcastEventLocked(types.EventTypeUserDisconnected, types.UserDisconnectedPayload{
+	client.Events.close()
+	delete(conns, client.ID)
+	log.Printf("Client removed: %s (connection %s, %d open)", client.Username, client.ID, len(conns))
+
+	if len(conns) == 0 {
+		delete(shard.clients, client.Username)
+		shard.streams[client.Username].idleSince = time.Now()
+		log.Printf("User offline on this instance: %s. Total users: %d", client.Username, s.registry.online.Add(-1))
+	}
+	return true
+}
+
+// collectStreams periodically removes the streams of users who have been
+// gone for longer than streamRetention
+func (s *NotificationService) collectStreams() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(streamSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.pruneStreams(time.Now())
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// pruneStreams removes the stream of every user without an open connection
+// whose stream has been idle since before now minus the retention. A user
+// who comes back later starts a new stream. It returns how many were removed.
+func (s *NotificationService) pruneStreams(now time.Time) int {
+	cutoff := now.Add(-streamRetention)
+	removed := 0
+	for i := range s.registry.shards {
+		shard := &s.registry.shards[i]
+		shard.mu.Lock()
+		for username, stream := range shard.streams {
+			if _, online := shard.clients[username]; !online && stream.lastActive().Before(cutoff) {
+				delete(shard.streams, username)
+				removed++
+			}
+		}
+		shard.mu.Unlock()
+	}
+
+	if removed > 0 {
+		log.Printf("Removed %d idle event stream(s)", removed)
+	}
+	return removed
+}
+
+// join records one more presence of the user and broadcasts user_connected
+// if it is the user's first in the cluster
+func (s *NotificationService) join(username string) {
+	if err := s.inbox.Register(username); err != nil {
+		log.Printf("Error creating inbox for %s: %v", username, err)
+	}
+
+	first, err := s.broker.Join(context.Background(), username)
+	if err != nil {
+		log.Printf("Error recording presence for %s: %v", username, err)
+	}
+	if first {
+		// Broadcast user_connected event to all other users
+		s.publish(types.EventTypeUserConnected, types.UserConnectedPayload{
 			Username: username,
 		}, []string{}) // Empty list means broadcast to all
 	}
 }
 
-// GetConnectedUsers returns a list of currently connected usernames
-func (s *NotificationService) GetConnectedUsers() []string {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	users := make([]string, 0, len(s.clients))
-	for username := range s.clients {
-		users = append(users, username)
+// leave drops one presence of the user and broadcasts user_disconnected if
+// it was the user's last in the cluster
+func (s *NotificationService) leave(username string) {
+	last, err := s.broker.Leave(context.Background(), username)
+	if err != nil {
+		log.Printf("Error recording presence for %s: %v", username, err)
+	}
+	if last {
+		// Broadcast user_disconnected event to all remaining users
+		s.publish(types.EventTypeUserDisconnected, types.UserDisconnectedPayload{
+			Username: username,
+		}, []string{}) // Empty list means broadcast to all
 	}
-	return users
 }
 
-// BroadcastMessage sends a message to the target user(s) using the typed event system.
-func (s *NotificationService) BroadcastMessage(req types.NotifyRequest) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// GetConnectedUsers returns the usernames connected to any instance in the cluster
+func (s *NotificationService) GetConnectedUsers() ([]string, error) {
+	return s.broker.Members(context.Background())
+}
 
+// BroadcastMessage sends a message to the target user(s) using the typed event
+// system. Target groups are expanded to their members at send time.
+func (s *NotificationService) BroadcastMessage(req types.NotifyRequest) {
 	notification := types.Notification{
-		Id:        uuid.New().String(),
-		From:      req.FromUsername,
-		Message:   req.Message,
-		Timestamp: time.Now(),
+		Id:          uuid.New().String(),
+		From:        req.FromUsername,
+		Message:     req.Message,
+		Timestamp:   time.Now(),
+		ReadReceipt: req.ReadReceipt,
+		Priority:    req.Priority,
 	}
 
-	if req.TargetUsername == "all" {
-		s.broadcastEventLocked(types.EventTypeNotification, notification, []string{})
-	} else {
-		s.broadcastEventLocked(types.EventTypeNotification, notification, []string{req.TargetUsername})
+	recipients := []string{}
+	if req.TargetUsername != "all" {
+		// An empty recipient list would mean everyone, so never send one
+		var err error
+		recipients, err = s.ResolveRecipients([]string{req.TargetUsername}, req.TargetGroups)
+		if err != nil {
+			log.Printf("Not sending notification from %s: %v", req.FromUsername, err)
+			return
+		}
 	}
+
+	// Record in the inbox first so offline recipients can catch up from history
+	if err := s.inbox.Add(recipients, notification); err != nil {
+		log.Printf("Error storing notification %s: %v", notification.Id, err)
+	}
+
+	s.publishPriority(types.EventTypeNotification, notification, recipients, req.Priority)
 }
 
-// CreateAcknowledgmentRequest creates an acknowledgment request and broadcasts it
-func (s *NotificationService) CreateAcknowledgmentRequest(fromUsername string, toUsernames []string, message string) string {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// ListNotifications returns a page of the user's notification history, newest first
+func (s *NotificationService) ListNotifications(username string, cursor string, limit int) ([]types.InboxItem, string, error) {
+	return s.inbox.List(username, cursor, limit)
+}
 
-	requestID := uuid.New().String()
-	req := &types.AcknowledgmentRequest{
-		ID:           requestID,
-		FromUsername: fromUsername,
-		ToUsernames:  toUsernames,
-		Message:      message,
-		CreatedAt:    time.Now(),
+// MarkNotificationRead marks a notification as read for username and returns
+// the new unread count. The user's other connections get a notification_read
+// event, and the sender gets a read receipt if one was requested.
+func (s *NotificationService) MarkNotificationRead(username string, id string) (int, error) {
+	item, changed, err := s.inbox.MarkRead(username, id, time.Now())
+	if err != nil {
+		return 0, err
 	}
 
-	s.acknowledgmentReqs[requestID] = req
-	s.acknowledgmentAckd[requestID] = []string{}
-
-	payload := types.AcknowledgmentRequestPayload{
-		ID:           req.ID,
-		FromUsername: req.FromUsername,
-		ToUsernames:  req.ToUsernames,
-		Message:      req.Message,
+	unread, err := s.inbox.UnreadCount(username)
+	if err != nil {
+		return 0, err
 	}
 
-	s.broadcastEventLocked(types.EventTypeAcknowledgmentRequest, payload, toUsernames)
+	if changed {
+		s.publish(types.EventTypeNotificationRead, types.NotificationReadPayload{
+			NotificationIDs: []string{id},
+			UnreadCount:     unread,
+		}, []string{username})
+
+		s.sendReadReceipts(username, []types.InboxItem{item})
+	}
 
-	return requestID
+	return unread, nil
 }
 
-// RecordAcknowledgment records that a user acknowledged a request
-func (s *NotificationService) RecordAcknowledgment(requestID string, fromUsername string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// MarkAllNotificationsRead marks every notification as read for username and
+// returns the number of notifications that changed
+func (s *NotificationService) MarkAllNotificationsRead(username string) (int, error) {
+	items, err := s.inbox.MarkAllRead(username, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if len(items) > 0 {
+		ids := make([]string, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.Id)
+		}
 
-	if req, ok := s.acknowledgmentReqs[requestID]; ok {
-		// Add to acknowledged list
-		s.acknowledgmentAckd[requestID] = append(s.acknowledgmentAckd[requestID], fromUsername)
+		s.publish(types.EventTypeNotificationRead, types.NotificationReadPayload{
+			NotificationIDs: ids,
+			UnreadCount:     0,
+		}, []string{username})
 
-		payload := types.AcknowledgmentResponsePayload{
-			RequestID:    requestID,
-			FromUsername: fromUsername,
+		s.sendReadReceipts(username, items)
+	}
+
+	return len(items), nil
+}
+
+// UnreadCount returns the number of unread notifications for username
+func (s *NotificationService) UnreadCount(username string) (int, error) {
+	return s.inbox.UnreadCount(username)
+}
+
+// sendReadReceipts notifies the senders of items that requested a read receipt
+func (s *NotificationService) sendReadReceipts(reader string, items []types.InboxItem) {
+	for _, item := range items {
+		if !item.ReadReceipt || item.From == reader {
+			continue
 		}
 
-		// Send response to the requester only
-		s.broadcastEventLocked(types.EventTypeAcknowledgmentResponse, payload, []string{req.FromUsername})
+		s.publish(types.EventTypeReadReceipt, types.ReadReceiptPayload{
+			NotificationID: item.Id,
+			Username:       reader,
+			ReadAt:         *item.ReadAt,
+		}, []string{item.From})
 	}
 }
 
-// broadcastEventLocked broadcasts a typed SSE event to specified users (must be called with mu locked)
-func (s *NotificationService) broadcastEventLocked(eventType types.EventType, payload interface{}, targetUsers []string) {
-	event := types.SSEEvent{
-		Type:      eventType,
-		Payload:   payload,
-		Timestamp: time.Now(),
+// publish sends a typed SSE event to the specified users through the broker.
+// It must not be called with mu locked, as the broker may deliver synchronously.
+func (s *NotificationService) publish(eventType types.EventType, payload interface{}, targetUsers []string) {
+	s.publishPriority(eventType, payload, targetUsers, "")
+}
+
+// publishPriority is publish for an event with a priority other than normal
+func (s *NotificationService) publishPriority(eventType types.EventType, payload interface{}, targetUsers []string, priority types.Priority) {
+	msg := Message{
+		Event: types.SSEEvent{
+			Type:      eventType,
+			Payload:   payload,
+			Timestamp: time.Now(),
+			Priority:  priority,
+		},
+		Recipients: targetUsers,
 	}
 
-	eventPayload, err := json.Marshal(event)
+	if err := s.broker.Publish(context.Background(), msg); err != nil {
+		log.Printf("Error publishing %s event: %v", eventType, err)
+	}
+}
+
+// deliver is the broker subscription handler. It hands an event to the local
+// clients of its recipients, or applies a do-not-disturb change.
+func (s *NotificationService) deliver(msg Message) {
+	if msg.DoNotDisturb != nil {
+		s.applyDoNotDisturb(*msg.DoNotDisturb)
+		return
+	}
+
+	data, payload, err := encodeEvent(msg.Event)
 	if err != nil {
 		log.Printf("Error marshaling event: %v", err)
 		return
 	}
 
-	eventStr := string(eventPayload)
+	encoded := Event{
+		Type:      msg.Event.Type,
+		Data:      data,
+		Payload:   payload,
+		Timestamp: msg.Event.Timestamp,
+		Priority:  msg.Event.Priority,
+	}
 
-	// Determine who receives the event
-	recipients := make(map[string]bool)
-	if len(targetUsers) == 0 {
-		// Broadcast to all connected clients
-		for username := range s.clients {
-			recipients[username] = true
+	// Fan out one shard at a time. Users with a stream are included even when
+	// offline so the event can be replayed when they reconnect.
+	var spills []spilledEvent
+	if len(msg.Recipients) == 0 {
+		// Broadcast to all known users
+		for i := range s.registry.shards {
+			shard := &s.registry.shards[i]
+			shard.mu.Lock()
+			for username := range shard.streams {
+				spills = append(spills, s.deliverLocked(shard, username, encoded)...)
+			}
+			shard.mu.Unlock()
 		}
 	} else {
-		// Send to specific users
-		for _, username := range targetUsers {
-			if _, ok := s.clients[username]; ok {
-				recipients[username] = true
+		// Send to specific users, each at most once
+		for shard, usernames := range s.registry.group(msg.Recipients) {
+			shard.mu.Lock()
+			seen := make(map[string]bool, len(usernames))
+			for _, username := range usernames {
+				if seen[username] {
+					continue
+				}
+				seen[username] = true
+				if _, ok := shard.streams[username]; ok {
+					spills = append(spills, s.deliverLocked(shard, username, encoded)...)
+				}
 			}
+			shard.mu.Unlock()
 		}
 	}
 
-	// Send to all recipients
-	for username, ch := range s.clients {
-		if recipients[username] {
-			select {
-			case ch <- eventStr:
-			default:
-				log.Printf("Channel full for user %s, skipping event", username)
-			}
+	// Inbox writes may hit the disk, so they happen outside the locks
+	for _, spilled := range spills {
+		s.spill(spilled.username, spilled.event)
+	}
+}
+
+// spilledEvent is an event that has to be recorded in a user's inbox because
+// it did not fit in a slow connection's buffer
+type spilledEvent struct {
+	username string
+	event    Event
+}
+
+// deliverLocked assigns the user's next event ID to event, buffers it for
+// replay and hands it to every open connection. Notifications other than
+// critical ones are held back while the user has do-not-disturb on; they are
+// in the inbox already. It returns the events that have to be spilled to the
+// user's inbox, each at most once (must be called with shard locked).
+func (s *NotificationService) deliverLocked(shard *registryShard, username string, encoded Event) []spilledEvent {
+	if encoded.Type == types.EventTypeNotification && encoded.Priority != types.PriorityCritical && s.doNotDisturb(username) {
+		return nil
+	}
+
+	event := shard.streams[username].append(encoded)
+
+	var spills []spilledEvent
+	for _, client := range shard.clients[username] {
+		lost, ok := s.enqueueLocked(client, event)
+		if !ok || slices.ContainsFunc(spills, func(sp spilledEvent) bool { return sp.event.ID == lost.ID }) {
+			continue
+		}
+		spills = append(spills, spilledEvent{username: username, event: lost})
+	}
+	return spills
+}
+
+// encodeEvent returns the JSON envelope of an event and its JSON payload. The
+// payload is encoded once and embedded in the envelope as is.
+func encodeEvent(event types.SSEEvent) (string, string, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return "", "", err
+	}
+
+	event.Payload = json.RawMessage(payload)
+	envelope, err := json.Marshal(event)
+	if err != nil {
+		return "", "", err
+	}
+	return string(envelope), string(payload), nil
+}
+
+// append assigns the next event ID to event and records it in the replay buffer.
+func (u *userStream) append(event Event) Event {
+	u.lastID++
+	event.ID = u.lastID
+
+	u.buffer = append(u.buffer, event)
+	if len(u.buffer) > replayBufferSize {
+		u.buffer = u.buffer[len(u.buffer)-replayBufferSize:]
+	}
+	return event
+}
+
+// lastActive returns when the stream was last used: its newest event, or
+// when the user's last connection closed if that was later
+func (u *userStream) lastActive() time.Time {
+	if n := len(u.buffer); n > 0 && u.buffer[n-1].Timestamp.After(u.idleSince) {
+		return u.buffer[n-1].Timestamp
+	}
+	return u.idleSince
+}
+
+// since returns the buffered events with an ID greater than lastID. An ID
+// beyond the stream's newest one was issued by an earlier stream, e.g. before
+// the server restarted, so the whole buffer is returned.
+func (u *userStream) since(lastID uint64) []Event {
+	if lastID > u.lastID {
+		log.Printf("Event ID %d is ahead of the stream's newest %d, replaying the whole buffer", lastID, u.lastID)
+		return append([]Event{}, u.buffer...)
+	}
+	if len(u.buffer) > 0 && u.buffer[0].ID > lastID+1 {
+		log.Printf("Replay buffer no longer holds events %d-%d", lastID+1, u.buffer[0].ID-1)
+	}
+
+	missed := []Event{}
+	for _, event := range u.buffer {
+		if event.ID > lastID {
+			missed = append(missed, event)
 		}
 	}
+	return missed
 }
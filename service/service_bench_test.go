@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sse-demo/types"
+	"sync"
+	"testing"
+	"time"
+)
+
+// benchConnections is the number of simulated connections in the benchmarks
+const benchConnections = 10000
+
+// benchBroker is a MemoryBroker that drops presence events, so connecting
+// thousands of users does not broadcast thousands of user_connected events
+type benchBroker struct {
+	*MemoryBroker
+}
+
+func (b benchBroker) Publish(ctx context.Context, msg Message) error {
+	switch msg.Event.Type {
+	case types.EventTypeUserConnected, types.EventTypeUserDisconnected:
+		return nil
+	}
+	return b.MemoryBroker.Publish(ctx, msg)
+}
+
+// newBenchService creates a service with n connected users. Each connection
+// is drained by its own goroutine, like the SSE handler would.
+func newBenchService(b *testing.B, n int) *NotificationService {
+	s, err := NewNotificationService(Options{
+		Broker:     benchBroker{NewMemoryBroker()},
+		BufferSize: 64,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	clients := make([]*Client, n)
+	for i := range clients {
+		client, _ := s.AddClient(benchUsername(i), nil)
+		clients[i] = client
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range client.Events.Ready() {
+				for _, ok := client.Events.Pop(); ok; _, ok = client.Events.Pop() {
+				}
+			}
+		}()
+	}
+
+	b.Cleanup(func() {
+		for _, client := range clients {
+			s.RemoveClient(client)
+		}
+		wg.Wait()
+		s.Close()
+	})
+	return s
+}
+
+func benchUsername(i int) string {
+	return fmt.Sprintf("user%d", i)
+}
+
+func benchNotification() types.Notification {
+	return types.Notification{
+		Id:        "bench",
+		From:      "bench",
+		Message:   "Deploy finished",
+		Timestamp: time.Now(),
+	}
+}
+
+// BenchmarkBroadcast measures one broadcast fanned out to every connection
+func BenchmarkBroadcast(b *testing.B) {
+	s := newBenchService(b, benchConnections)
+	notification := benchNotification()
+
+	b.ResetTimer()
+	for range b.N {
+		s.publish(types.EventTypeNotification, notification, nil)
+	}
+	b.ReportMetric(float64(b.N*benchConnections)/b.Elapsed().Seconds(), "deliveries/s")
+}
+
+// BenchmarkTargetedParallel measures concurrent single-user sends, which only
+// contend when they land on the same registry shard
+func BenchmarkTargetedParallel(b *testing.B) {
+	s := newBenchService(b, benchConnections)
+	notification := benchNotification()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			target := benchUsername(rand.IntN(benchConnections))
+			s.publish(types.EventTypeNotification, notification, []string{target})
+		}
+	})
+}
+
+// BenchmarkConnectDuringBroadcast measures connecting and disconnecting while
+// broadcasts to every connection run continuously in the background
+func BenchmarkConnectDuringBroadcast(b *testing.B) {
+	s := newBenchService(b, benchConnections)
+	notification := benchNotification()
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				s.publish(types.EventTypeNotification, notification, nil)
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	for i := range b.N {
+		client, _ := s.AddClient(benchUsername(benchConnections+i), nil)
+		s.RemoveClient(client)
+	}
+	b.StopTimer()
+
+	close(stop)
+	<-done
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"io"
+	"log"
+	"os"
+	"sse-demo/types"
+	"testing"
+	"time"
+)
+
+// TestMain silences the log for the package's tests, as the service logs
+// every connection, and restores it afterwards
+func TestMain(m *testing.M) {
+	out := log.Writer()
+	log.SetOutput(io.Discard)
+	code := m.Run()
+	log.SetOutput(out)
+	os.Exit(code)
+}
+
+func TestPruneStreamsRemovesIdleOfflineUsers(t *testing.T) {
+	s, err := NewNotificationService(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	online, _ := s.AddClient("alice", nil)
+	defer s.RemoveClient(online)
+	gone, _ := s.AddClient("bob", nil)
+	s.RemoveClient(gone)
+	s.publish(types.EventTypeNotification, "hello", []string{"alice", "bob"})
+
+	hasStream := func(username string) bool {
+		shard := s.registry.shard(username)
+		shard.mu.Lock()
+		defer shard.mu.Unlock()
+		_, ok := shard.streams[username]
+		return ok
+	}
+
+	steps := []struct {
+		name  string
+		at    time.Time
+		alice bool
+		bob   bool
+	}{
+		{"within the retention", time.Now().Add(streamRetention - time.Minute), true, true},
+		{"after the retention", time.Now().Add(streamRetention + time.Minute), true, false},
+	}
+	for _, step := range steps {
+		s.pruneStreams(step.at)
+		if got := hasStream("alice"); got != step.alice {
+			t.Errorf("%s: alice's stream kept = %v, want %v", step.name, got, step.alice)
+		}
+		if got := hasStream("bob"); got != step.bob {
+			t.Errorf("%s: bob's stream kept = %v, want %v", step.name, got, step.bob)
+		}
+	}
+
+	// Coming back starts a new stream that receives events again
+	back, _ := s.AddClient("bob", nil)
+	defer s.RemoveClient(back)
+	s.publish(types.EventTypeNotification, "welcome back", []string{"bob"})
+	received := false
+	for event, ok := back.Events.Pop(); ok; event, ok = back.Events.Pop() {
+		received = received || event.Payload == `"welcome back"`
+	}
+	if !received {
+		t.Error("returning user was not sent the new notification")
+	}
+}
+
+func TestReplayAfterRestartSendsWholeBuffer(t *testing.T) {
+	// A fresh service stands in for the server after a restart
+	s, err := NewNotificationService(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	first, _ := s.AddClient("bob", nil)
+	s.publish(types.EventTypeNotification, "since the restart", []string{"bob"})
+	s.RemoveClient(first)
+
+	tests := []struct {
+		name        string
+		lastEventID uint64
+		wantReplay  bool
+	}{
+		{"ID of the current stream", 2, false},
+		{"ID from before the restart", 500, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, missed := s.AddClient("bob", &tt.lastEventID)
+			defer s.RemoveClient(client)
+
+			replayed := false
+			for _, event := range missed {
+				replayed = replayed || event.Payload == `"since the restart"`
+			}
+			if replayed != tt.wantReplay {
+				t.Errorf("reconnecting after ID %d replayed the notification = %v, want %v (missed %d event(s))", tt.lastEventID, replayed, tt.wantReplay, len(missed))
+			}
+		})
+	}
+}
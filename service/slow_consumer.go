@@ -0,0 +1,149 @@
+package service
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"log"
+	"sse-demo/types"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SlowConsumerPolicy decides what happens to an event when a connection's
+// buffer is full because the client is not reading fast enough
+type SlowConsumerPolicy string
+
+const (
+	// PolicyDropNewest discards the incoming event
+	PolicyDropNewest SlowConsumerPolicy = "drop-newest"
+
+	// PolicyDropOldest discards the oldest buffered event to make room
+	PolicyDropOldest SlowConsumerPolicy = "drop-oldest"
+
+	// PolicyDisconnect closes the connection with a disconnect event; the
+	// client can reconnect and catch up through Last-Event-ID replay
+	PolicyDisconnect SlowConsumerPolicy = "disconnect"
+
+	// PolicySpill skips the live delivery and records the event in the
+	// user's inbox, where it can be fetched through the notification history
+	PolicySpill SlowConsumerPolicy = "spill"
+)
+
+// defaultBufferSize is the per-connection event buffer used when none is configured
+const defaultBufferSize = 10
+
+// droppedEvents counts the events that did not reach a connection live, keyed by policy
+var droppedEvents = expvar.NewMap("sse_dropped_events")
+
+// valid reports whether p is a known policy
+func (p SlowConsumerPolicy) valid() bool {
+	switch p {
+	case PolicyDropNewest, PolicyDropOldest, PolicyDisconnect, PolicySpill:
+		return true
+	}
+	return false
+}
+
+// enqueueLocked hands event to a connection, applying the slow-consumer policy
+// if its buffer is full. The policies sacrifice the least urgent event, so an
+// event may displace a queued one of lower priority. It returns the event that
+// has to be spilled to the user's inbox, if any (must be called with the
+// user's shard locked).
+func (s *NotificationService) enqueueLocked(client *Client, event Event) (Event, bool) {
+	if client.dropped || client.Events.push(event) {
+		return Event{}, false
+	}
+
+	rank := event.Priority.Rank()
+
+	switch s.policy {
+	case PolicyDropOldest:
+		// Make room by dropping the oldest of the least urgent events, unless
+		// they are all more urgent than the incoming one. The reader only ever
+		// frees space, so the push after a successful evict goes through.
+		if _, ok := client.Events.evict(rank, true); ok && client.Events.push(event) {
+			droppedEvents.Add(string(s.policy), 1)
+			log.Printf("Channel full for user %s (connection %s), dropped oldest event", client.Username, client.ID)
+			return Event{}, false
+		}
+		droppedEvents.Add(string(s.policy), 1)
+		log.Printf("Channel full for user %s (connection %s), skipping %s event", client.Username, client.ID, event.Priority)
+		return Event{}, false
+
+	case PolicyDisconnect:
+		// Everything still buffered is lost along with the incoming event
+		droppedEvents.Add(string(s.policy), int64(client.Events.Len()+1))
+		log.Printf("Channel full for user %s (connection %s), disconnecting slow client", client.Username, client.ID)
+		s.dropClientLocked(client, "slow_consumer")
+		return Event{}, false
+
+	case PolicySpill:
+		// A less urgent queued event goes to the inbox in the incoming one's place
+		lost := event
+		if displaced, ok := client.Events.evict(rank-1, false); ok && client.Events.push(event) {
+			lost = displaced
+		}
+		droppedEvents.Add(string(s.policy), 1)
+		log.Printf("Channel full for user %s (connection %s), spilling %s event to inbox", client.Username, client.ID, lost.Type)
+		return lost, true
+
+	default:
+		// Drop the newest of the less urgent events, or else the incoming one
+		if _, ok := client.Events.evict(rank-1, false); ok && client.Events.push(event) {
+			droppedEvents.Add(string(s.policy), 1)
+			log.Printf("Channel full for user %s (connection %s), dropped a less urgent event", client.Username, client.ID)
+			return Event{}, false
+		}
+		droppedEvents.Add(string(s.policy), 1)
+		log.Printf("Channel full for user %s (connection %s), skipping event", client.Username, client.ID)
+		return Event{}, false
+	}
+}
+
+// dropClientLocked stops deliveries to a connection and signals its reader to
+// close it with a disconnect event. The reader still calls RemoveClient, which
+// takes care of presence (must be called with the user's shard locked).
+func (s *NotificationService) dropClientLocked(client *Client, reason string) {
+	client.dropped = true
+
+	event := types.SSEEvent{
+		Type:      types.EventTypeDisconnect,
+		Payload:   types.DisconnectPayload{Reason: reason},
+		Timestamp: time.Now(),
+	}
+	envelope, payload, err := encodeEvent(event)
+	if err != nil {
+		log.Printf("Error marshaling disconnect event: %v", err)
+	}
+	client.CloseEvent = Event{
+		Type:      event.Type,
+		Data:      envelope,
+		Payload:   payload,
+		Timestamp: event.Timestamp,
+	}
+	close(client.Closed)
+}
+
+// spill records events that did not fit in a slow connection's buffer in the
+// user's inbox. Notifications are stored there already, and presence can be
+// read from GetConnectedUsers, so those are not duplicated.
+func (s *NotificationService) spill(username string, event Event) {
+	switch event.Type {
+	case types.EventTypeNotification, types.EventTypeUserConnected, types.EventTypeUserDisconnected:
+		return
+	}
+
+	notification := types.Notification{
+		Id:        uuid.New().String(),
+		From:      "system",
+		Message:   fmt.Sprintf("Missed %s event", event.Type),
+		Timestamp: event.Timestamp,
+		EventType: event.Type,
+		Payload:   json.RawMessage(event.Payload),
+	}
+	if err := s.inbox.Add([]string{username}, notification); err != nil {
+		log.Printf("Error spilling %s event for %s: %v", event.Type, username, err)
+	}
+}
@@ -1,6 +1,9 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // EventType represents the type of SSE event
 type EventType string
@@ -11,28 +14,115 @@ const (
 	EventTypeUserDisconnected      EventType = "user_disconnected"
 	EventTypeAcknowledgmentRequest EventType = "acknowledgment_request"
 	EventTypeAcknowledgmentResponse EventType = "acknowledgment_response"
+	EventTypeAcknowledgmentCompleted EventType = "acknowledgment_completed"
+	EventTypeAcknowledgmentExpired  EventType = "acknowledgment_expired"
+	EventTypeNotificationRead       EventType = "notification_read"
+	EventTypeReadReceipt            EventType = "read_receipt"
+	EventTypeDisconnect             EventType = "disconnect"
+	EventTypeActionResult           EventType = "action_result"
+	EventTypeChannelJoined          EventType = "channel_joined"
+	EventTypeChannelLeft            EventType = "channel_left"
+)
+
+// Priority is how urgently an event should reach its recipients
+type Priority string
+
+const (
+	PriorityLow      Priority = "low"
+	PriorityNormal   Priority = "normal"
+	PriorityHigh     Priority = "high"
+	PriorityCritical Priority = "critical" // Delivered even during do-not-disturb
 )
 
+// Valid reports whether p is a known priority. Empty counts as normal.
+func (p Priority) Valid() bool {
+	switch p {
+	case "", PriorityLow, PriorityNormal, PriorityHigh, PriorityCritical:
+		return true
+	}
+	return false
+}
+
+// Rank orders priorities from low (0) to critical (3). Empty and unknown
+// priorities rank as normal.
+func (p Priority) Rank() int {
+	switch p {
+	case PriorityLow:
+		return 0
+	case PriorityHigh:
+		return 2
+	case PriorityCritical:
+		return 3
+	default:
+		return 1
+	}
+}
+
 // SSEEvent represents a Server-Sent Event with type information
 type SSEEvent struct {
 	Type      EventType   `json:"type"`
 	Payload   interface{} `json:"payload"`
 	Timestamp time.Time   `json:"timestamp"`
+	Priority  Priority    `json:"priority,omitempty"` // Empty for normal
 }
 
 // NotifyRequest represents a request to send a notification
 type NotifyRequest struct {
 	FromUsername   string `json:"from_username"`
 	Message        string `json:"message"`
-	TargetUsername string `json:"target_username"` // A specific username or 'all'
+	TargetUsername string   `json:"target_username"`         // A specific username or 'all'
+	TargetGroups   []string `json:"target_groups,omitempty"` // Groups whose members also receive it, expanded at send time
+	ReadReceipt    bool     `json:"read_receipt"`            // Notify the sender when a recipient reads it
+	Priority       Priority `json:"priority,omitempty"`      // Defaults to normal
 }
 
 // Notification represents a notification message
 type Notification struct {
-	Id        string    `json:"id"`
-	From      string    `json:"from"`
-	Message   string    `json:"message"`
-	Timestamp time.Time `json:"timestamp"`
+	Id          string    `json:"id"`
+	From        string    `json:"from"`
+	Message     string    `json:"message"`
+	Timestamp   time.Time `json:"timestamp"`
+	ReadReceipt bool      `json:"read_receipt,omitempty"`
+	Channel     string    `json:"channel,omitempty"` // Set when published to a channel
+	Priority    Priority  `json:"priority,omitempty"`
+
+	// Set when the notification holds an event spilled from a slow connection
+	EventType EventType       `json:"event_type,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+// DoNotDisturb holds back a user's live notifications, except critical ones,
+// while it is active. The notifications still reach the user's inbox.
+type DoNotDisturb struct {
+	Enabled bool       `json:"enabled"`
+	Until   *time.Time `json:"until,omitempty"` // Ends on its own at this time; unset lasts until turned off
+}
+
+// Active reports whether do-not-disturb is in effect at now
+func (d DoNotDisturb) Active(now time.Time) bool {
+	return d.Enabled && (d.Until == nil || now.Before(*d.Until))
+}
+
+// InboxItem is a notification stored in a user's inbox with its read state
+type InboxItem struct {
+	Notification
+	Read   bool       `json:"read"`
+	ReadAt *time.Time `json:"read_at,omitempty"`
+}
+
+// NotificationReadPayload represents a notification_read SSE event, sent to
+// the reader's own connections so every tab can update its unread badge
+type NotificationReadPayload struct {
+	NotificationIDs []string `json:"notification_ids"`
+	UnreadCount     int      `json:"unread_count"`
+}
+
+// ReadReceiptPayload represents a read_receipt SSE event sent to the sender
+// of a notification that requested one
+type ReadReceiptPayload struct {
+	NotificationID string    `json:"notification_id"`
+	Username       string    `json:"username"`
+	ReadAt         time.Time `json:"read_at"`
 }
 
 // UserConnectedPayload represents a user_connected SSE event
@@ -45,31 +135,184 @@ type UserDisconnectedPayload struct {
 	Username string `json:"username"`
 }
 
+// Channel is a named group of users that messages can be published to
+type Channel struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+// Group is a named, server-managed list of users that notifications and
+// acknowledgment requests can be addressed to
+type Group struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Members     []string  `json:"members"`
+	CreatedBy   string    `json:"created_by"` // Only the creator may change or delete the group
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ScheduledKind is what a scheduled send sends
+type ScheduledKind string
+
+const (
+	ScheduledKindNotification   ScheduledKind = "notification"
+	ScheduledKindAcknowledgment ScheduledKind = "acknowledgment"
+)
+
+// ScheduledSend is a notification or acknowledgment request waiting for its
+// send time. Exactly one of Notification and Acknowledgment is set, matching Kind.
+type ScheduledSend struct {
+	ID             string              `json:"id"`
+	Kind           ScheduledKind       `json:"kind"`
+	SendAt         time.Time           `json:"send_at"`
+	CreatedBy      string              `json:"created_by"` // Session user who scheduled it; may differ from the sender when impersonating
+	CreatedAt      time.Time           `json:"created_at"`
+	Notification   *NotifyRequest      `json:"notification,omitempty"`
+	Acknowledgment *AcknowledgeRequest `json:"acknowledgment,omitempty"`
+}
+
+// RecurringSchedule sends a notification or acknowledgment request on every
+// tick of a cron expression, evaluated in TimeZone. Exactly one of
+// Notification and Acknowledgment is set, matching Kind.
+type RecurringSchedule struct {
+	ID             string              `json:"id"`
+	Kind           ScheduledKind       `json:"kind"`
+	Cron           string              `json:"cron"`      // Standard five-field expression or descriptor such as @daily
+	TimeZone       string              `json:"time_zone"` // IANA name, e.g. Europe/Berlin
+	Paused         bool                `json:"paused,omitempty"`
+	DeadlineAfter  time.Duration       `json:"deadline_after,omitempty"` // Deadline of each acknowledgment request, relative to its run
+	CreatedBy      string              `json:"created_by"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	Notification   *NotifyRequest      `json:"notification,omitempty"`
+	Acknowledgment *AcknowledgeRequest `json:"acknowledgment,omitempty"`
+}
+
+// RecurringRun records one execution of a recurring schedule
+type RecurringRun struct {
+	At        time.Time `json:"at"`
+	RequestID string    `json:"request_id,omitempty"` // Acknowledgment request the run created
+	Error     string    `json:"error,omitempty"`      // Why the run sent nothing
+}
+
+// ChannelMembershipPayload represents a channel_joined or channel_left SSE
+// event, sent to the channel's members and the user who joined or left
+type ChannelMembershipPayload struct {
+	Channel  string `json:"channel"`
+	Username string `json:"username"`
+}
+
+// DisconnectPayload is sent on a connection just before the server closes it
+type DisconnectPayload struct {
+	Reason string `json:"reason"`
+}
+
+// ActionResultPayload answers an action sent over a WebSocket connection
+type ActionResultPayload struct {
+	Ref    string      `json:"ref,omitempty"` // Echoes the ref of the action
+	Action string      `json:"action"`
+	OK     bool        `json:"ok"`
+	Error  string      `json:"error,omitempty"`
+	Result interface{} `json:"result,omitempty"` // Response body of the matching REST endpoint
+}
+
+// AcknowledgmentStatus is the lifecycle state of an acknowledgment request
+type AcknowledgmentStatus string
+
+const (
+	AcknowledgmentStatusPending   AcknowledgmentStatus = "pending"
+	AcknowledgmentStatusCompleted AcknowledgmentStatus = "completed"
+	AcknowledgmentStatusExpired   AcknowledgmentStatus = "expired"
+)
+
+// AcknowledgeRequest represents a request to create an acknowledgment request
+type AcknowledgeRequest struct {
+	FromUsername string     `json:"from_username"`
+	ToUsernames  []string   `json:"to_usernames"`
+	ToGroups     []string   `json:"to_groups,omitempty"` // Groups whose members also receive it
+	Message      string     `json:"message"`
+	Options      []string   `json:"options,omitempty"`  // Choices offered to recipients; empty for a plain acknowledgment
+	Deadline     *time.Time `json:"deadline,omitempty"` // When the request expires
+}
+
 // AcknowledgmentRequest represents an acknowledgment request
 type AcknowledgmentRequest struct {
-	ID           string    `json:"id"`
-	FromUsername string    `json:"from_username"`
-	ToUsernames  []string  `json:"to_usernames"`
-	Message      string    `json:"message"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           string               `json:"id"`
+	FromUsername string               `json:"from_username"`
+	ToUsernames  []string             `json:"to_usernames"`        // Resolved recipients, including group members
+	ToGroups     []string             `json:"to_groups,omitempty"` // Groups the request was addressed to
+	Message      string               `json:"message"`
+	Options      []string             `json:"options,omitempty"`
+	CreatedAt    time.Time            `json:"created_at"`
+	Deadline     *time.Time           `json:"deadline,omitempty"`
+	Status       AcknowledgmentStatus `json:"status"`
+	ClosedAt     *time.Time           `json:"closed_at,omitempty"` // When the request completed or expired
+}
+
+// AcknowledgmentStatusInfo is a snapshot of a request with its responses
+type AcknowledgmentStatusInfo struct {
+	AcknowledgmentRequest
+	Acknowledged []string                 `json:"acknowledged"`
+	Pending      []string                 `json:"pending"`
+	Responses    []AcknowledgmentResponse `json:"responses"`
+}
+
+// AcknowledgmentTally summarises the responses to a request per option
+type AcknowledgmentTally struct {
+	RequestID string                      `json:"request_id"`
+	Options   []AcknowledgmentOptionTally `json:"options"`
+	Responded int                         `json:"responded"`
+	Pending   int                         `json:"pending"`
+}
+
+// AcknowledgmentOptionTally counts the responses that chose one option
+type AcknowledgmentOptionTally struct {
+	Option    string   `json:"option"`
+	Count     int      `json:"count"`
+	Usernames []string `json:"usernames"`
 }
 
 // AcknowledgmentRequestPayload represents the SSE payload for acknowledgment request
 type AcknowledgmentRequestPayload struct {
-	ID           string   `json:"id"`
-	FromUsername string   `json:"from_username"`
-	ToUsernames  []string `json:"to_usernames"`
-	Message      string   `json:"message"`
+	ID           string     `json:"id"`
+	FromUsername string     `json:"from_username"`
+	ToUsernames  []string   `json:"to_usernames"`
+	ToGroups     []string   `json:"to_groups,omitempty"`
+	Message      string     `json:"message"`
+	Options      []string   `json:"options,omitempty"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+}
+
+// AcknowledgmentCompletedPayload represents the SSE payload sent to the
+// requester once every recipient has acknowledged
+type AcknowledgmentCompletedPayload struct {
+	RequestID    string   `json:"request_id"`
+	Acknowledged []string `json:"acknowledged"`
+}
+
+// AcknowledgmentExpiredPayload represents the SSE payload sent to the requester
+// when a request's deadline passes with recipients still pending
+type AcknowledgmentExpiredPayload struct {
+	RequestID    string    `json:"request_id"`
+	Deadline     time.Time `json:"deadline"`
+	Acknowledged []string  `json:"acknowledged"`
+	Pending      []string  `json:"pending"`
 }
 
 // AcknowledgmentResponse represents an acknowledgment response
 type AcknowledgmentResponse struct {
-	RequestID    string `json:"request_id"`
-	FromUsername string `json:"from_username"`
+	RequestID    string    `json:"request_id"`
+	FromUsername string    `json:"from_username"`
+	Option       string    `json:"option,omitempty"`  // Chosen option, if the request offers any
+	Comment      string    `json:"comment,omitempty"` // Optional free-text comment
+	RespondedAt  time.Time `json:"responded_at"`
 }
 
 // AcknowledgmentResponsePayload represents the SSE payload for acknowledgment response
 type AcknowledgmentResponsePayload struct {
 	RequestID    string `json:"request_id"`
 	FromUsername string `json:"from_username"`
+	Option       string `json:"option,omitempty"`
+	Comment      string `json:"comment,omitempty"`
 }
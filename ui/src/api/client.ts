@@ -7,6 +7,76 @@
 import { customInstance } from './axios-instance';
 export interface LoginRequest {
   username: string;
+  password: string;
+}
+
+export interface LoginMethodsResponse {
+  password: boolean;
+  /** Whether single sign-on through /oidc/login is available */
+  oidc: boolean;
+}
+
+export interface SessionResponse {
+  username: string;
+  /** OIDC provider the user logged in through; absent for password logins */
+  issuer?: string;
+  expires_at: string;
+}
+
+export interface RegisterRequest {
+  /** Letters, digits and _ . @ -, starting with a letter or digit; at most 64 characters */
+  username: string;
+  /** Between 8 and 72 bytes */
+  password: string;
+}
+
+/**
+ * notify allows POST /notify; acknowledge allows POST /acknowledge/request
+ */
+export type TokenScope = typeof TokenScope[keyof typeof TokenScope];
+
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const TokenScope = {
+  notify: 'notify',
+  acknowledge: 'acknowledge',
+} as const;
+
+export interface ApiToken {
+  id: string;
+  name: string;
+  /** User the token acts as, and the sender of what it sends */
+  principal: string;
+  scopes: TokenScope[];
+  /** Last characters of the secret */
+  hint: string;
+  created_at: string;
+  expires_at?: string;
+}
+
+export interface CreateTokenRequest {
+  name: string;
+  scopes: TokenScope[];
+  /** User the token acts as; defaults to the current user, and others need the impersonate permission */
+  principal?: string;
+  /** When the token stops working; omit for a token that does not expire */
+  expires_at?: string;
+}
+
+export interface CreateTokenResponse {
+  token: ApiToken;
+  /** The bearer token; it cannot be retrieved again */
+  secret: string;
+}
+
+export interface TokensResponse {
+  tokens: ApiToken[];
+}
+
+export interface ChangePasswordRequest {
+  current_password: string;
+  /** Between 8 and 72 bytes */
+  new_password: string;
 }
 
 export interface LoginResponse {
@@ -18,15 +88,43 @@ export interface LogoutResponse {
   success?: boolean;
 }
 
+/**
+ * How urgently the notification should be delivered; defaults to normal. Higher priorities are delivered ahead of queued lower ones, and critical ones even during do-not-disturb.
+ */
+export type Priority = typeof Priority[keyof typeof Priority];
+
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const Priority = {
+  low: 'low',
+  normal: 'normal',
+  high: 'high',
+  critical: 'critical',
+} as const;
+
+export interface DoNotDisturb {
+  enabled: boolean;
+  /** Turn off on its own at this time; unset keeps it on until it is turned off */
+  until?: string;
+}
+
 export interface NotifyRequest {
-  from_username: string;
-  /** A specific username or 'all' */
-  target_username: string;
+  /** Send as another user; requires the impersonate permission. Defaults to the session user */
+  from_username?: string;
+  /** A specific username or 'all'. Required unless target_groups is set */
+  target_username?: string;
+  /** Groups whose members receive the notification, expanded when it is sent */
+  target_groups?: string[];
   message: string;
+  priority?: Priority;
+  /** Send at this future time instead of now; see /scheduled */
+  send_at?: string;
 }
 
 export interface NotifyResponse {
   success?: boolean;
+  /** ID of the scheduled send, if send_at was given */
+  scheduled_id?: string;
 }
 
 export interface Notification {
@@ -34,6 +132,29 @@ export interface Notification {
   from?: string;
   message?: string;
   timestamp?: string;
+  /** Channel the notification was published to, if any */
+  channel?: string;
+  priority?: Priority;
+}
+
+export interface NotificationsResponse {
+  notifications: Notification[];
+  /** Cursor for the next (older) page; absent on the last page */
+  next_cursor?: string;
+}
+
+export interface PolledEvent {
+  id: number;
+  type: string;
+  payload: unknown;
+  timestamp: string;
+  priority?: Priority;
+}
+
+export interface PollResponse {
+  events: PolledEvent[];
+  /** Pass as after on the next poll */
+  last_id: number;
 }
 
 export interface UsersResponse {
@@ -41,27 +162,257 @@ export interface UsersResponse {
   users?: string[];
 }
 
+export interface ChannelSummary {
+  name: string;
+  member_count: number;
+  /** Whether the current user is a member */
+  joined: boolean;
+}
+
+export interface ChannelsResponse {
+  channels: ChannelSummary[];
+}
+
+export interface ChannelMembershipResponse {
+  success?: boolean;
+  /** Normalized channel name */
+  channel?: string;
+}
+
+export interface ChannelMembersResponse {
+  channel: string;
+  members: string[];
+}
+
+export interface ChannelPublishRequest {
+  message: string;
+  /** Notify the sender when a member reads the notification */
+  read_receipt?: boolean;
+  priority?: Priority;
+}
+
+export interface ChannelPublishResponse {
+  success?: boolean;
+  /** ID of the notification */
+  id?: string;
+  /** Number of members it was sent to */
+  recipients?: number;
+}
+
+export interface Group {
+  name: string;
+  description?: string;
+  members: string[];
+  /** Only the creator may change or delete the group */
+  created_by: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface GroupsResponse {
+  groups: Group[];
+}
+
+export interface GroupCreateRequest {
+  /** Lowercase letters, digits, '.', '_' and '-'; normalized to lowercase */
+  name: string;
+  description?: string;
+  members?: string[];
+}
+
+export interface GroupUpdateRequest {
+  description?: string;
+  /** Replaces the member list when set */
+  members?: string[];
+}
+
+export interface GroupMembersRequest {
+  usernames: string[];
+}
+
+export type ScheduledSendKind = typeof ScheduledSendKind[keyof typeof ScheduledSendKind];
+
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const ScheduledSendKind = {
+  notification: 'notification',
+  acknowledgment: 'acknowledgment',
+} as const;
+
+export interface ScheduledSend {
+  id: string;
+  kind: ScheduledSendKind;
+  send_at: string;
+  created_by: string;
+  created_at: string;
+  notification?: NotifyRequest;
+  acknowledgment?: AcknowledgeRequestPayload;
+}
+
+export interface ScheduledSendsResponse {
+  scheduled: ScheduledSend[];
+}
+
+export interface RecurringScheduleRequest {
+  /** Standard five-field cron expression (minute hour day-of-month month day-of-week) or a descriptor such as @daily or @every 1h */
+  cron: string;
+  /** IANA time zone the expression is evaluated in, e.g. Europe/Berlin; defaults to UTC */
+  time_zone?: string;
+  notification?: NotifyRequest;
+  acknowledgment?: AcknowledgeRequestPayload;
+  /** Seconds after each run that its acknowledgment request expires. Recurring acknowledgment requests take no absolute deadline. */
+  deadline_after?: number;
+}
+
+export interface RecurringScheduleUpdateRequest {
+  cron?: string;
+  time_zone?: string;
+  /** Paused schedules keep their history but do not run */
+  paused?: boolean;
+}
+
+export type RecurringScheduleKind = typeof RecurringScheduleKind[keyof typeof RecurringScheduleKind];
+
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const RecurringScheduleKind = {
+  notification: 'notification',
+  acknowledgment: 'acknowledgment',
+} as const;
+
+export interface RecurringSchedule {
+  id: string;
+  kind: RecurringScheduleKind;
+  cron: string;
+  time_zone: string;
+  paused: boolean;
+  /** When the schedule runs next; absent while paused */
+  next_run?: string;
+  deadline_after?: number;
+  created_by: string;
+  created_at: string;
+  updated_at: string;
+  notification?: NotifyRequest;
+  acknowledgment?: AcknowledgeRequestPayload;
+}
+
+export interface RecurringSchedulesResponse {
+  recurring: RecurringSchedule[];
+}
+
+export interface RecurringRun {
+  at: string;
+  /** Acknowledgment request the run created */
+  request_id?: string;
+  /** Why the run sent nothing, e.g. a group that no longer exists */
+  error?: string;
+}
+
+export interface RecurringRunsResponse {
+  runs: RecurringRun[];
+}
+
 export interface AcknowledgeRequestPayload {
-  /** List of usernames to send acknowledgment request to */
-  to_usernames: string[];
+  /** List of usernames to send acknowledgment request to. Required unless to_groups is set */
+  to_usernames?: string[];
+  /** Groups whose members receive the request; the request records the resolved recipients in to_usernames */
+  to_groups?: string[];
   /** Message for the acknowledgment request */
   message: string;
+  /** Optional choices offered to recipients, e.g. Approve/Reject. Responses must pick one of them. */
+  options?: string[];
+  /** Optional time after which the request expires and the requester is told who did not respond. Requests without one expire after 7 days. */
+  deadline?: string;
+  /** Send at this future time instead of now; see /scheduled. A deadline must come after it. */
+  send_at?: string;
 }
 
 export interface AcknowledgeRequestResponse {
   success?: boolean;
+  /** Absent when the request is scheduled */
   request_id?: string;
+  /** ID of the scheduled send, if send_at was given */
+  scheduled_id?: string;
+  /** Resolved recipients, including the members of to_groups */
+  to_usernames?: string[];
 }
 
 export interface AcknowledgeResponsePayload {
   /** ID of the acknowledgment request being responded to */
   request_id: string;
+  /** Chosen option; required when the request offers options */
+  option?: string;
+  /** Optional free-text comment */
+  comment?: string;
 }
 
 export interface AcknowledgeResponseResponse {
   success?: boolean;
 }
 
+export type GetOidcLoginParams = {
+/**
+ * Passed on to the provider to preselect the account
+ */
+login_hint?: string;
+};
+
+export type GetOidcCallbackParams = {
+code?: string;
+state?: string;
+/**
+ * Set by the provider when the user did not log in
+ */
+error?: string;
+};
+
+export type GetEventsParams = {
+/**
+ * Comma separated event types to receive, e.g. notification,acknowledgment_request; omit for all
+ */
+types?: string[];
+/**
+ * Wire format. "named" sets the SSE event field to the event type and sends the payload as data. "envelope" sends unnamed frames whose data is the {type, payload, timestamp} JSON envelope, as older clients expect.
+ */
+format?: GetEventsFormat;
+};
+
+export type GetEventsFormat = typeof GetEventsFormat[keyof typeof GetEventsFormat];
+
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const GetEventsFormat = {
+  named: 'named',
+  envelope: 'envelope',
+} as const;
+
+export type GetEventsPollParams = {
+/**
+ * last_id of the previous poll. Omit on the first poll to wait for new events only.
+ * @minimum 0
+ */
+after?: number;
+/**
+ * Seconds to wait for an event before returning an empty batch
+ * @minimum 1
+ * @maximum 60
+ */
+timeout?: number;
+};
+
+export type GetNotificationsParams = {
+/**
+ * Cursor from a previous page's next_cursor; omit for the newest notifications
+ */
+cursor?: string;
+/**
+ * Maximum number of notifications to return
+ * @minimum 1
+ * @maximum 100
+ */
+limit?: number;
+};
+
 type SecondParameter<T extends (...args: never) => unknown> = Parameters<T>[1];
 
 
@@ -80,6 +431,122 @@ const postLogin = (
       options);
     }
   
+/**
+ * @summary Lists the ways users can log in
+ */
+const getLoginMethods = (
+    
+ options?: SecondParameter<typeof customInstance<LoginMethodsResponse>>,) => {
+      return customInstance<LoginMethodsResponse>(
+      {url: `/login/methods`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Starts single sign-on by redirecting to the OIDC provider
+ */
+const getOidcLogin = (
+    params?: GetOidcLoginParams,
+ options?: SecondParameter<typeof customInstance<void>>,) => {
+      return customInstance<void>(
+      {url: `/oidc/login`, method: 'GET',
+        params
+    },
+      options);
+    }
+  
+/**
+ * @summary Completes single sign-on; the provider redirects the browser here
+ */
+const getOidcCallback = (
+    params?: GetOidcCallbackParams,
+ options?: SecondParameter<typeof customInstance<void>>,) => {
+      return customInstance<void>(
+      {url: `/oidc/callback`, method: 'GET',
+        params
+    },
+      options);
+    }
+  
+/**
+ * @summary Gets the user the current session belongs to (requires authentication)
+ */
+const getSession = (
+    
+ options?: SecondParameter<typeof customInstance<SessionResponse>>,) => {
+      return customInstance<SessionResponse>(
+      {url: `/session`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Registers a user with a password and logs them in
+ */
+const postRegister = (
+    registerRequest: RegisterRequest,
+ options?: SecondParameter<typeof customInstance<LoginResponse>>,) => {
+      return customInstance<LoginResponse>(
+      {url: `/register`, method: 'POST',
+      headers: {'Content-Type': 'application/json', },
+      data: registerRequest
+    },
+      options);
+    }
+  
+/**
+ * @summary Changes the current user's password and signs out their other sessions (requires authentication)
+ */
+const putPassword = (
+    changePasswordRequest: ChangePasswordRequest,
+ options?: SecondParameter<typeof customInstance<NotifyResponse>>,) => {
+      return customInstance<NotifyResponse>(
+      {url: `/password`, method: 'PUT',
+      headers: {'Content-Type': 'application/json', },
+      data: changePasswordRequest
+    },
+      options);
+    }
+  
+/**
+ * @summary Lists the API tokens the current user created (requires authentication)
+ */
+const getTokens = (
+    
+ options?: SecondParameter<typeof customInstance<TokensResponse>>,) => {
+      return customInstance<TokensResponse>(
+      {url: `/tokens`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Creates an API token for calling the API with Authorization: Bearer (requires authentication)
+ */
+const postToken = (
+    createTokenRequest: CreateTokenRequest,
+ options?: SecondParameter<typeof customInstance<CreateTokenResponse>>,) => {
+      return customInstance<CreateTokenResponse>(
+      {url: `/tokens`, method: 'POST',
+      headers: {'Content-Type': 'application/json', },
+      data: createTokenRequest
+    },
+      options);
+    }
+  
+/**
+ * @summary Revokes an API token; only its creator may (requires authentication)
+ */
+const deleteToken = (
+    id: string,
+ options?: SecondParameter<typeof customInstance<NotifyResponse>>,) => {
+      return customInstance<NotifyResponse>(
+      {url: `/tokens/${id}`, method: 'DELETE'
+    },
+      options);
+    }
+  
 /**
  * @summary Logs a user out and destroys the session
  */
@@ -96,10 +563,24 @@ const postLogout = (
  * @summary Subscribes to the SSE notification stream (requires authentication)
  */
 const getEvents = (
-    
+    params?: GetEventsParams,
  options?: SecondParameter<typeof customInstance<string>>,) => {
       return customInstance<string>(
-      {url: `/events`, method: 'GET'
+      {url: `/events`, method: 'GET',
+        params
+    },
+      options);
+    }
+  
+/**
+ * @summary Long-polls the event stream, for networks that buffer SSE (requires authentication)
+ */
+const getEventsPoll = (
+    params?: GetEventsPollParams,
+ options?: SecondParameter<typeof customInstance<PollResponse>>,) => {
+      return customInstance<PollResponse>(
+      {url: `/events/poll`, method: 'GET',
+        params
     },
       options);
     }
@@ -118,6 +599,45 @@ const postNotify = (
       options);
     }
   
+/**
+ * @summary Lists the user's notification history, newest first (requires authentication)
+ */
+const getNotifications = (
+    params?: GetNotificationsParams,
+ options?: SecondParameter<typeof customInstance<NotificationsResponse>>,) => {
+      return customInstance<NotificationsResponse>(
+      {url: `/notifications`, method: 'GET',
+        params
+    },
+      options);
+    }
+  
+/**
+ * @summary Gets the current user's do-not-disturb setting (requires authentication)
+ */
+const getDoNotDisturb = (
+    
+ options?: SecondParameter<typeof customInstance<DoNotDisturb>>,) => {
+      return customInstance<DoNotDisturb>(
+      {url: `/dnd`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Turns do-not-disturb on or off for the current user. While on, only critical notifications are delivered live; the rest still reach the notification history (requires authentication)
+ */
+const putDoNotDisturb = (
+    doNotDisturb: DoNotDisturb,
+ options?: SecondParameter<typeof customInstance<DoNotDisturb>>,) => {
+      return customInstance<DoNotDisturb>(
+      {url: `/dnd`, method: 'PUT',
+      headers: {'Content-Type': 'application/json', },
+      data: doNotDisturb
+    },
+      options);
+    }
+  
 /**
  * @summary Gets list of currently connected users (requires authentication)
  */
@@ -130,6 +650,264 @@ const getUsers = (
       options);
     }
   
+/**
+ * @summary Lists the channels and their member counts (requires authentication)
+ */
+const getChannels = (
+    
+ options?: SecondParameter<typeof customInstance<ChannelsResponse>>,) => {
+      return customInstance<ChannelsResponse>(
+      {url: `/channels`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Joins a channel, creating it if needed (requires authentication)
+ */
+const postChannelJoin = (
+    name: string,
+ options?: SecondParameter<typeof customInstance<ChannelMembershipResponse>>,) => {
+      return customInstance<ChannelMembershipResponse>(
+      {url: `/channels/${name}/join`, method: 'POST'
+    },
+      options);
+    }
+  
+/**
+ * @summary Leaves a channel (requires authentication)
+ */
+const postChannelLeave = (
+    name: string,
+ options?: SecondParameter<typeof customInstance<ChannelMembershipResponse>>,) => {
+      return customInstance<ChannelMembershipResponse>(
+      {url: `/channels/${name}/leave`, method: 'POST'
+    },
+      options);
+    }
+  
+/**
+ * @summary Lists the members of a channel (requires authentication)
+ */
+const getChannelMembers = (
+    name: string,
+ options?: SecondParameter<typeof customInstance<ChannelMembersResponse>>,) => {
+      return customInstance<ChannelMembersResponse>(
+      {url: `/channels/${name}/members`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * Members receive a notification event with the channel field set. Only members may publish.
+ * @summary Sends a notification to every member of a channel (requires authentication)
+ */
+const postChannelPublish = (
+    name: string,
+    channelPublishRequest: ChannelPublishRequest,
+ options?: SecondParameter<typeof customInstance<ChannelPublishResponse>>,) => {
+      return customInstance<ChannelPublishResponse>(
+      {url: `/channels/${name}/publish`, method: 'POST',
+      headers: {'Content-Type': 'application/json', },
+      data: channelPublishRequest
+    },
+      options);
+    }
+  
+/**
+ * @summary Lists the recipient groups (requires authentication)
+ */
+const getGroups = (
+    
+ options?: SecondParameter<typeof customInstance<GroupsResponse>>,) => {
+      return customInstance<GroupsResponse>(
+      {url: `/groups`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Creates a recipient group owned by the current user (requires authentication)
+ */
+const postGroup = (
+    groupCreateRequest: GroupCreateRequest,
+ options?: SecondParameter<typeof customInstance<Group>>,) => {
+      return customInstance<Group>(
+      {url: `/groups`, method: 'POST',
+      headers: {'Content-Type': 'application/json', },
+      data: groupCreateRequest
+    },
+      options);
+    }
+  
+/**
+ * @summary Gets a recipient group (requires authentication)
+ */
+const getGroup = (
+    name: string,
+ options?: SecondParameter<typeof customInstance<Group>>,) => {
+      return customInstance<Group>(
+      {url: `/groups/${name}`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Replaces a group's description and/or members; only its creator may (requires authentication)
+ */
+const putGroup = (
+    name: string,
+    groupUpdateRequest: GroupUpdateRequest,
+ options?: SecondParameter<typeof customInstance<Group>>,) => {
+      return customInstance<Group>(
+      {url: `/groups/${name}`, method: 'PUT',
+      headers: {'Content-Type': 'application/json', },
+      data: groupUpdateRequest
+    },
+      options);
+    }
+  
+/**
+ * @summary Deletes a group; only its creator may (requires authentication)
+ */
+const deleteGroup = (
+    name: string,
+ options?: SecondParameter<typeof customInstance<NotifyResponse>>,) => {
+      return customInstance<NotifyResponse>(
+      {url: `/groups/${name}`, method: 'DELETE'
+    },
+      options);
+    }
+  
+/**
+ * @summary Adds members to a group; only its creator may (requires authentication)
+ */
+const postGroupMembers = (
+    name: string,
+    groupMembersRequest: GroupMembersRequest,
+ options?: SecondParameter<typeof customInstance<Group>>,) => {
+      return customInstance<Group>(
+      {url: `/groups/${name}/members`, method: 'POST',
+      headers: {'Content-Type': 'application/json', },
+      data: groupMembersRequest
+    },
+      options);
+    }
+  
+/**
+ * @summary Removes a member from a group; only its creator may (requires authentication)
+ */
+const deleteGroupMember = (
+    name: string,
+    username: string,
+ options?: SecondParameter<typeof customInstance<Group>>,) => {
+      return customInstance<Group>(
+      {url: `/groups/${name}/members/${username}`, method: 'DELETE'
+    },
+      options);
+    }
+  
+/**
+ * @summary Lists the current user's pending scheduled sends, soonest first (requires authentication)
+ */
+const getScheduled = (
+    
+ options?: SecondParameter<typeof customInstance<ScheduledSendsResponse>>,) => {
+      return customInstance<ScheduledSendsResponse>(
+      {url: `/scheduled`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Cancels a pending scheduled send (requires authentication)
+ */
+const deleteScheduled = (
+    id: string,
+ options?: SecondParameter<typeof customInstance<NotifyResponse>>,) => {
+      return customInstance<NotifyResponse>(
+      {url: `/scheduled/${id}`, method: 'DELETE'
+    },
+      options);
+    }
+  
+/**
+ * @summary Lists the current user's recurring schedules, oldest first (requires authentication)
+ */
+const getRecurring = (
+    
+ options?: SecondParameter<typeof customInstance<RecurringSchedulesResponse>>,) => {
+      return customInstance<RecurringSchedulesResponse>(
+      {url: `/recurring`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Creates a schedule that sends a notification or acknowledgment request on every tick of a cron expression (requires authentication)
+ */
+const postRecurring = (
+    recurringScheduleRequest: RecurringScheduleRequest,
+ options?: SecondParameter<typeof customInstance<RecurringSchedule>>,) => {
+      return customInstance<RecurringSchedule>(
+      {url: `/recurring`, method: 'POST',
+      headers: {'Content-Type': 'application/json', },
+      data: recurringScheduleRequest
+    },
+      options);
+    }
+  
+/**
+ * @summary Gets a recurring schedule (requires authentication)
+ */
+const getRecurringSchedule = (
+    id: string,
+ options?: SecondParameter<typeof customInstance<RecurringSchedule>>,) => {
+      return customInstance<RecurringSchedule>(
+      {url: `/recurring/${id}`, method: 'GET'
+    },
+      options);
+    }
+  
+/**
+ * @summary Changes a recurring schedule's cron expression, time zone or paused state; only its creator may (requires authentication)
+ */
+const putRecurringSchedule = (
+    id: string,
+    recurringScheduleUpdateRequest: RecurringScheduleUpdateRequest,
+ options?: SecondParameter<typeof customInstance<RecurringSchedule>>,) => {
+      return customInstance<RecurringSchedule>(
+      {url: `/recurring/${id}`, method: 'PUT',
+      headers: {'Content-Type': 'application/json', },
+      data: recurringScheduleUpdateRequest
+    },
+      options);
+    }
+  
+/**
+ * @summary Deletes a recurring schedule and its history; only its creator may (requires authentication)
+ */
+const deleteRecurringSchedule = (
+    id: string,
+ options?: SecondParameter<typeof customInstance<NotifyResponse>>,) => {
+      return customInstance<NotifyResponse>(
+      {url: `/recurring/${id}`, method: 'DELETE'
+    },
+      options);
+    }
+  
+/**
+ * @summary Lists the latest executions of a recurring schedule, newest first (requires authentication)
+ */
+const getRecurringRuns = (
+    id: string,
+ options?: SecondParameter<typeof customInstance<RecurringRunsResponse>>,) => {
+      return customInstance<RecurringRunsResponse>(
+      {url: `/recurring/${id}/runs`, method: 'GET'
+    },
+      options);
+    }
+  
 /**
  * @summary Sends an acknowledgment request to user(s) (requires authentication)
  */
@@ -158,11 +936,44 @@ const postAcknowledgeResponse = (
       options);
     }
   
-return {postLogin,postLogout,getEvents,postNotify,getUsers,postAcknowledgeRequest,postAcknowledgeResponse}};
+return {postLogin,getLoginMethods,getOidcLogin,getOidcCallback,getSession,postRegister,putPassword,getTokens,postToken,deleteToken,postLogout,getEvents,getEventsPoll,postNotify,getNotifications,getUsers,getDoNotDisturb,putDoNotDisturb,getChannels,postChannelJoin,postChannelLeave,getChannelMembers,postChannelPublish,getGroups,postGroup,getGroup,putGroup,deleteGroup,postGroupMembers,deleteGroupMember,getScheduled,deleteScheduled,getRecurring,postRecurring,getRecurringSchedule,putRecurringSchedule,deleteRecurringSchedule,getRecurringRuns,postAcknowledgeRequest,postAcknowledgeResponse}};
 export type PostLoginResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postLogin']>>>
+export type GetLoginMethodsResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getLoginMethods']>>>
+export type GetOidcLoginResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getOidcLogin']>>>
+export type GetOidcCallbackResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getOidcCallback']>>>
+export type GetSessionResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getSession']>>>
+export type PostRegisterResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postRegister']>>>
+export type PutPasswordResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['putPassword']>>>
+export type GetTokensResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getTokens']>>>
+export type PostTokenResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postToken']>>>
+export type DeleteTokenResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['deleteToken']>>>
 export type PostLogoutResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postLogout']>>>
 export type GetEventsResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getEvents']>>>
+export type GetEventsPollResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getEventsPoll']>>>
 export type PostNotifyResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postNotify']>>>
+export type GetNotificationsResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getNotifications']>>>
 export type GetUsersResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getUsers']>>>
+export type GetDoNotDisturbResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getDoNotDisturb']>>>
+export type PutDoNotDisturbResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['putDoNotDisturb']>>>
+export type GetChannelsResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getChannels']>>>
+export type PostChannelJoinResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postChannelJoin']>>>
+export type PostChannelLeaveResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postChannelLeave']>>>
+export type GetChannelMembersResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getChannelMembers']>>>
+export type PostChannelPublishResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postChannelPublish']>>>
+export type GetGroupsResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getGroups']>>>
+export type PostGroupResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postGroup']>>>
+export type GetGroupResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getGroup']>>>
+export type PutGroupResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['putGroup']>>>
+export type DeleteGroupResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['deleteGroup']>>>
+export type PostGroupMembersResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postGroupMembers']>>>
+export type DeleteGroupMemberResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['deleteGroupMember']>>>
+export type GetScheduledResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getScheduled']>>>
+export type DeleteScheduledResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['deleteScheduled']>>>
+export type GetRecurringResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getRecurring']>>>
+export type PostRecurringResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postRecurring']>>>
+export type GetRecurringScheduleResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getRecurringSchedule']>>>
+export type PutRecurringScheduleResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['putRecurringSchedule']>>>
+export type DeleteRecurringScheduleResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['deleteRecurringSchedule']>>>
+export type GetRecurringRunsResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['getRecurringRuns']>>>
 export type PostAcknowledgeRequestResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postAcknowledgeRequest']>>>
 export type PostAcknowledgeResponseResult = NonNullable<Awaited<ReturnType<ReturnType<typeof getSimpleSSENotificationAPI>['postAcknowledgeResponse']>>>
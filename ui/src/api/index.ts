@@ -8,10 +8,43 @@ import { getSimpleSSENotificationAPI } from './client';
 const api = getSimpleSSENotificationAPI();
 export const {
   postLogin,
+  getLoginMethods,
+  getOidcLogin,
+  getOidcCallback,
+  getSession,
+  postRegister,
+  putPassword,
+  getTokens,
+  postToken,
+  deleteToken,
   postLogout,
   getEvents,
+  getEventsPoll,
   postNotify,
+  getNotifications,
   getUsers,
+  getDoNotDisturb,
+  putDoNotDisturb,
+  getChannels,
+  postChannelJoin,
+  postChannelLeave,
+  getChannelMembers,
+  postChannelPublish,
+  getGroups,
+  postGroup,
+  getGroup,
+  putGroup,
+  deleteGroup,
+  postGroupMembers,
+  deleteGroupMember,
+  getScheduled,
+  deleteScheduled,
+  getRecurring,
+  postRecurring,
+  getRecurringSchedule,
+  putRecurringSchedule,
+  deleteRecurringSchedule,
+  getRecurringRuns,
   postAcknowledgeRequest,
   postAcknowledgeResponse,
 } = api;
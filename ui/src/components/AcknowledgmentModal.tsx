@@ -7,22 +7,28 @@ import UserDropdown from './UserDropdown'
 interface AcknowledgmentModalProps {
   isOpen: boolean
   onClose: () => void
+  onScheduled: () => void
 }
 
-export default function AcknowledgmentModal({ isOpen, onClose }: AcknowledgmentModalProps) {
+export default function AcknowledgmentModal({ isOpen, onClose, onScheduled }: AcknowledgmentModalProps) {
   const [selectedUsers, setSelectedUsers] = useState<string[]>([])
   const [message, setMessage] = useState('')
+  const [options, setOptions] = useState('')
+  const [sendAt, setSendAt] = useState('')
   const [loading, setLoading] = useState(false)
   const [activeRequest, setActiveRequest] = useState<string | null>(null)
   const acknowledgmentRequests = useAppStore((state) => state.acknowledgmentRequests)
   const addAcknowledgmentRequest = useAppStore((state) => state.addAcknowledgmentRequest)
   const username = useAppStore((state) => state.username)
+  const groups = useAppStore((state) => state.groups)
 
   // Reset form state when modal opens fresh (only reset when transitioning from closed to open)
   useEffect(() => {
     if (isOpen && !activeRequest) {
       setSelectedUsers([])
       setMessage('')
+      setOptions('')
+      setSendAt('')
     }
   }, [isOpen])
 
@@ -42,23 +48,43 @@ export default function AcknowledgmentModal({ isOpen, onClose }: AcknowledgmentM
       return
     }
 
+    // Options are entered comma separated; none means a plain acknowledgment
+    const optionList = [...new Set(options.split(',').map((o) => o.trim()).filter(Boolean))]
+
+    // Groups are listed as "@name" alongside the users
+    const toGroups = selectedUsers.filter((u) => u.startsWith('@')).map((u) => u.slice(1))
+    const toUsernames = selectedUsers.filter((u) => !u.startsWith('@'))
+
     setLoading(true)
     try {
       const response = await postAcknowledgeRequest({
-        to_usernames: selectedUsers,
+        to_usernames: toUsernames.length > 0 ? toUsernames : undefined,
+        to_groups: toGroups.length > 0 ? toGroups : undefined,
         message: message,
+        options: optionList.length > 0 ? optionList : undefined,
+        send_at: sendAt ? new Date(sendAt).toISOString() : undefined,
       })
 
-      if (response.request_id) {
+      // Scheduled requests are tracked once they go out
+      if (response.scheduled_id) {
+        onScheduled()
+        setSelectedUsers([])
+        setMessage('')
+        setOptions('')
+        setSendAt('')
+        onClose()
+      } else if (response.request_id) {
         addAcknowledgmentRequest({
           id: response.request_id,
           fromUsername: username || 'Unknown',
-          toUsernames: selectedUsers,
+          toUsernames: response.to_usernames ?? toUsernames,
           message: message,
+          options: optionList,
           acknowledgedBy: [],
         })
         setActiveRequest(response.request_id)
         setMessage('')
+        setOptions('')
       }
     } catch (err) {
       console.error('Failed to send acknowledgment request:', err)
@@ -101,6 +127,7 @@ export default function AcknowledgmentModal({ isOpen, onClose }: AcknowledgmentM
               value=""
               onChange={handleAddUser}
               includeAll={false}
+              groups={groups.map((g) => g.name)}
             />
           </div>
 
@@ -135,6 +162,31 @@ export default function AcknowledgmentModal({ isOpen, onClose }: AcknowledgmentM
               rows={3}
             />
           </div>
+
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              Response Options (optional)
+            </label>
+            <input
+              type="text"
+              value={options}
+              onChange={(e) => setOptions(e.target.value)}
+              className="form-input"
+              placeholder="Approve, Reject, Need more info"
+            />
+          </div>
+
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              Send at (optional)
+            </label>
+            <input
+              type="datetime-local"
+              value={sendAt}
+              onChange={(e) => setSendAt(e.target.value)}
+              className="form-input"
+            />
+          </div>
         </div>
       ) : (
         <div className="space-y-4">
@@ -145,6 +197,7 @@ export default function AcknowledgmentModal({ isOpen, onClose }: AcknowledgmentM
             <div className="space-y-2">
               {activeAckRequest.toUsernames.map((user) => {
                 const acknowledged = activeAckRequest.acknowledgedBy.includes(user)
+                const response = activeAckRequest.responses?.[user]
                 return (
                   <div key={user} className="flex items-center gap-3">
                     <span className={`text-sm font-medium ${acknowledged ? 'text-green-600' : 'text-gray-500'}`}>
@@ -153,6 +206,12 @@ export default function AcknowledgmentModal({ isOpen, onClose }: AcknowledgmentM
                     <span className={acknowledged ? 'text-green-600 line-through' : ''}>
                       {user}
                     </span>
+                    {response?.option && (
+                      <span className="text-sm font-semibold text-gray-700">{response.option}</span>
+                    )}
+                    {response?.comment && (
+                      <span className="text-sm italic text-gray-500">"{response.comment}"</span>
+                    )}
                   </div>
                 )
               })}
@@ -177,7 +236,7 @@ export default function AcknowledgmentModal({ isOpen, onClose }: AcknowledgmentM
             disabled={loading || !selectedUsers.length || !message.trim()}
             className="btn-primary disabled:opacity-50 disabled:cursor-not-allowed"
           >
-            {loading ? 'Sending...' : 'Send Request'}
+            {loading ? 'Sending...' : sendAt ? 'Schedule Request' : 'Send Request'}
           </button>
         )}
       </div>
@@ -7,6 +7,7 @@ interface AcknowledgmentRequestModalProps {
   requestId: string | null
   fromUsername: string | null
   message: string | null
+  options?: string[]
   onClose: () => void
   onAcknowledge: () => void
 }
@@ -16,26 +17,31 @@ export default function AcknowledgmentRequestModal({
   requestId,
   fromUsername,
   message,
+  options,
   onClose,
   onAcknowledge,
 }: AcknowledgmentRequestModalProps) {
   const [loading, setLoading] = useState(false)
   const [acknowledged, setAcknowledged] = useState(false)
+  const [comment, setComment] = useState('')
 
   // Reset acknowledged state when a new request arrives
   useEffect(() => {
     if (isOpen && requestId) {
       setAcknowledged(false)
+      setComment('')
     }
   }, [requestId, isOpen])
 
-  const handleAcknowledge = async () => {
+  const handleAcknowledge = async (option?: string) => {
     if (!requestId) return
 
     setLoading(true)
     try {
       await postAcknowledgeResponse({
         request_id: requestId,
+        option,
+        comment: comment.trim() || undefined,
       })
       setAcknowledged(true)
       onAcknowledge()
@@ -62,6 +68,21 @@ export default function AcknowledgmentRequestModal({
           <p className="text-gray-700">{message}</p>
         </div>
 
+        {!acknowledged && (
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              Comment (optional)
+            </label>
+            <textarea
+              value={comment}
+              onChange={(e) => setComment(e.target.value)}
+              className="form-textarea"
+              placeholder="Add a comment..."
+              rows={2}
+            />
+          </div>
+        )}
+
         {acknowledged && (
           <div className="bg-green-50 border border-green-200 p-4 rounded text-center">
             <p className="text-green-800 font-medium">Acknowledged!</p>
@@ -76,9 +97,20 @@ export default function AcknowledgmentRequestModal({
         >
           Close
         </button>
-        {!acknowledged && (
+        {!acknowledged && options && options.length > 0 &&
+          options.map((option) => (
+            <button
+              key={option}
+              onClick={() => handleAcknowledge(option)}
+              disabled={loading}
+              className="btn-secondary disabled:opacity-50 disabled:cursor-not-allowed"
+            >
+              {option}
+            </button>
+          ))}
+        {!acknowledged && (!options || options.length === 0) && (
           <button
-            onClick={handleAcknowledge}
+            onClick={() => handleAcknowledge()}
             disabled={loading}
             className="btn-secondary disabled:opacity-50 disabled:cursor-not-allowed"
           >
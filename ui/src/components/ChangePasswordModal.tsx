@@ -0,0 +1,131 @@
+import { useState, useEffect } from 'react'
+import { isAxiosError } from 'axios'
+import { putPassword } from '../api'
+import Modal from './Modal'
+
+interface ChangePasswordModalProps {
+  isOpen: boolean
+  onClose: () => void
+}
+
+export default function ChangePasswordModal({ isOpen, onClose }: ChangePasswordModalProps) {
+  const [currentPassword, setCurrentPassword] = useState('')
+  const [newPassword, setNewPassword] = useState('')
+  const [confirmPassword, setConfirmPassword] = useState('')
+  const [error, setError] = useState('')
+  const [done, setDone] = useState(false)
+  const [loading, setLoading] = useState(false)
+
+  // Start from an empty form each time the modal opens
+  useEffect(() => {
+    if (isOpen) {
+      setCurrentPassword('')
+      setNewPassword('')
+      setConfirmPassword('')
+      setError('')
+      setDone(false)
+    }
+  }, [isOpen])
+
+  const handleSave = async () => {
+    setError('')
+    if (newPassword !== confirmPassword) {
+      setError('The new passwords do not match')
+      return
+    }
+
+    setLoading(true)
+    try {
+      await putPassword({ current_password: currentPassword, new_password: newPassword })
+      setDone(true)
+    } catch (err) {
+      const status = isAxiosError(err) ? err.response?.status : undefined
+      if (status === 401) {
+        setError('The current password is wrong')
+      } else if (status === 400) {
+        setError('The new password needs 8 to 72 characters')
+      } else if (status === 429) {
+        setError('Too many wrong passwords, try again later')
+      } else {
+        setError('Failed to change password')
+      }
+      console.error('Failed to change password:', err)
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  if (!isOpen) return null
+
+  return (
+    <Modal isOpen={isOpen} onClose={onClose} title="Change Password">
+      {done ? (
+        <div className="bg-green-50 border border-green-200 text-green-700 px-4 py-3 rounded text-sm">
+          Password changed. Your other sessions have been signed out.
+        </div>
+      ) : (
+        <div className="space-y-4">
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              Current Password
+            </label>
+            <input
+              type="password"
+              autoComplete="current-password"
+              value={currentPassword}
+              onChange={(e) => setCurrentPassword(e.target.value)}
+              className="form-input"
+            />
+          </div>
+
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              New Password
+            </label>
+            <input
+              type="password"
+              autoComplete="new-password"
+              value={newPassword}
+              onChange={(e) => setNewPassword(e.target.value)}
+              className="form-input"
+              placeholder="At least 8 characters"
+            />
+          </div>
+
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              Confirm New Password
+            </label>
+            <input
+              type="password"
+              autoComplete="new-password"
+              value={confirmPassword}
+              onChange={(e) => setConfirmPassword(e.target.value)}
+              className="form-input"
+            />
+          </div>
+
+          {error && <div className="bg-red-50 border border-red-200 text-red-700 px-4 py-3 rounded text-sm">{error}</div>}
+        </div>
+      )}
+
+      <div slot="footer" className="flex gap-2 mt-4">
+        <button
+          onClick={onClose}
+          className="px-4 py-2 text-gray-700 border border-gray-300 rounded hover:bg-gray-50"
+        >
+          Close
+        </button>
+        {!done && (
+          <button
+            onClick={handleSave}
+            disabled={loading || !currentPassword || !newPassword}
+            className="btn-primary disabled:opacity-50 disabled:cursor-not-allowed"
+          >
+            {loading ? 'Saving...' : 'Change Password'}
+          </button>
+        )}
+      </div>
+    </Modal>
+  )
+}
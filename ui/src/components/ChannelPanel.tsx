@@ -0,0 +1,73 @@
+import { useState } from "react";
+import { useAppStore } from "../store";
+import { postChannelJoin, postChannelLeave } from "../api";
+
+interface ChannelPanelProps {
+  onChange: () => void;
+}
+
+export default function ChannelPanel({ onChange }: ChannelPanelProps) {
+  const [name, setName] = useState("");
+  const channels = useAppStore((state) => state.channels);
+
+  const handleJoin = async (channel: string) => {
+    try {
+      await postChannelJoin(encodeURIComponent(channel));
+      setName("");
+      onChange();
+    } catch (err) {
+      console.error("Failed to join channel:", err);
+    }
+  };
+
+  const handleLeave = async (channel: string) => {
+    try {
+      await postChannelLeave(encodeURIComponent(channel));
+      onChange();
+    } catch (err) {
+      console.error("Failed to leave channel:", err);
+    }
+  };
+
+  return (
+    <div className="mt-6 pt-6 border-t">
+      <h3 className="text-sm font-bold text-gray-700 mb-3">Channels ({channels.length})</h3>
+
+      <form
+        onSubmit={(e) => {
+          e.preventDefault();
+          if (name.trim()) handleJoin(name.trim());
+        }}
+        className="flex gap-2 mb-3"
+      >
+        <input type="text" placeholder="#ops" value={name} onChange={(e) => setName(e.target.value)} className="form-input flex-1" />
+        <button type="submit" disabled={!name.trim()} className="btn-secondary disabled:opacity-50">
+          Join
+        </button>
+      </form>
+
+      <div className="space-y-2">
+        {channels.length === 0 ? (
+          <p className="text-gray-500 text-sm">No channels yet</p>
+        ) : (
+          channels.map((channel) => (
+            <div key={channel.name} className="flex items-center justify-between gap-2 px-3 py-2 bg-purple-50 rounded border border-purple-200">
+              <span className="text-gray-800 font-medium">
+                #{channel.name} <span className="text-xs text-gray-500">({channel.memberCount})</span>
+              </span>
+              {channel.joined ? (
+                <button onClick={() => handleLeave(channel.name)} className="text-sm text-red-600 hover:underline">
+                  Leave
+                </button>
+              ) : (
+                <button onClick={() => handleJoin(channel.name)} className="text-sm text-blue-600 hover:underline">
+                  Join
+                </button>
+              )}
+            </div>
+          ))
+        )}
+      </div>
+    </div>
+  );
+}
@@ -0,0 +1,76 @@
+import { useState } from "react";
+import { useAppStore } from "../store";
+import { postGroup, deleteGroup } from "../api";
+
+interface GroupPanelProps {
+  onChange: () => void;
+}
+
+export default function GroupPanel({ onChange }: GroupPanelProps) {
+  const [name, setName] = useState("");
+  const [members, setMembers] = useState("");
+  const username = useAppStore((state) => state.username);
+  const groups = useAppStore((state) => state.groups);
+
+  const handleCreate = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!name.trim()) return;
+
+    try {
+      // Members are entered comma separated
+      await postGroup({
+        name: name.trim(),
+        members: members.split(",").map((m) => m.trim()).filter(Boolean),
+      });
+      setName("");
+      setMembers("");
+      onChange();
+    } catch (err) {
+      console.error("Failed to create group:", err);
+      alert("Failed to create group");
+    }
+  };
+
+  const handleDelete = async (group: string) => {
+    try {
+      await deleteGroup(encodeURIComponent(group));
+      onChange();
+    } catch (err) {
+      console.error("Failed to delete group:", err);
+    }
+  };
+
+  return (
+    <div className="mt-6 pt-6 border-t">
+      <h3 className="text-sm font-bold text-gray-700 mb-3">Groups ({groups.length})</h3>
+
+      <form onSubmit={handleCreate} className="space-y-2 mb-3">
+        <input type="text" placeholder="Group name, e.g. oncall" value={name} onChange={(e) => setName(e.target.value)} className="form-input w-full" />
+        <input type="text" placeholder="Members, comma separated" value={members} onChange={(e) => setMembers(e.target.value)} className="form-input w-full" />
+        <button type="submit" disabled={!name.trim()} className="w-full btn-secondary disabled:opacity-50">
+          Create Group
+        </button>
+      </form>
+
+      <div className="space-y-2">
+        {groups.length === 0 ? (
+          <p className="text-gray-500 text-sm">No groups yet</p>
+        ) : (
+          groups.map((group) => (
+            <div key={group.name} className="px-3 py-2 bg-orange-50 rounded border border-orange-200">
+              <div className="flex items-center justify-between gap-2">
+                <span className="text-gray-800 font-medium">@{group.name}</span>
+                {group.createdBy === username && (
+                  <button onClick={() => handleDelete(group.name)} className="text-sm text-red-600 hover:underline">
+                    Delete
+                  </button>
+                )}
+              </div>
+              <p className="text-xs text-gray-500">{group.members.length > 0 ? group.members.join(", ") : "No members"}</p>
+            </div>
+          ))
+        )}
+      </div>
+    </div>
+  );
+}
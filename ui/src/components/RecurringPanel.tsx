@@ -0,0 +1,142 @@
+import { useState, useEffect } from "react";
+import { getRecurring, postRecurring, putRecurringSchedule, deleteRecurringSchedule, getRecurringRuns, RecurringSchedule, RecurringRun } from "../api";
+
+export default function RecurringPanel() {
+  const [schedules, setSchedules] = useState<RecurringSchedule[]>([]);
+  const [target, setTarget] = useState("");
+  const [message, setMessage] = useState("");
+  const [cron, setCron] = useState("0 9 * * 1-5");
+  const [timeZone, setTimeZone] = useState(Intl.DateTimeFormat().resolvedOptions().timeZone);
+  const [acknowledge, setAcknowledge] = useState(false);
+  const [history, setHistory] = useState<{ id: string; runs: RecurringRun[] } | null>(null);
+
+  const fetchRecurring = async () => {
+    try {
+      const response = await getRecurring();
+      setSchedules(response.recurring);
+    } catch (err) {
+      console.error("Failed to fetch recurring schedules:", err);
+    }
+  };
+
+  useEffect(() => {
+    fetchRecurring();
+  }, []);
+
+  const handleCreate = async (e: React.FormEvent) => {
+    e.preventDefault();
+
+    // Targets are entered comma separated, with groups as "@name"
+    const targets = target.split(",").map((t) => t.trim()).filter(Boolean);
+    const groups = targets.filter((t) => t.startsWith("@")).map((t) => t.slice(1));
+    const usernames = targets.filter((t) => !t.startsWith("@"));
+
+    try {
+      await postRecurring({
+        cron,
+        time_zone: timeZone,
+        ...(acknowledge
+          ? { acknowledgment: { to_usernames: usernames, to_groups: groups, message } }
+          : { notification: { target_username: usernames[0], target_groups: groups, message } }),
+      });
+      setMessage("");
+      fetchRecurring();
+    } catch (err) {
+      console.error("Failed to create recurring schedule:", err);
+      alert("Failed to create recurring schedule. Check the cron expression, time zone and recipients.");
+    }
+  };
+
+  const handleTogglePaused = async (schedule: RecurringSchedule) => {
+    try {
+      await putRecurringSchedule(schedule.id, { paused: !schedule.paused });
+      fetchRecurring();
+    } catch (err) {
+      console.error("Failed to update recurring schedule:", err);
+    }
+  };
+
+  const handleDelete = async (id: string) => {
+    try {
+      await deleteRecurringSchedule(id);
+      if (history?.id === id) setHistory(null);
+      fetchRecurring();
+    } catch (err) {
+      console.error("Failed to delete recurring schedule:", err);
+    }
+  };
+
+  const handleHistory = async (id: string) => {
+    if (history?.id === id) {
+      setHistory(null);
+      return;
+    }
+    try {
+      const response = await getRecurringRuns(id);
+      setHistory({ id, runs: response.runs });
+    } catch (err) {
+      console.error("Failed to fetch recurring runs:", err);
+    }
+  };
+
+  return (
+    <div className="bg-white rounded-lg  p-6">
+      <h2 className="text-xl font-bold text-gray-800 mb-4">Recurring ({schedules.length})</h2>
+
+      <form onSubmit={handleCreate} className="grid grid-cols-1 md:grid-cols-2 gap-2 mb-4">
+        <input type="text" placeholder="To, e.g. bob, @oncall" value={target} onChange={(e) => setTarget(e.target.value)} className="form-input" />
+        <input type="text" placeholder="Message" value={message} onChange={(e) => setMessage(e.target.value)} className="form-input" />
+        <input type="text" placeholder="Cron, e.g. 0 9 * * 1-5" value={cron} onChange={(e) => setCron(e.target.value)} className="form-input" />
+        <input type="text" placeholder="Time zone, e.g. Europe/Berlin" value={timeZone} onChange={(e) => setTimeZone(e.target.value)} className="form-input" />
+        <label className="flex items-center gap-2 text-sm text-gray-700">
+          <input type="checkbox" checked={acknowledge} onChange={(e) => setAcknowledge(e.target.checked)} />
+          Request acknowledgment
+        </label>
+        <button type="submit" disabled={!target.trim() || !message.trim() || !cron.trim()} className="btn-secondary disabled:opacity-50">
+          Add Recurring
+        </button>
+      </form>
+
+      <div className="space-y-3">
+        {schedules.map((schedule) => (
+          <div key={schedule.id} className="border border-gray-200 bg-gray-50 p-4 rounded">
+            <div className="flex justify-between items-start gap-2">
+              <div>
+                <p className="text-xs font-semibold uppercase text-gray-500">
+                  {schedule.kind === "notification" ? "Message" : "Acknowledgment"} · <code>{schedule.cron}</code> · {schedule.time_zone}
+                </p>
+                <p className="text-gray-700">{schedule.notification?.message ?? schedule.acknowledgment?.message}</p>
+                <p className="text-xs text-gray-500">{schedule.paused ? "Paused" : schedule.next_run ? `Next: ${new Date(schedule.next_run).toLocaleString()}` : ""}</p>
+              </div>
+              <div className="flex gap-3 text-sm whitespace-nowrap">
+                <button onClick={() => handleHistory(schedule.id)} className="text-blue-600 hover:underline">
+                  History
+                </button>
+                <button onClick={() => handleTogglePaused(schedule)} className="text-gray-700 hover:underline">
+                  {schedule.paused ? "Resume" : "Pause"}
+                </button>
+                <button onClick={() => handleDelete(schedule.id)} className="text-red-600 hover:underline">
+                  Delete
+                </button>
+              </div>
+            </div>
+
+            {history?.id === schedule.id && (
+              <ul className="mt-3 space-y-1 text-xs">
+                {history.runs.length === 0 ? (
+                  <li className="text-gray-500">Not run yet</li>
+                ) : (
+                  history.runs.map((run) => (
+                    <li key={run.at} className={run.error ? "text-red-600" : "text-gray-600"}>
+                      {new Date(run.at).toLocaleString()} {run.error ? `failed: ${run.error}` : "sent"}
+                    </li>
+                  ))
+                )}
+              </ul>
+            )}
+          </div>
+        ))}
+      </div>
+    </div>
+  );
+}
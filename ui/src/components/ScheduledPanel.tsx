@@ -0,0 +1,66 @@
+import { useState, useEffect } from "react";
+import { getScheduled, deleteScheduled, ScheduledSend } from "../api";
+
+interface ScheduledPanelProps {
+  refreshKey: number;
+}
+
+export default function ScheduledPanel({ refreshKey }: ScheduledPanelProps) {
+  const [scheduled, setScheduled] = useState<ScheduledSend[]>([]);
+
+  const fetchScheduled = async () => {
+    try {
+      const response = await getScheduled();
+      setScheduled(response.scheduled);
+    } catch (err) {
+      console.error("Failed to fetch scheduled sends:", err);
+    }
+  };
+
+  // Refetch when something was scheduled, and every so often to drop sends that went out
+  useEffect(() => {
+    fetchScheduled();
+    const interval = setInterval(fetchScheduled, 30000);
+    return () => clearInterval(interval);
+  }, [refreshKey]);
+
+  const handleCancel = async (id: string) => {
+    try {
+      await deleteScheduled(id);
+    } catch (err) {
+      console.error("Failed to cancel scheduled send:", err);
+    }
+    fetchScheduled();
+  };
+
+  if (scheduled.length === 0) return null;
+
+  return (
+    <div className="bg-white rounded-lg  p-6">
+      <h2 className="text-xl font-bold text-gray-800 mb-4">Scheduled ({scheduled.length})</h2>
+      <div className="space-y-3">
+        {scheduled.map((send) => {
+          const message = send.notification?.message ?? send.acknowledgment?.message;
+          const target =
+            send.kind === "notification"
+              ? [send.notification?.target_username, ...(send.notification?.target_groups ?? []).map((g) => `@${g}`)].filter(Boolean).join(", ")
+              : [...(send.acknowledgment?.to_usernames ?? []), ...(send.acknowledgment?.to_groups ?? []).map((g) => `@${g}`)].join(", ");
+          return (
+            <div key={send.id} className="flex justify-between items-start gap-2 border border-gray-200 bg-gray-50 p-4 rounded">
+              <div>
+                <p className="text-xs font-semibold uppercase text-gray-500">
+                  {send.kind === "notification" ? "Message" : "Acknowledgment"} to {target}
+                </p>
+                <p className="text-gray-700">{message}</p>
+                <p className="text-xs text-gray-500">{new Date(send.send_at).toLocaleString()}</p>
+              </div>
+              <button onClick={() => handleCancel(send.id)} className="text-sm text-red-600 hover:underline">
+                Cancel
+              </button>
+            </div>
+          );
+        })}
+      </div>
+    </div>
+  );
+}
@@ -0,0 +1,123 @@
+import { useState, useEffect, useCallback } from "react";
+import { getTokens, postToken, deleteToken, ApiToken, TokenScope } from "../api";
+
+const scopeLabels: Record<TokenScope, string> = {
+  notify: "Send notifications",
+  acknowledge: "Request acknowledgments",
+};
+
+export default function TokenPanel() {
+  const [tokens, setTokens] = useState<ApiToken[]>([]);
+  const [name, setName] = useState("");
+  const [scopes, setScopes] = useState<TokenScope[]>([TokenScope.notify]);
+  const [principal, setPrincipal] = useState("");
+  const [expiresAt, setExpiresAt] = useState("");
+  const [secret, setSecret] = useState<string | null>(null);
+
+  const fetchTokens = useCallback(async () => {
+    try {
+      const response = await getTokens();
+      setTokens(response.tokens);
+    } catch (err) {
+      console.error("Failed to fetch API tokens:", err);
+    }
+  }, []);
+
+  useEffect(() => {
+    fetchTokens();
+  }, [fetchTokens]);
+
+  const toggleScope = (scope: TokenScope) => {
+    setScopes(scopes.includes(scope) ? scopes.filter((s) => s !== scope) : [...scopes, scope]);
+  };
+
+  const handleCreate = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!name.trim() || scopes.length === 0) return;
+
+    try {
+      // The principal defaults to the current user
+      const response = await postToken({
+        name: name.trim(),
+        scopes,
+        ...(principal.trim() ? { principal: principal.trim() } : {}),
+        ...(expiresAt ? { expires_at: new Date(expiresAt).toISOString() } : {}),
+      });
+      setSecret(response.secret);
+      setName("");
+      setPrincipal("");
+      setExpiresAt("");
+      fetchTokens();
+    } catch (err) {
+      console.error("Failed to create API token:", err);
+      alert("Failed to create API token");
+    }
+  };
+
+  const handleRevoke = async (id: string) => {
+    try {
+      await deleteToken(encodeURIComponent(id));
+      fetchTokens();
+    } catch (err) {
+      console.error("Failed to revoke API token:", err);
+    }
+  };
+
+  return (
+    <div className="mt-6 pt-6 border-t">
+      <h3 className="text-sm font-bold text-gray-700 mb-3">API Tokens ({tokens.length})</h3>
+
+      <form onSubmit={handleCreate} className="space-y-2 mb-3">
+        <input type="text" placeholder="Token name, e.g. ci" value={name} onChange={(e) => setName(e.target.value)} className="form-input w-full" />
+        <input type="text" placeholder="Act as (optional), e.g. ci-bot" value={principal} onChange={(e) => setPrincipal(e.target.value)} className="form-input w-full" />
+        <div className="flex flex-col gap-1">
+          {Object.values(TokenScope).map((scope) => (
+            <label key={scope} className="flex items-center gap-2 text-sm text-gray-700">
+              <input type="checkbox" checked={scopes.includes(scope)} onChange={() => toggleScope(scope)} />
+              {scopeLabels[scope]}
+            </label>
+          ))}
+        </div>
+        <label className="block text-xs text-gray-500">Expires (optional)</label>
+        <input type="datetime-local" value={expiresAt} onChange={(e) => setExpiresAt(e.target.value)} className="form-input w-full" />
+        <button type="submit" disabled={!name.trim() || scopes.length === 0} className="w-full btn-secondary disabled:opacity-50">
+          Create Token
+        </button>
+      </form>
+
+      {/* The secret is only returned once, right after creation */}
+      {secret && (
+        <div className="mb-3 px-3 py-2 bg-yellow-50 rounded border border-yellow-300">
+          <p className="text-xs text-gray-700 mb-1">Copy this token now; it will not be shown again.</p>
+          <code className="block text-xs break-all text-gray-900">{secret}</code>
+          <button onClick={() => setSecret(null)} className="mt-1 text-xs text-gray-600 hover:underline">
+            Dismiss
+          </button>
+        </div>
+      )}
+
+      <div className="space-y-2">
+        {tokens.length === 0 ? (
+          <p className="text-gray-500 text-sm">No API tokens</p>
+        ) : (
+          tokens.map((token) => (
+            <div key={token.id} className="px-3 py-2 bg-gray-50 rounded border border-gray-200">
+              <div className="flex items-center justify-between gap-2">
+                <span className="text-gray-800 font-medium">
+                  {token.name} <span className="text-xs text-gray-500">…{token.hint}</span>
+                </span>
+                <button onClick={() => handleRevoke(token.id)} className="text-sm text-red-600 hover:underline">
+                  Revoke
+                </button>
+              </div>
+              <p className="text-xs text-gray-500">
+                As {token.principal} · {token.scopes.join(", ")}
+                {token.expires_at && ` · expires ${new Date(token.expires_at).toLocaleString()}`}
+              </p>
+            </div>
+          ))
+        )}
+      </div>
+    </div>
+  );
+}
@@ -5,9 +5,11 @@ interface UserDropdownProps {
   value: string;
   onChange: (username: string) => void;
   includeAll?: boolean;
+  channels?: string[];
+  groups?: string[];
 }
 
-export default function UserDropdown({ value, onChange, includeAll = true }: UserDropdownProps) {
+export default function UserDropdown({ value, onChange, includeAll = true, channels = [], groups = [] }: UserDropdownProps) {
   const [isOpen, setIsOpen] = useState(false);
   const users = useAppStore((state) => state.users);
 
@@ -30,12 +32,22 @@ export default function UserDropdown({ value, onChange, includeAll = true }: Use
               All Users
             </button>
           )}
+          {channels.map((channel) => (
+            <button key={`#${channel}`} onClick={() => handleSelect(`#${channel}`)} className={`w-full text-left px-3 py-2 hover:bg-blue-50 ${value === `#${channel}` ? "bg-blue-100" : ""}`}>
+              #{channel}
+            </button>
+          ))}
+          {groups.map((group) => (
+            <button key={`@${group}`} onClick={() => handleSelect(`@${group}`)} className={`w-full text-left px-3 py-2 hover:bg-blue-50 ${value === `@${group}` ? "bg-blue-100" : ""}`}>
+              @{group}
+            </button>
+          ))}
           {users.map((user) => (
             <button key={user.username} onClick={() => handleSelect(user.username)} className={`w-full text-left px-3 py-2 hover:bg-blue-50 ${value === user.username ? "bg-blue-100" : ""}`}>
               {user.username}
             </button>
           ))}
-          {users.length === 0 && !includeAll && <div className="px-3 py-2 text-gray-500 text-sm">No users available</div>}
+          {users.length === 0 && groups.length === 0 && !includeAll && <div className="px-3 py-2 text-gray-500 text-sm">No users available</div>}
         </div>
       )}
     </div>
@@ -1,10 +1,24 @@
-import { useState, useEffect } from "react";
+import { useState, useEffect, useCallback } from "react";
 import { useNavigate } from "react-router-dom";
 import { useAppStore, Notification } from "../store";
-import { postNotify, postLogout, getUsers } from "../api";
+import { postNotify, postLogout, getUsers, getNotifications, getEventsPoll, getChannels, postChannelPublish, getGroups, getDoNotDisturb, putDoNotDisturb, Priority } from "../api";
 import UserDropdown from "../components/UserDropdown";
+import ChannelPanel from "../components/ChannelPanel";
+import GroupPanel from "../components/GroupPanel";
+import TokenPanel from "../components/TokenPanel";
+import ScheduledPanel from "../components/ScheduledPanel";
+import RecurringPanel from "../components/RecurringPanel";
 import AcknowledgmentModal from "../components/AcknowledgmentModal";
 import AcknowledgmentRequestModal from "../components/AcknowledgmentRequestModal";
+import ChangePasswordModal from "../components/ChangePasswordModal";
+
+// Feed styling by notification priority
+const priorityStyles: Record<Priority, string> = {
+  low: "bg-gray-50 opacity-75",
+  normal: "bg-blue-50",
+  high: "bg-orange-50 border-l-4 border-orange-400",
+  critical: "bg-red-50 border-l-4 border-red-600 font-semibold",
+};
 
 export default function Dashboard() {
   const navigate = useNavigate();
@@ -15,16 +29,22 @@ export default function Dashboard() {
   }));
 
   // App store actions
-  const { users, setUsers, addUser, removeUser, notifications, addNotification, updateAcknowledgmentResponse, acknowledgmentRequests } = useAppStore();
+  const { users, setUsers, addUser, removeUser, channels, setChannels, groups, setGroups, notifications, addNotification, mergeNotificationHistory, updateAcknowledgmentResponse, acknowledgmentRequests } = useAppStore();
 
   // Local state
   const [targetUser, setTargetUser] = useState("all");
   const [message, setMessage] = useState("");
+  const [sendAt, setSendAt] = useState("");
+  const [priority, setPriority] = useState<Priority>("normal");
+  const [doNotDisturb, setDoNotDisturb] = useState(false);
+  const [scheduledKey, setScheduledKey] = useState(0);
   const [showAckModal, setShowAckModal] = useState(false);
+  const [showPasswordModal, setShowPasswordModal] = useState(false);
   const [incomingAckRequest, setIncomingAckRequest] = useState<{
     id: string;
     from: string;
     message: string;
+    options?: string[];
   } | null>(null);
 
   // Protect route
@@ -52,74 +72,207 @@ export default function Dashboard() {
     fetchUsers();
   }, [username, setUsers]);
 
-  // Main SSE Logic
+  // Fetch the channel list; refetched whenever channel membership changes
+  const fetchChannels = useCallback(async () => {
+    try {
+      const response = await getChannels();
+      setChannels(response.channels.map((c) => ({ name: c.name, memberCount: c.member_count, joined: c.joined })));
+    } catch (err) {
+      console.error("Failed to fetch channels:", err);
+    }
+  }, [setChannels]);
+
+  useEffect(() => {
+    if (!username) return;
+    fetchChannels();
+  }, [username, fetchChannels]);
+
+  // Fetch the recipient groups; refetched after changes made from this dashboard
+  const fetchGroups = useCallback(async () => {
+    try {
+      const response = await getGroups();
+      setGroups(response.groups.map((g) => ({ name: g.name, description: g.description, members: g.members, createdBy: g.created_by })));
+    } catch (err) {
+      console.error("Failed to fetch groups:", err);
+    }
+  }, [setGroups]);
+
   useEffect(() => {
     if (!username) return;
+    fetchGroups();
+  }, [username, fetchGroups]);
+
+  // Load notification history, including anything sent while offline or
+  // held back by do-not-disturb
+  const fetchHistory = useCallback(async () => {
+    try {
+      const response = await getNotifications({ limit: 50 });
+      mergeNotificationHistory(response.notifications);
+    } catch (err) {
+      console.error("Failed to fetch notification history:", err);
+    }
+  }, [mergeNotificationHistory]);
+
+  useEffect(() => {
+    if (!username) return;
+    fetchHistory();
+  }, [username, fetchHistory]);
+
+  // Load the do-not-disturb setting
+  useEffect(() => {
+    if (!username) return;
+
+    getDoNotDisturb()
+      .then((response) => setDoNotDisturb(response.enabled))
+      .catch((err) => console.error("Failed to fetch do-not-disturb setting:", err));
+  }, [username]);
+
+  const toggleDoNotDisturb = async () => {
+    try {
+      const response = await putDoNotDisturb({ enabled: !doNotDisturb });
+      setDoNotDisturb(response.enabled);
+      // Only critical notifications arrive live during do-not-disturb; the rest are in the history
+      if (!response.enabled) {
+        fetchHistory();
+      }
+    } catch (err) {
+      console.error("Failed to update do-not-disturb setting:", err);
+    }
+  };
+
+  // Main event stream logic: SSE, falling back to long polling
+  useEffect(() => {
+    if (!username) return;
+
+    // Handlers by event type, shared by both transports
+    const handlers: Record<string, (payload: any) => void> = {
+      notification: (payload) => {
+        addNotification({
+          id: payload.id,
+          from: payload.from,
+          message: payload.message,
+          timestamp: payload.timestamp,
+          channel: payload.channel,
+          priority: payload.priority,
+        });
+      },
+      channel_joined: () => {
+        fetchChannels();
+      },
+      channel_left: () => {
+        fetchChannels();
+      },
+      user_connected: (payload) => {
+        if (payload.username && payload.username !== username) {
+          addUser(payload.username);
+        }
+      },
+      user_disconnected: (payload) => {
+        removeUser(payload.username);
+      },
+      acknowledgment_request: (payload) => {
+        setIncomingAckRequest({
+          id: payload.id,
+          from: payload.from_username,
+          message: payload.message,
+          options: payload.options,
+        });
+      },
+      acknowledgment_response: (payload) => {
+        console.log("Received acknowledgment_response:", payload);
+        updateAcknowledgmentResponse(payload.request_id, payload.from_username, payload.option, payload.comment);
+        console.log("Updated acknowledgment for request:", payload.request_id, "from:", payload.from_username);
+      },
+    };
+
+    let stopped = false;
+    let lastEventId: number | undefined;
+
+    // Long polling, for proxies that buffer the SSE response
+    const poll = async () => {
+      while (!stopped) {
+        try {
+          const response = await getEventsPoll({ after: lastEventId, timeout: 25 });
+          if (stopped) return;
+          for (const event of response.events) {
+            handlers[event.type]?.(event.payload);
+          }
+          lastEventId = response.last_id;
+        } catch (err) {
+          console.error("Long poll failed, retrying:", err);
+          await new Promise((resolve) => setTimeout(resolve, 3000));
+        }
+      }
+    };
 
     const eventSource = new EventSource("/api/events");
 
+    // A buffering proxy holds back even the "connected" event, so if it does
+    // not arrive in time, switch to long polling
+    const fallbackTimer = setTimeout(() => {
+      console.warn("No SSE connected event received, falling back to long polling");
+      eventSource.close();
+      poll();
+    }, 5000);
+
     eventSource.onopen = () => {
       console.log("SSE connection opened");
     };
 
+    // EventSource reconnects on its own and sends Last-Event-ID so the
+    // server can replay anything missed while the connection was down
     eventSource.onerror = (err) => {
-      console.error("EventSource failed:", err);
-      eventSource.close();
+      console.error("EventSource error, reconnecting:", err);
     };
 
-    // Handle all SSE messages
-    eventSource.onmessage = (event) => {
-      try {
-        const sseEvent = JSON.parse(event.data);
-        const eventType = sseEvent.type;
-        const payload = sseEvent.payload;
-
-        // Route based on event type
-        if (eventType === "notification") {
-          addNotification({
-            id: payload.id,
-            from: payload.from,
-            message: payload.message,
-            timestamp: payload.timestamp,
-          });
-        } else if (eventType === "user_connected") {
-          if (payload.username && payload.username !== username) {
-            addUser(payload.username);
-          }
-        } else if (eventType === "user_disconnected") {
-          removeUser(payload.username);
-        } else if (eventType === "acknowledgment_request") {
-          setIncomingAckRequest({
-            id: payload.id,
-            from: payload.from_username,
-            message: payload.message,
-          });
-        } else if (eventType === "acknowledgment_response") {
-          console.log("Received acknowledgment_response:", payload);
-          updateAcknowledgmentResponse(payload.request_id, payload.from_username);
-          console.log("Updated acknowledgment for request:", payload.request_id, "from:", payload.from_username);
+    eventSource.addEventListener("connected", () => {
+      clearTimeout(fallbackTimer);
+    });
+
+    // Each event type arrives as a named SSE event whose data is the payload
+    for (const [eventType, handle] of Object.entries(handlers)) {
+      eventSource.addEventListener(eventType, (event) => {
+        const message = event as MessageEvent;
+        if (message.lastEventId) {
+          lastEventId = Number(message.lastEventId);
         }
-      } catch (e) {
-        console.error("Failed to parse SSE message:", e);
-      }
-    };
+        try {
+          handle(JSON.parse(message.data));
+        } catch (e) {
+          console.error(`Failed to parse ${eventType} event:`, e);
+        }
+      });
+    }
 
     // Cleanup on unmount
     return () => {
+      stopped = true;
+      clearTimeout(fallbackTimer);
       eventSource.close();
     };
-  }, [username, addNotification, addUser, removeUser, updateAcknowledgmentResponse]);
+  }, [username, addNotification, addUser, removeUser, updateAcknowledgmentResponse, fetchChannels]);
 
   const handleSend = async (e: React.FormEvent) => {
     e.preventDefault();
     if (!message.trim() || !username) return;
 
     try {
-      await postNotify({
-        from_username: username,
-        target_username: targetUser,
-        message: message,
-      });
+      // Channels are listed as "#name" and groups as "@name" alongside the users
+      if (targetUser.startsWith("#")) {
+        await postChannelPublish(encodeURIComponent(targetUser.slice(1)), { message, priority });
+      } else {
+        // send_at is left out for immediate sends
+        const response = await postNotify({
+          ...(targetUser.startsWith("@") ? { target_groups: [targetUser.slice(1)] } : { target_username: targetUser }),
+          message: message,
+          priority,
+          ...(sendAt ? { send_at: new Date(sendAt).toISOString() } : {}),
+        });
+        if (response.scheduled_id) {
+          setSendAt("");
+          setScheduledKey((key) => key + 1);
+        }
+      }
       setMessage("");
     } catch (err) {
       console.error("Failed to send notification:", err);
@@ -150,9 +303,15 @@ export default function Dashboard() {
             </p>
           </div>
           <div className="flex gap-3">
+            <button onClick={toggleDoNotDisturb} className={doNotDisturb ? "btn-primary" : "btn-secondary"} title="Only critical messages are shown live while on">
+              {doNotDisturb ? "Do Not Disturb: On" : "Do Not Disturb: Off"}
+            </button>
             <button onClick={() => setShowAckModal(true)} className="btn-secondary" title="Send acknowledgment request">
               Wait For...
             </button>
+            <button onClick={() => setShowPasswordModal(true)} className="btn-secondary">
+              Change Password
+            </button>
             <button onClick={handleLogout} className="btn-danger">
               Logout
             </button>
@@ -171,7 +330,7 @@ export default function Dashboard() {
               <form onSubmit={handleSend} className="space-y-4">
                 <div>
                   <label className="block text-sm font-medium text-gray-700 mb-2">To</label>
-                  <UserDropdown value={targetUser} onChange={setTargetUser} includeAll={true} />
+                  <UserDropdown value={targetUser} onChange={setTargetUser} includeAll={true} channels={channels.filter((c) => c.joined).map((c) => c.name)} groups={groups.map((g) => g.name)} />
                 </div>
 
                 <div>
@@ -179,8 +338,24 @@ export default function Dashboard() {
                   <textarea placeholder="Type your message..." value={message} onChange={(e) => setMessage(e.target.value)} className="form-textarea" rows={4} />
                 </div>
 
+                <div>
+                  <label className="block text-sm font-medium text-gray-700 mb-2">Priority</label>
+                  <select value={priority} onChange={(e) => setPriority(e.target.value as Priority)} className="form-input w-full">
+                    <option value="low">Low</option>
+                    <option value="normal">Normal</option>
+                    <option value="high">High</option>
+                    <option value="critical">Critical (bypasses do-not-disturb)</option>
+                  </select>
+                </div>
+
+                {/* Channel publishes always go out immediately */}
+                <div>
+                  <label className="block text-sm font-medium text-gray-700 mb-2">Send at (optional)</label>
+                  <input type="datetime-local" value={sendAt} onChange={(e) => setSendAt(e.target.value)} disabled={targetUser.startsWith("#")} className="form-input w-full disabled:opacity-50" />
+                </div>
+
                 <button type="submit" disabled={!message.trim()} className="w-full btn-primary disabled:opacity-50 disabled:cursor-not-allowed">
-                  Send
+                  {sendAt && !targetUser.startsWith("#") ? "Schedule" : "Send"}
                 </button>
               </form>
 
@@ -199,6 +374,12 @@ export default function Dashboard() {
                   )}
                 </div>
               </div>
+
+              <ChannelPanel onChange={fetchChannels} />
+
+              <GroupPanel onChange={fetchGroups} />
+
+              <TokenPanel />
             </div>
           </div>
 
@@ -212,10 +393,15 @@ export default function Dashboard() {
                   <p className="text-gray-500 text-center py-8">No messages yet</p>
                 ) : (
                   notifications.map((notif) => (
-                    <div key={notif.id || Math.random().toString()} className=" bg-blue-50 p-4 rounded-r">
+                    <div key={notif.id || Math.random().toString()} className={`${priorityStyles[notif.priority ?? "normal"]} p-4 rounded-r`}>
                       <div className="flex justify-between items-start gap-2">
                         <div>
-                          <p className="font-semibold text-gray-800">{notif.from || "Unknown"}</p>
+                          <p className="font-semibold text-gray-800">
+                            {notif.from || "Unknown"}
+                            {notif.channel && <span className="ml-2 text-xs font-medium text-purple-700">#{notif.channel}</span>}
+                            {notif.priority === "critical" && <span className="ml-2 px-1.5 py-0.5 text-xs font-bold text-white bg-red-600 rounded">URGENT</span>}
+                            {notif.priority === "high" && <span className="ml-2 px-1.5 py-0.5 text-xs font-semibold text-orange-800 bg-orange-200 rounded">High</span>}
+                          </p>
                           <p className="text-gray-700">{notif.message || "No message"}</p>
                         </div>
                         <span className="text-xs text-gray-500 whitespace-nowrap">{notif.timestamp ? new Date(notif.timestamp).toLocaleTimeString() : "N/A"}</span>
@@ -226,6 +412,12 @@ export default function Dashboard() {
               </div>
             </div>
 
+            {/* Pending Scheduled Sends */}
+            <ScheduledPanel refreshKey={scheduledKey} />
+
+            {/* Recurring Schedules */}
+            <RecurringPanel />
+
             {/* Acknowledgments Status */}
             {acknowledgmentRequests.length > 0 && (
               <div className="bg-white rounded-lg  p-6">
@@ -237,10 +429,13 @@ export default function Dashboard() {
                       <div className="space-y-2">
                         {req.toUsernames.map((user) => {
                           const ackd = req.acknowledgedBy.includes(user);
+                          const response = req.responses?.[user];
                           return (
                             <div key={user} className="flex items-center gap-2">
                               <span className={`text-sm font-medium ${ackd ? "text-green-600" : "text-gray-500"}`}>{ackd ? "[Done]" : "[Waiting]"}</span>
                               <span className={ackd ? "text-green-600 line-through" : ""}>{user}</span>
+                              {response?.option && <span className="text-sm font-semibold text-gray-700">{response.option}</span>}
+                              {response?.comment && <span className="text-sm italic text-gray-500">"{response.comment}"</span>}
                             </div>
                           );
                         })}
@@ -255,9 +450,11 @@ export default function Dashboard() {
       </div>
 
       {/* Modals */}
-      <AcknowledgmentModal isOpen={showAckModal} onClose={() => setShowAckModal(false)} />
+      <AcknowledgmentModal isOpen={showAckModal} onClose={() => setShowAckModal(false)} onScheduled={() => setScheduledKey((key) => key + 1)} />
+
+      <ChangePasswordModal isOpen={showPasswordModal} onClose={() => setShowPasswordModal(false)} />
 
-      <AcknowledgmentRequestModal isOpen={!!incomingAckRequest} requestId={incomingAckRequest?.id ?? null} fromUsername={incomingAckRequest?.from ?? null} message={incomingAckRequest?.message ?? null} onClose={() => setIncomingAckRequest(null)} onAcknowledge={() => setIncomingAckRequest(null)} />
+      <AcknowledgmentRequestModal isOpen={!!incomingAckRequest} requestId={incomingAckRequest?.id ?? null} fromUsername={incomingAckRequest?.from ?? null} message={incomingAckRequest?.message ?? null} options={incomingAckRequest?.options} onClose={() => setIncomingAckRequest(null)} onAcknowledge={() => setIncomingAckRequest(null)} />
     </div>
   );
 }
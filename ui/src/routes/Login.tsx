@@ -1,15 +1,45 @@
-import React, { useState } from "react";
+import React, { useState, useEffect } from "react";
 import { useNavigate } from "react-router-dom";
+import { isAxiosError } from "axios";
 import { useAppStore } from "../store";
-import { postLogin } from "../api";
+import { postLogin, postRegister, getLoginMethods, getSession } from "../api";
+
+// Error messages by response status; anything else is a generic failure
+const loginErrors: Record<number, string> = {
+  401: "Invalid username or password",
+  429: "Too many failed attempts, try again later",
+};
+
+const registerErrors: Record<number, string> = {
+  400: "Usernames use letters, digits and _ . @ -; passwords need 8 to 72 characters",
+  409: "That username is taken or reserved",
+};
 
 export default function Login() {
   const [input, setInput] = useState("");
+  const [password, setPassword] = useState("");
+  const [registering, setRegistering] = useState(false);
   const [error, setError] = useState("");
   const [loading, setLoading] = useState(false);
+  const [sso, setSso] = useState(false);
   const navigate = useNavigate();
   const setUsername = useAppStore((state) => state.setUsername);
 
+  // Single sign-on ends with a redirect back here with the session cookie
+  // already set, so pick up an existing session before asking for a password
+  useEffect(() => {
+    getSession()
+      .then((session) => {
+        setUsername(session.username);
+        navigate("/dashboard");
+      })
+      .catch(() => {});
+
+    getLoginMethods()
+      .then((methods) => setSso(methods.oidc))
+      .catch((err) => console.error("Failed to fetch login methods:", err));
+  }, [navigate, setUsername]);
+
   const handleSubmit = async (e: React.FormEvent) => {
     e.preventDefault();
     setError("");
@@ -18,14 +48,25 @@ export default function Login() {
       setError("Username cannot be empty");
       return;
     }
+    if (!password) {
+      setError("Password cannot be empty");
+      return;
+    }
 
     setLoading(true);
     try {
-      await postLogin({ username: input });
+      // Registering also logs the user in
+      if (registering) {
+        await postRegister({ username: input, password });
+      } else {
+        await postLogin({ username: input, password });
+      }
       setUsername(input);
       navigate("/dashboard");
     } catch (err) {
-      setError("Login failed");
+      const status = isAxiosError(err) ? err.response?.status : undefined;
+      const messages = registering ? registerErrors : loginErrors;
+      setError((status && messages[status]) || (registering ? "Registration failed" : "Login failed"));
       console.error(err);
     } finally {
       setLoading(false);
@@ -46,15 +87,50 @@ export default function Login() {
               <label htmlFor="username" className="block text-sm font-medium text-gray-700 mb-2">
                 Username
               </label>
-              <input id="username" type="text" placeholder="Enter username" value={input} onChange={(e) => setInput(e.target.value)} className="form-input" disabled={loading} />
+              <input id="username" type="text" placeholder="Enter username" autoComplete="username" value={input} onChange={(e) => setInput(e.target.value)} className="form-input" disabled={loading} />
+            </div>
+
+            <div>
+              <label htmlFor="password" className="block text-sm font-medium text-gray-700 mb-2">
+                Password
+              </label>
+              <input id="password" type="password" placeholder={registering ? "At least 8 characters" : "Enter password"} autoComplete={registering ? "new-password" : "current-password"} value={password} onChange={(e) => setPassword(e.target.value)} className="form-input" disabled={loading} />
             </div>
 
             {error && <div className="bg-red-50 border border-red-200 text-red-700 px-4 py-3 rounded text-sm">{error}</div>}
 
             <button type="submit" disabled={loading} className="w-full btn-primary disabled:opacity-50 disabled:cursor-not-allowed">
-              {loading ? "Logging in..." : "Login"}
+              {loading ? (registering ? "Registering..." : "Logging in...") : registering ? "Register" : "Login"}
             </button>
           </form>
+
+          {sso && !registering && (
+            <>
+              <div className="my-4 flex items-center gap-2 text-xs text-gray-400">
+                <div className="flex-1 border-t" />
+                or
+                <div className="flex-1 border-t" />
+              </div>
+              {/* A full page navigation, since the provider's login page takes over */}
+              <a href="/api/oidc/login" className="block w-full text-center btn-secondary">
+                Sign in with SSO
+              </a>
+            </>
+          )}
+
+          <p className="mt-4 text-center text-sm text-gray-600">
+            {registering ? "Already have an account?" : "New here?"}{" "}
+            <button
+              type="button"
+              onClick={() => {
+                setRegistering(!registering);
+                setError("");
+              }}
+              className="text-blue-600 hover:underline"
+            >
+              {registering ? "Log in" : "Create an account"}
+            </button>
+          </p>
         </div>
       </div>
     </div>
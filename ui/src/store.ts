@@ -9,6 +9,21 @@ export interface Notification {
   from?: string
   message?: string
   timestamp?: string
+  channel?: string
+  priority?: 'low' | 'normal' | 'high' | 'critical'
+}
+
+export interface Channel {
+  name: string
+  memberCount: number
+  joined: boolean
+}
+
+export interface Group {
+  name: string
+  description?: string
+  members: string[]
+  createdBy: string
 }
 
 export interface AcknowledgmentRequest {
@@ -16,7 +31,9 @@ export interface AcknowledgmentRequest {
   fromUsername: string
   toUsernames: string[]
   message: string
+  options?: string[]
   acknowledgedBy: string[]
+  responses?: Record<string, { option?: string; comment?: string }>
 }
 
 interface AppState {
@@ -31,15 +48,24 @@ interface AppState {
   addUser: (username: string) => void
   removeUser: (username: string) => void
 
+  // Channels
+  channels: Channel[]
+  setChannels: (channels: Channel[]) => void
+
+  // Recipient groups
+  groups: Group[]
+  setGroups: (groups: Group[]) => void
+
   // Notifications
   notifications: Notification[]
   addNotification: (notification: Notification) => void
+  mergeNotificationHistory: (history: Notification[]) => void
   clearNotifications: () => void
 
   // Acknowledgment tracking
   acknowledgmentRequests: AcknowledgmentRequest[]
   addAcknowledgmentRequest: (request: AcknowledgmentRequest) => void
-  updateAcknowledgmentResponse: (requestId: string, username: string) => void
+  updateAcknowledgmentResponse: (requestId: string, username: string, option?: string, comment?: string) => void
   removeAcknowledgmentRequest: (requestId: string) => void
 
   // UI state
@@ -53,7 +79,7 @@ export const useAppStore = create<AppState>((set) => ({
   // User session
   username: null,
   setUsername: (name) => set({ username: name }),
-  logout: () => set({ username: null, users: [], notifications: [], acknowledgmentRequests: [] }),
+  logout: () => set({ username: null, users: [], channels: [], groups: [], notifications: [], acknowledgmentRequests: [] }),
 
   // Connected users list
   users: [],
@@ -65,11 +91,26 @@ export const useAppStore = create<AppState>((set) => ({
     users: state.users.filter((u) => u.username !== username)
   })),
 
+  // Channels
+  channels: [],
+  setChannels: (channels) => set({ channels }),
+
+  // Recipient groups
+  groups: [],
+  setGroups: (groups) => set({ groups }),
+
   // Notifications
   notifications: [],
   addNotification: (notification) => set((state) => ({
     notifications: [notification, ...state.notifications]
   })),
+  mergeNotificationHistory: (history) => set((state) => {
+    // Live events may arrive before the history request completes
+    const seen = new Set(state.notifications.map((n) => n.id))
+    return {
+      notifications: [...state.notifications, ...history.filter((n) => !seen.has(n.id))]
+    }
+  }),
   clearNotifications: () => set({ notifications: [] }),
 
   // Acknowledgment tracking
@@ -77,10 +118,14 @@ export const useAppStore = create<AppState>((set) => ({
   addAcknowledgmentRequest: (request) => set((state) => ({
     acknowledgmentRequests: [...state.acknowledgmentRequests, request]
   })),
-  updateAcknowledgmentResponse: (requestId, username) => set((state) => ({
+  updateAcknowledgmentResponse: (requestId, username, option, comment) => set((state) => ({
     acknowledgmentRequests: state.acknowledgmentRequests.map((req) =>
       req.id === requestId
-        ? { ...req, acknowledgedBy: [...req.acknowledgedBy, username] }
+        ? {
+            ...req,
+            acknowledgedBy: [...req.acknowledgedBy, username],
+            responses: { ...req.responses, [username]: { option, comment } },
+          }
         : req
     )
   })),